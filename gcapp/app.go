@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"slices"
 
+	appmodulev2 "cosmossdk.io/core/appmodule/v2"
 	"cosmossdk.io/core/registry"
 	"cosmossdk.io/core/transaction"
 	"cosmossdk.io/depinject"
@@ -19,6 +20,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/std"
+	"github.com/gordian-engine/gcosmos/gccrypto/gcblsminsig"
 )
 
 type GCApp struct {
@@ -55,6 +57,10 @@ func AppConfig() depinject.Config {
 		depinject.Invoke(
 			std.RegisterInterfaces,
 			std.RegisterLegacyAminoCodec,
+
+			// BLS consensus keys, for chains using aggregated BLS signatures.
+			gcblsminsig.RegisterInterfaces,
+			gcblsminsig.RegisterLegacyAminoCodec,
 		),
 	)
 }
@@ -65,6 +71,9 @@ func NewGCApp(config depinject.Config, outputs ...any) (*GCApp, error) {
 		config,
 		depinject.Supply(), // Are these necessary if empty?
 		depinject.Provide(),
+
+		// Only in the full app, as a client-only config has no staking keeper.
+		depinject.ProvideInModule(blsModuleName, ProvideBLSStakingHooks),
 	)
 
 	var app GCApp
@@ -91,6 +100,12 @@ func NewGCApp(config depinject.Config, outputs ...any) (*GCApp, error) {
 		return nil, fmt.Errorf("failed to dep inject: %w", err)
 	}
 
+	if err = appBuilder.RegisterModules(map[string]appmodulev2.AppModule{
+		blsModuleName: blsModule{},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register BLS module: %w", err)
+	}
+
 	app.App, err = appBuilder.Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build app: %w", err)
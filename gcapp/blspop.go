@@ -0,0 +1,136 @@
+package gcapp
+
+import (
+	"context"
+	"fmt"
+
+	appmodulev2 "cosmossdk.io/core/appmodule/v2"
+	"cosmossdk.io/math"
+	stakingkeeper "cosmossdk.io/x/staking/keeper"
+	stakingtypes "cosmossdk.io/x/staking/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/gordian-engine/gcosmos/gccrypto/gcblsminsig"
+)
+
+// blsModuleName is the depinject module scope of [ProvideBLSStakingHooks],
+// as staking hooks are collected per module,
+// and the name of the [blsModule] in the module manager.
+const blsModuleName = "gordian"
+
+// ProvideBLSStakingHooks provides staking hooks rejecting the creation of a validator
+// whose BLS consensus key lacks a valid proof of possession.
+// This covers validators in the staking genesis state,
+// genesis transactions, and create-validator transactions alike.
+//
+// Without the proof, a validator could register a key derived from other validators' keys,
+// and then forge aggregated commit signatures on their behalf.
+//
+// Consensus key rotations are checked by the [blsModule] instead,
+// because the staking module applies them at the end of the block,
+// where a hook error would halt the chain rather than reject the transaction.
+//
+// depinject requires this to be exported.
+func ProvideBLSStakingHooks(k *stakingkeeper.Keeper) stakingtypes.StakingHooksWrapper {
+	return stakingtypes.StakingHooksWrapper{StakingHooks: blsStakingHooks{k: k}}
+}
+
+// blsModule is an app module rejecting a consensus key rotation
+// to a BLS key that lacks a valid proof of possession,
+// before the staking module records the rotation,
+// so that a rotation cannot register a rogue key either.
+//
+// It has no state or configuration of its own,
+// so it is registered directly with the app builder rather than through the app config.
+type blsModule struct{}
+
+func (blsModule) IsAppModule()        {}
+func (blsModule) IsOnePerModuleType() {}
+
+// RegisterPreMsgHandlers implements [appmodulev2.HasPreMsgHandlers].
+// Pre-message handlers also run for messages nested in others, such as authz executions.
+func (blsModule) RegisterPreMsgHandlers(router appmodulev2.PreMsgRouter) {
+	appmodulev2.RegisterMsgPreHandler(
+		router,
+		gogoproto.MessageName(&stakingtypes.MsgRotateConsPubKey{}),
+		checkRotateConsPubKey,
+	)
+}
+
+func checkRotateConsPubKey(_ context.Context, msg *stakingtypes.MsgRotateConsPubKey) error {
+	if msg.NewPubkey == nil {
+		// The staking module rejects a missing key itself.
+		return nil
+	}
+	pk, ok := msg.NewPubkey.GetCachedValue().(cryptotypes.PubKey)
+	if !ok {
+		// Likewise for a value that is not a public key.
+		return nil
+	}
+	if err := gcblsminsig.ValidateConsensusPubKey(pk); err != nil {
+		return fmt.Errorf("invalid new consensus key for validator %s: %w", msg.ValidatorAddress, err)
+	}
+	return nil
+}
+
+// blsStakingHooks implements [stakingtypes.StakingHooks],
+// only acting on validator creation.
+type blsStakingHooks struct {
+	k *stakingkeeper.Keeper
+}
+
+func (h blsStakingHooks) AfterValidatorCreated(ctx context.Context, valAddr sdk.ValAddress) error {
+	v, err := h.k.GetValidator(ctx, valAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get created validator: %w", err)
+	}
+	pk, err := v.ConsPubKey()
+	if err != nil {
+		return fmt.Errorf("failed to get consensus key of created validator: %w", err)
+	}
+	if err := gcblsminsig.ValidateConsensusPubKey(pk); err != nil {
+		return fmt.Errorf("invalid consensus key for validator %s: %w", v.GetOperator(), err)
+	}
+	return nil
+}
+
+func (blsStakingHooks) BeforeValidatorModified(context.Context, sdk.ValAddress) error {
+	return nil
+}
+
+func (blsStakingHooks) AfterValidatorRemoved(context.Context, sdk.ConsAddress, sdk.ValAddress) error {
+	return nil
+}
+
+func (blsStakingHooks) AfterValidatorBonded(context.Context, sdk.ConsAddress, sdk.ValAddress) error {
+	return nil
+}
+
+func (blsStakingHooks) AfterValidatorBeginUnbonding(context.Context, sdk.ConsAddress, sdk.ValAddress) error {
+	return nil
+}
+
+func (blsStakingHooks) BeforeDelegationCreated(context.Context, sdk.AccAddress, sdk.ValAddress) error {
+	return nil
+}
+
+func (blsStakingHooks) BeforeDelegationSharesModified(context.Context, sdk.AccAddress, sdk.ValAddress) error {
+	return nil
+}
+
+func (blsStakingHooks) BeforeDelegationRemoved(context.Context, sdk.AccAddress, sdk.ValAddress) error {
+	return nil
+}
+
+func (blsStakingHooks) AfterDelegationModified(context.Context, sdk.AccAddress, sdk.ValAddress) error {
+	return nil
+}
+
+func (blsStakingHooks) BeforeValidatorSlashed(context.Context, sdk.ValAddress, math.LegacyDec) error {
+	return nil
+}
+
+func (blsStakingHooks) AfterConsensusPubKeyUpdate(context.Context, cryptotypes.PubKey, cryptotypes.PubKey, sdk.Coin) error {
+	return nil
+}
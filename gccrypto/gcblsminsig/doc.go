@@ -0,0 +1,12 @@
+// Package gcblsminsig adapts gordian's BLS12-381 minimized-signature keys
+// (see [github.com/gordian-engine/gordian/gcrypto/gblsminsig])
+// to the SDK public key interface,
+// so that validators can register BLS consensus keys through the staking module.
+//
+// Commit proofs for a validator set of BLS keys
+// can be aggregated into a single signature,
+// instead of growing linearly with the number of validators.
+// Because aggregation is vulnerable to keys crafted from other keys,
+// a key registered as a validator's consensus key must carry a proof of possession
+// of its secret key (see [ValidateConsensusPubKey]).
+package gcblsminsig
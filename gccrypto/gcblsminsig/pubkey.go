@@ -0,0 +1,199 @@
+package gcblsminsig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/core/registry"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gcrypto/gblsminsig"
+)
+
+const (
+	// KeyType is the type of the SDK public key.
+	// It deliberately matches the name of the key type
+	// in a [gcrypto.Registry] populated through [gblsminsig.Register],
+	// so that validator updates from the staking module
+	// can be decoded directly into gordian public keys.
+	KeyType = "bls-ms"
+
+	// PubKeyName is the amino name of the public key.
+	PubKeyName = "gordian/PubKeyBLSMinSig"
+
+	// PubKeySize is the size of a compressed G2 point.
+	PubKeySize = 96
+
+	// ProofOfPossessionSize is the size of a proof of possession,
+	// which is a signature, and therefore a compressed G1 point.
+	ProofOfPossessionSize = 48
+)
+
+var _ cryptotypes.PubKey = (*PubKey)(nil)
+
+// NewPubKey returns the SDK public key corresponding to
+// the gordian BLS minimized-signature public key pk.
+//
+// The returned key has no proof of possession,
+// so it cannot be used to register a validator;
+// use [NewPubKeyWithProof] for that.
+func NewPubKey(pk gblsminsig.PubKey) *PubKey {
+	return &PubKey{Key: pk.PubKeyBytes()}
+}
+
+// proofOfPossessionPrefix separates proofs of possession
+// from any other message signed with a BLS consensus key.
+const proofOfPossessionPrefix = "gcosmos/bls-proof-of-possession/v1\x00"
+
+// ProofOfPossessionMessage returns the message that the holder of the BLS key
+// with the given compressed bytes signs to prove possession of its secret key.
+func ProofOfPossessionMessage(key []byte) []byte {
+	b := make([]byte, 0, len(proofOfPossessionPrefix)+len(key))
+	b = append(b, proofOfPossessionPrefix...)
+	return append(b, key...)
+}
+
+// NewPubKeyWithProof returns the SDK public key for the BLS key of s,
+// with the proof of possession signed by s,
+// as required to register the key as a validator's consensus key.
+func NewPubKeyWithProof(ctx context.Context, s gcrypto.Signer) (*PubKey, error) {
+	pk, ok := s.PubKey().(gblsminsig.PubKey)
+	if !ok {
+		return nil, fmt.Errorf("signer has non-BLS public key of type %T", s.PubKey())
+	}
+
+	k := NewPubKey(pk)
+	pop, err := s.Sign(ctx, ProofOfPossessionMessage(k.Key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign proof of possession: %w", err)
+	}
+	k.ProofOfPossession = pop
+	return k, nil
+}
+
+// VerifyProofOfPossession returns an error
+// if k does not carry a valid proof of possession of its secret key.
+func (k *PubKey) VerifyProofOfPossession() error {
+	if len(k.ProofOfPossession) == 0 {
+		return errors.New("BLS public key has no proof of possession")
+	}
+	pk, err := k.GordianPubKey()
+	if err != nil {
+		return fmt.Errorf("invalid BLS public key: %w", err)
+	}
+	if !pk.Verify(ProofOfPossessionMessage(k.Key), k.ProofOfPossession) {
+		return errors.New("BLS public key has an invalid proof of possession")
+	}
+	return nil
+}
+
+// ValidateConsensusPubKey returns an error if pk may not be registered
+// as a validator's consensus key:
+// that is, if it is a BLS key without a valid proof of possession.
+// Keys of other types are not checked.
+func ValidateConsensusPubKey(pk cryptotypes.PubKey) error {
+	k, ok := pk.(*PubKey)
+	if !ok {
+		return nil
+	}
+	return k.VerifyProofOfPossession()
+}
+
+// GordianPubKey decodes k as a gordian public key,
+// returning an error if k does not hold a valid compressed G2 point.
+func (k *PubKey) GordianPubKey() (gcrypto.PubKey, error) {
+	return gblsminsig.NewPubKey(k.Key)
+}
+
+// Address is the truncated SHA-256 hash of the key,
+// in the same form as SDK ed25519 consensus addresses.
+func (k *PubKey) Address() cryptotypes.Address {
+	return cryptotypes.Address(tmhash.SumTruncated(k.Key))
+}
+
+func (k *PubKey) Bytes() []byte {
+	return k.Key
+}
+
+func (k *PubKey) VerifySignature(msg, sig []byte) bool {
+	pk, err := k.GordianPubKey()
+	if err != nil {
+		return false
+	}
+	return pk.Verify(msg, sig)
+}
+
+func (k *PubKey) Equals(other cryptotypes.PubKey) bool {
+	return k.Type() == other.Type() && bytes.Equal(k.Bytes(), other.Bytes())
+}
+
+func (k *PubKey) Type() string {
+	return KeyType
+}
+
+func (k *PubKey) String() string {
+	return fmt.Sprintf("PubKeyBLSMinSig{%X}", k.Key)
+}
+
+// MarshalAmino overrides the default amino encoding,
+// so that the key is encoded as its bare bytes like the other SDK consensus keys,
+// followed by the proof of possession, if there is one.
+// Dropping the proof would make a key signed in an amino JSON transaction
+// unusable as a consensus key.
+func (k PubKey) MarshalAmino() ([]byte, error) {
+	if len(k.ProofOfPossession) == 0 {
+		return k.Key, nil
+	}
+	if len(k.ProofOfPossession) != ProofOfPossessionSize {
+		return nil, fmt.Errorf(
+			"invalid BLS proof of possession size: got %d, expected %d",
+			len(k.ProofOfPossession), ProofOfPossessionSize,
+		)
+	}
+
+	b := make([]byte, 0, len(k.Key)+len(k.ProofOfPossession))
+	b = append(b, k.Key...)
+	return append(b, k.ProofOfPossession...), nil
+}
+
+// UnmarshalAmino overrides the default amino decoding.
+func (k *PubKey) UnmarshalAmino(bz []byte) error {
+	switch len(bz) {
+	case PubKeySize:
+		k.Key = bz
+		k.ProofOfPossession = nil
+	case PubKeySize + ProofOfPossessionSize:
+		k.Key = bz[:PubKeySize:PubKeySize]
+		k.ProofOfPossession = bz[PubKeySize:]
+	default:
+		return fmt.Errorf(
+			"invalid BLS public key size: got %d, expected %d, or %d with a proof of possession",
+			len(bz), PubKeySize, PubKeySize+ProofOfPossessionSize,
+		)
+	}
+	return nil
+}
+
+// MarshalAminoJSON overrides the default amino JSON encoding.
+func (k PubKey) MarshalAminoJSON() ([]byte, error) {
+	return k.MarshalAmino()
+}
+
+// UnmarshalAminoJSON overrides the default amino JSON decoding.
+func (k *PubKey) UnmarshalAminoJSON(bz []byte) error {
+	return k.UnmarshalAmino(bz)
+}
+
+// RegisterInterfaces registers [PubKey] as an implementation of the SDK public key interface,
+// so that it can be used as a validator consensus key in staking messages.
+func RegisterInterfaces(reg registry.InterfaceRegistrar) {
+	reg.RegisterImplementations((*cryptotypes.PubKey)(nil), &PubKey{})
+}
+
+// RegisterLegacyAminoCodec registers [PubKey] with the amino codec.
+func RegisterLegacyAminoCodec(reg registry.AminoRegistrar) {
+	reg.RegisterConcrete(&PubKey{}, PubKeyName)
+}
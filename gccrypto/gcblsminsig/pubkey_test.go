@@ -0,0 +1,146 @@
+package gcblsminsig_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/gordian-engine/gcosmos/gccrypto/gcblsminsig"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gcrypto/gblsminsig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubKey_gordianRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	s, err := gblsminsig.NewSigner(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	pk := gcblsminsig.NewPubKey(s.PubKey().(gblsminsig.PubKey))
+	require.Len(t, pk.Bytes(), gcblsminsig.PubKeySize)
+	require.Len(t, pk.Address(), 20)
+
+	msg := []byte("hello")
+	sig, err := s.Sign(context.Background(), msg)
+	require.NoError(t, err)
+	require.True(t, pk.VerifySignature(msg, sig))
+	require.False(t, pk.VerifySignature([]byte("other"), sig))
+
+	// The SDK key type must be decodable through a gordian registry,
+	// because that is how the driver turns validator updates into validators.
+	var reg gcrypto.Registry
+	gblsminsig.Register(&reg)
+	gpk, err := reg.Decode(pk.Type(), pk.Bytes())
+	require.NoError(t, err)
+	require.True(t, s.PubKey().Equal(gpk))
+}
+
+func TestPubKey_any(t *testing.T) {
+	t.Parallel()
+
+	s, err := gblsminsig.NewSigner(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	pk := gcblsminsig.NewPubKey(s.PubKey().(gblsminsig.PubKey))
+
+	ir := codectypes.NewInterfaceRegistry()
+	ir.RegisterInterface("cosmos.crypto.PubKey", (*cryptotypes.PubKey)(nil))
+	gcblsminsig.RegisterInterfaces(ir)
+
+	a, err := codectypes.NewAnyWithValue(pk)
+	require.NoError(t, err)
+	require.Equal(t, "/gordian.crypto.blsminsig.v1.PubKey", a.TypeUrl)
+
+	var got cryptotypes.PubKey
+	require.NoError(t, ir.UnpackAny(a, &got))
+	require.True(t, pk.Equals(got))
+}
+
+func TestPubKey_invalid(t *testing.T) {
+	t.Parallel()
+
+	pk := &gcblsminsig.PubKey{Key: bytes.Repeat([]byte{0xff}, gcblsminsig.PubKeySize)}
+	_, err := pk.GordianPubKey()
+	require.Error(t, err)
+	require.False(t, pk.VerifySignature([]byte("msg"), []byte("sig")))
+
+	require.Error(t, new(gcblsminsig.PubKey).UnmarshalAmino([]byte{1, 2, 3}))
+}
+
+func TestPubKey_proofOfPossession(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	s, err := gblsminsig.NewSigner(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+
+	pk, err := gcblsminsig.NewPubKeyWithProof(ctx, s)
+	require.NoError(t, err)
+	require.NoError(t, pk.VerifyProofOfPossession())
+	require.NoError(t, gcblsminsig.ValidateConsensusPubKey(pk))
+
+	// The proof survives the protobuf encoding used in staking messages and state.
+	b, err := pk.Marshal()
+	require.NoError(t, err)
+	var decoded gcblsminsig.PubKey
+	require.NoError(t, decoded.Unmarshal(b))
+	require.NoError(t, decoded.VerifyProofOfPossession())
+
+	// A key without a proof is rejected.
+	bare := gcblsminsig.NewPubKey(s.PubKey().(gblsminsig.PubKey))
+	require.Error(t, bare.VerifyProofOfPossession())
+	require.Error(t, gcblsminsig.ValidateConsensusPubKey(bare))
+
+	// As is a key carrying another key's proof.
+	other, err := gblsminsig.NewSigner(bytes.Repeat([]byte{4}, 32))
+	require.NoError(t, err)
+	stolen := gcblsminsig.NewPubKey(other.PubKey().(gblsminsig.PubKey))
+	stolen.ProofOfPossession = pk.ProofOfPossession
+	require.Error(t, gcblsminsig.ValidateConsensusPubKey(stolen))
+
+	// And a signature over the bare key bytes, without the proof's domain separation.
+	sig, err := s.Sign(ctx, pk.Key)
+	require.NoError(t, err)
+	undomained := gcblsminsig.NewPubKey(s.PubKey().(gblsminsig.PubKey))
+	undomained.ProofOfPossession = sig
+	require.Error(t, gcblsminsig.ValidateConsensusPubKey(undomained))
+
+	// Other key types need no proof.
+	require.NoError(t, gcblsminsig.ValidateConsensusPubKey(ed25519.GenPrivKey().PubKey()))
+}
+
+func TestPubKey_amino(t *testing.T) {
+	t.Parallel()
+
+	s, err := gblsminsig.NewSigner(bytes.Repeat([]byte{4}, 32))
+	require.NoError(t, err)
+
+	pk, err := gcblsminsig.NewPubKeyWithProof(context.Background(), s)
+	require.NoError(t, err)
+	require.Len(t, pk.ProofOfPossession, gcblsminsig.ProofOfPossessionSize)
+
+	// The proof survives the amino encoding, as used in amino JSON signing.
+	full, err := pk.MarshalAmino()
+	require.NoError(t, err)
+	require.Len(t, full, gcblsminsig.PubKeySize+gcblsminsig.ProofOfPossessionSize)
+	var decoded gcblsminsig.PubKey
+	require.NoError(t, decoded.UnmarshalAmino(full))
+	require.True(t, pk.Equals(&decoded))
+	require.NoError(t, decoded.VerifyProofOfPossession())
+
+	// A key without a proof is still encoded as its bare bytes.
+	bare := gcblsminsig.NewPubKey(s.PubKey().(gblsminsig.PubKey))
+	b, err := bare.MarshalAmino()
+	require.NoError(t, err)
+	require.Equal(t, bare.Key, b)
+	require.NoError(t, decoded.UnmarshalAmino(b))
+	require.Equal(t, bare.Key, decoded.Key)
+	require.Empty(t, decoded.ProofOfPossession)
+
+	// A truncated proof is rejected.
+	require.Error(t, decoded.UnmarshalAmino(full[:gcblsminsig.PubKeySize+1]))
+}
@@ -0,0 +1,49 @@
+package gserver
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// checkBlockDataURLs validates the values of the block data upload and public URL flags.
+// Both must be absolute HTTP or HTTPS URLs if set,
+// and the public URL is only meaningful with an upload URL.
+func checkBlockDataURLs(uploadURL, publicURL string) error {
+	if uploadURL == "" {
+		if publicURL != "" {
+			return fmt.Errorf("--%s requires --%s", blockDataPublicURLFlag, blockDataUploadURLFlag)
+		}
+		return nil
+	}
+
+	for flag, v := range map[string]string{
+		blockDataUploadURLFlag: uploadURL,
+		blockDataPublicURLFlag: publicURL,
+	} {
+		if v == "" {
+			continue
+		}
+
+		if err := checkHTTPURL(flag, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkHTTPURL validates the value v of the named flag
+// as an absolute HTTP or HTTPS URL.
+func checkHTTPURL(flag, v string) error {
+	u, err := url.Parse(v)
+	if err != nil {
+		return fmt.Errorf("invalid --%s %q: %w", flag, v, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid --%s %q: scheme must be http or https", flag, v)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid --%s %q: missing host", flag, v)
+	}
+	return nil
+}
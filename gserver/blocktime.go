@@ -0,0 +1,29 @@
+package gserver
+
+import (
+	"fmt"
+	"time"
+)
+
+// durationFlag returns the duration value of the named flag in cfg,
+// or def if the flag is not set.
+// Depending on its source, the value may be a time.Duration or a string.
+func durationFlag(cfg map[string]any, name string, def time.Duration) (time.Duration, error) {
+	switch v := cfg[name].(type) {
+	case nil:
+		return def, nil
+	case time.Duration:
+		return v, nil
+	case string:
+		if v == "" {
+			return def, nil
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value %q for %s: %w", v, name, err)
+		}
+		return d, nil
+	default:
+		return 0, fmt.Errorf("invalid type %T for %s", v, name)
+	}
+}
@@ -0,0 +1,39 @@
+package gserver
+
+import (
+	"path/filepath"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval"
+	"github.com/gordian-engine/gordian/gcrypto"
+)
+
+// blsKeyFileName is the name of the file, within the home config directory,
+// holding a validator's BLS consensus key.
+//
+// Comet's key file format has no BLS minimized-signature key type,
+// so the key lives alongside priv_validator_key.json rather than in it.
+const blsKeyFileName = "gordian_bls_key.json"
+
+// defaultBLSKeyPath returns the default BLS key path within homeDir.
+func defaultBLSKeyPath(homeDir string) string {
+	return filepath.Join(homeDir, "config", blsKeyFileName)
+}
+
+// loadValidatorSigner loads the validator's local signing key:
+// the BLS key if the chain aggregates BLS signatures,
+// or otherwise the ed25519 key in the Comet key file at cometKeyPath.
+func (c *Component) loadValidatorSigner(cfg map[string]any, gi genesisInfo, cometKeyPath string) (gcrypto.Signer, error) {
+	if gi.usesBLS() {
+		path, _ := cfg[blsKeyPathFlag].(string)
+		if path == "" {
+			path = defaultBLSKeyPath(c.homeDir)
+		}
+		return gprivval.LoadBLSKeyFile(path)
+	}
+
+	privKey, err := gprivval.LoadCometKeyFile(cometKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return gcrypto.NewEd25519Signer(privKey), nil
+}
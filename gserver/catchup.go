@@ -0,0 +1,67 @@
+package gserver
+
+import (
+	"fmt"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+)
+
+// Upper bound for the catchup size limit flags.
+// Anything larger is more likely a typo than a real header or block.
+const maxCatchupSizeLimit = 1 << 30
+
+// configureCatchup sets the catchup client options from the start flags in cfg.
+func (c *Component) configureCatchup(cfg map[string]any) error {
+	maxHeaderSize, err := uint64Flag(cfg, catchupMaxHeaderSizeFlag, gp2papi.DefaultMaxHeaderSize)
+	if err != nil {
+		return err
+	}
+	if maxHeaderSize == 0 || maxHeaderSize > maxCatchupSizeLimit {
+		return fmt.Errorf(
+			"--%s must be between 1 and %d (got %d)",
+			catchupMaxHeaderSizeFlag, maxCatchupSizeLimit, maxHeaderSize,
+		)
+	}
+	c.catchupMaxHeaderSize = int(maxHeaderSize)
+
+	maxBlockDataSize, err := uint64Flag(cfg, catchupMaxBlockDataSizeFlag, gp2papi.DefaultMaxBlockDataSize)
+	if err != nil {
+		return err
+	}
+	if maxBlockDataSize == 0 || maxBlockDataSize > maxCatchupSizeLimit {
+		return fmt.Errorf(
+			"--%s must be between 1 and %d (got %d)",
+			catchupMaxBlockDataSizeFlag, maxCatchupSizeLimit, maxBlockDataSize,
+		)
+	}
+	c.catchupMaxBlockDataSize = int(maxBlockDataSize)
+
+	c.catchupBlockDataURL, _ = cfg[catchupBlockDataURLFlag].(string)
+	if c.catchupBlockDataURL != "" {
+		if err := checkHTTPURL(catchupBlockDataURLFlag, c.catchupBlockDataURL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// catchupBlockDataSource returns the block data source for a header-only catchup client,
+// or nil if the catchup client should fetch block data from peers.
+func (c *Component) catchupBlockDataSource() gp2papi.BlockDataSource {
+	if c.catchupBlockDataURL == "" {
+		return nil
+	}
+
+	hc := gsbd.NewHTTPClient(nil, c.txc, c.catchupMaxBlockDataSize)
+	hc.SetZstdCodec(c.blockDataZstdCodec)
+
+	// Unlike the locations in proposals, the operator chose this URL,
+	// so it may be an archive on the operator's private network.
+	hc.AllowPrivateAddresses()
+	return gp2papi.HTTPBlockDataSource{
+		Client:  hc,
+		BaseURL: c.catchupBlockDataURL,
+	}
+}
@@ -0,0 +1,271 @@
+package gserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gscheme"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsi"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+)
+
+// genesisInfo is the subset of genesis.json that the component needs
+// before the SDK has parsed the rest.
+type genesisInfo struct {
+	ChainID string `json:"chain_id"`
+
+	// Optional top-level section for Gordian-specific chain parameters.
+	Gordian chainParams `json:"gordian"`
+}
+
+// chainParams are the Gordian-specific parameters recorded in genesis,
+// which must be identical on every node of a chain.
+//
+// Blank fields use their defaults,
+// so that a genesis file without a gordian section is still valid.
+type chainParams struct {
+	// Names of the schemes in the gscheme package.
+	HashScheme           string `json:"hash_scheme"`
+	SignatureScheme      string `json:"signature_scheme"`
+	SignatureProofScheme string `json:"signature_proof_scheme"`
+
+	// Name of the gsbd.Format for proposed block data.
+	BlockDataFormat string `json:"block_data_format"`
+
+	// Name of the compression for proposed block data,
+	// either "snappy" or "zstd".
+	BlockDataCompression string `json:"block_data_compression"`
+
+	// Optional zstd dictionary for block data, as produced by the train-dictionary command.
+	// Only valid with zstd compression.
+	// Encoded as base64 in genesis.
+	ZstdDictionary []byte `json:"zstd_dictionary"`
+
+	// Name of the gsbd.TxsHashScheme for the transactions hash in block data IDs.
+	// Transaction proofs require the Merkle scheme.
+	TxsHash string `json:"txs_hash"`
+
+	// Like Comet's create_empty_blocks: if false,
+	// proposers wait for transactions instead of proposing empty blocks.
+	// Unset means true.
+	CreateEmptyBlocks *bool `json:"create_empty_blocks"`
+
+	// Like Comet's create_empty_blocks_interval, as a Go duration such as "30s":
+	// how long a proposer waits for transactions in the first round of a height
+	// before proposing an empty block.
+	CreateEmptyBlocksInterval string `json:"create_empty_blocks_interval"`
+
+	// When create_empty_blocks is false, as a Go duration such as "5m":
+	// how much longer than usual every round waits for a proposal,
+	// while the proposer waits for transactions.
+	// An idle chain moves to a new round about this often.
+	NoEmptyBlocksWait string `json:"no_empty_blocks_wait"`
+}
+
+// readGenesisInfo parses the genesis file at path,
+// filling in defaults for any unset chain parameters.
+func readGenesisInfo(path string) (genesisInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return genesisInfo{}, fmt.Errorf("failed to open genesis file: %w", err)
+	}
+	defer f.Close()
+
+	var gi genesisInfo
+	if err := json.NewDecoder(f).Decode(&gi); err != nil {
+		return genesisInfo{}, fmt.Errorf("failed to parse JSON from genesis file at %s: %w", path, err)
+	}
+
+	if gi.Gordian.HashScheme == "" {
+		gi.Gordian.HashScheme = gscheme.DefaultHashSchemeName
+	}
+	if gi.Gordian.SignatureScheme == "" {
+		gi.Gordian.SignatureScheme = gscheme.DefaultSignatureSchemeName
+	}
+	if gi.Gordian.SignatureProofScheme == "" {
+		gi.Gordian.SignatureProofScheme = gscheme.DefaultProofSchemeName
+	}
+	if gi.Gordian.BlockDataFormat == "" {
+		// The original format, so that existing chains keep producing identical data.
+		gi.Gordian.BlockDataFormat = gsbd.JSONFormatName
+	}
+	if gi.Gordian.BlockDataCompression == "" {
+		gi.Gordian.BlockDataCompression = gsbd.SnappyCompressionName
+	}
+	if gi.Gordian.TxsHash == "" {
+		// The original hash, so that existing chains keep producing identical data IDs.
+		gi.Gordian.TxsHash = gsbd.Blake2bTxsHashName
+	}
+	if gi.Gordian.CreateEmptyBlocks == nil {
+		t := true
+		gi.Gordian.CreateEmptyBlocks = &t
+	}
+	if gi.Gordian.CreateEmptyBlocksInterval == "" {
+		gi.Gordian.CreateEmptyBlocksInterval = "0s"
+	}
+	if gi.Gordian.NoEmptyBlocksWait == "" {
+		gi.Gordian.NoEmptyBlocksWait = gsi.DefaultNoEmptyBlocksWait.String()
+	}
+
+	return gi, nil
+}
+
+// schemes returns the hash and signature schemes named in gi.
+func (gi genesisInfo) schemes() (tmconsensus.HashScheme, tmconsensus.SignatureScheme, error) {
+	hs, err := gscheme.HashSchemeByName(gi.Gordian.HashScheme)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid gordian.hash_scheme in genesis: %w", err)
+	}
+	ss, err := gscheme.SignatureSchemeByName(gi.Gordian.SignatureScheme, gi.ChainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid gordian.signature_scheme in genesis: %w", err)
+	}
+	return hs, ss, nil
+}
+
+// proofScheme returns the signature proof scheme named in gi,
+// and the validator key types compatible with it.
+func (gi genesisInfo) proofScheme() (gcrypto.CommonMessageSignatureProofScheme, []string, error) {
+	ps, err := gscheme.ProofSchemeByName(gi.Gordian.SignatureProofScheme)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid gordian.signature_proof_scheme in genesis: %w", err)
+	}
+	keyTypes, err := gscheme.ValidatorKeyTypes(gi.Gordian.SignatureProofScheme)
+	if err != nil {
+		panic(fmt.Errorf(
+			"BUG: no validator key types for known proof scheme %q: %w",
+			gi.Gordian.SignatureProofScheme, err,
+		))
+	}
+	return ps, keyTypes, nil
+}
+
+// blockDataFormat returns the block data format named in gi.
+func (gi genesisInfo) blockDataFormat() (gsbd.Format, error) {
+	f, err := gsbd.FormatByName(gi.Gordian.BlockDataFormat)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gordian.block_data_format in genesis: %w", err)
+	}
+	return f, nil
+}
+
+// txsHashScheme returns the transactions hash scheme named in gi.
+func (gi genesisInfo) txsHashScheme() (gsbd.TxsHashScheme, error) {
+	s, err := gsbd.TxsHashSchemeByName(gi.Gordian.TxsHash)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gordian.txs_hash in genesis: %w", err)
+	}
+	return s, nil
+}
+
+// emptyBlocks returns whether proposers create empty blocks,
+// how long they wait for transactions before doing so,
+// and how much longer rounds wait for proposals when they do not, as set in gi.
+func (gi genesisInfo) emptyBlocks() (create bool, interval, wait time.Duration, err error) {
+	interval, err = parseChainParamDuration("create_empty_blocks_interval", gi.Gordian.CreateEmptyBlocksInterval)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	wait, err = parseChainParamDuration("no_empty_blocks_wait", gi.Gordian.NoEmptyBlocksWait)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	return *gi.Gordian.CreateEmptyBlocks, interval, wait, nil
+}
+
+// parseChainParamDuration parses s as the non-negative duration
+// of the gordian chain parameter with the given name.
+func parseChainParamDuration(name, s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gordian.%s in genesis: %w", name, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf(
+			"invalid gordian.%s in genesis: must not be negative (got %s)",
+			name, d,
+		)
+	}
+	return d, nil
+}
+
+// canonicalDuration returns s in the canonical form of its duration,
+// so that equal durations written differently, such as "30s" and "30000ms",
+// have the same fingerprint.
+// An invalid duration is returned unchanged.
+func canonicalDuration(s string) string {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return s
+	}
+	return d.String()
+}
+
+// blockDataZstdCodec returns the zstd codec for block data described in gi,
+// or nil if the chain compresses block data with snappy.
+func (gi genesisInfo) blockDataZstdCodec() (*gsbd.ZstdCodec, error) {
+	switch gi.Gordian.BlockDataCompression {
+	case gsbd.SnappyCompressionName:
+		if len(gi.Gordian.ZstdDictionary) > 0 {
+			return nil, fmt.Errorf(
+				"gordian.zstd_dictionary in genesis requires gordian.block_data_compression %q",
+				gsbd.ZstdCompressionName,
+			)
+		}
+		return nil, nil
+	case gsbd.ZstdCompressionName:
+		zc, err := gsbd.NewZstdCodec(gi.Gordian.ZstdDictionary)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gordian.zstd_dictionary in genesis: %w", err)
+		}
+		return zc, nil
+	default:
+		return nil, fmt.Errorf(
+			"invalid gordian.block_data_compression in genesis: unknown compression %q (known compressions: %q, %q)",
+			gi.Gordian.BlockDataCompression, gsbd.SnappyCompressionName, gsbd.ZstdCompressionName,
+		)
+	}
+}
+
+// usesBLS reports whether validators sign with BLS keys
+// rather than Comet ed25519 keys.
+func (gi genesisInfo) usesBLS() bool {
+	return gi.Gordian.SignatureProofScheme == gscheme.ProofSchemeBLSMinSigName
+}
+
+// fingerprint describes the parameters that peers must agree on,
+// for the peer compatibility check.
+func (gi genesisInfo) fingerprint() string {
+	return strings.Join([]string{
+		"chain_id=" + gi.ChainID,
+		"hash_scheme=" + gi.Gordian.HashScheme,
+		"signature_scheme=" + gi.Gordian.SignatureScheme,
+		"signature_proof_scheme=" + gi.Gordian.SignatureProofScheme,
+		"block_data_format=" + gi.Gordian.BlockDataFormat,
+		"block_data_compression=" + gi.Gordian.BlockDataCompression,
+		// The dictionary is too large to include directly.
+		"zstd_dictionary_sha256=" + zstdDictionaryHash(gi.Gordian.ZstdDictionary),
+		"txs_hash=" + gi.Gordian.TxsHash,
+		"create_empty_blocks=" + strconv.FormatBool(*gi.Gordian.CreateEmptyBlocks),
+		"create_empty_blocks_interval=" + canonicalDuration(gi.Gordian.CreateEmptyBlocksInterval),
+		"no_empty_blocks_wait=" + canonicalDuration(gi.Gordian.NoEmptyBlocksWait),
+	}, ";")
+}
+
+// zstdDictionaryHash returns the hex-encoded SHA-256 hash of dict,
+// or the empty string if there is no dictionary.
+func zstdDictionaryHash(dict []byte) string {
+	if len(dict) == 0 {
+		return ""
+	}
+	h := sha256.Sum256(dict)
+	return hex.EncodeToString(h[:])
+}
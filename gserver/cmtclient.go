@@ -2,11 +2,11 @@ package gserver
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	abcitypes "github.com/cometbft/cometbft/abci/types"
 	cmtcryptoed25519 "github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/merkle"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtclient "github.com/cometbft/cometbft/rpc/client"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
@@ -172,9 +172,6 @@ func (c *Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultCo
 }
 
 func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.ResultTx, error) {
-	if prove {
-		panic(errors.New("TODO: prove is not yet supported"))
-	}
 	res, err := c.gclient.QueryTx(ctx, &ggrpc.SDKQueryTxRequest{
 		TxHash: hash,
 		Prove:  prove,
@@ -202,11 +199,12 @@ func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.Re
 		resEvents[i] = out
 	}
 
-	return &coretypes.ResultTx{
+	out := &coretypes.ResultTx{
 		Hash:   cmtbytes.HexBytes(res.TxHash),
 		Height: res.Height,
 
-		// TODO: what is Index supposed to be?
+		// Only set when proving, as it requires loading the block data.
+		Index: res.Index,
 
 		TxResult: abcitypes.ExecTxResult{
 			Code: res.Result.Code,
@@ -223,11 +221,24 @@ func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.Re
 		},
 
 		Tx: cmttypes.Tx(res.TxBytes),
+	}
 
-		Proof: cmttypes.TxProof{
-			// TODO: what are RootHash, Data, and Proof supposed to be?
-		},
-	}, nil
+	if p := res.Proof; p != nil {
+		// The leaves of the tree are the transaction hashes,
+		// so the Comet proof verifies against the hash of the transaction bytes.
+		out.Proof = cmttypes.TxProof{
+			RootHash: p.RootHash,
+			Data:     cmttypes.Tx(res.TxBytes),
+			Proof: merkle.Proof{
+				Total:    p.Total,
+				Index:    p.Index,
+				LeafHash: p.LeafHash,
+				Aunts:    p.Aunts,
+			},
+		}
+	}
+
+	return out, nil
 }
 
 func (c *Client) TxSearch(
@@ -2,13 +2,28 @@ package gserver
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/cometbft/cometbft/privval"
 	"github.com/cosmos/cosmos-sdk/client"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/gordian-engine/gcosmos/gccrypto/gcblsminsig"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gscheme"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gcrypto/gblsminsig"
 	"github.com/gordian-engine/gordian/tm/tmp2p/tmlibp2p"
 	"github.com/libp2p/go-libp2p"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
@@ -18,8 +33,10 @@ import (
 )
 
 func newSeedCommand() *cobra.Command {
-	return &cobra.Command{
-		Use:   "seed",
+	var nodeKeyPath, listenAddrs, announceAddrs, pskPath string
+
+	cmd := &cobra.Command{
+		Use:   "seed OUTPUT_FILE",
 		Short: `Run a "seed node" as a central discovery point for other libp2p nodes`,
 		Args:  cobra.ExactArgs(1),
 
@@ -27,18 +44,47 @@ func newSeedCommand() *cobra.Command {
 			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
+			opts := []libp2p.Option{
+				// Unsure if this is something we always want.
+				// Can be controlled by a flag later if undesirable by default.
+				libp2p.ForceReachabilityPublic(),
+			}
+
+			// Unless a key path is given, use a dynamic identity.
+			// Unlike the start command, the seed has no default key path,
+			// as it may be run from the same home directory as a validator.
+			if nodeKeyPath != "" {
+				nk, created, err := loadOrGenerateNodeKey(nodeKeyPath)
+				if err != nil {
+					return fmt.Errorf("failed to load node key: %w", err)
+				}
+				if created {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Generated new node key at %s\n", nodeKeyPath)
+				}
+				opts = append(opts, libp2p.Identity(nk))
+			}
+
+			addrOpts, err := hostAddrOptions(listenAddrs, announceAddrs)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, addrOpts...)
+
+			if pskPath != "" {
+				if err := checkPSKListenAddrs(listenAddrs); err != nil {
+					return err
+				}
+				psk, err := loadPSK(pskPath)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, libp2p.PrivateNetwork(psk))
+			}
+
 			h, err := tmlibp2p.NewHost(
 				ctx,
 				tmlibp2p.HostOptions{
-					Options: []libp2p.Option{
-						// Since unspecified, use a dynamic identity and a random listening address.
-						// Specify only tcp for the protocol, just for simplicity in stack traces.
-						libp2p.ListenAddrStrings("/ip4/0.0.0.0/tcp/0"),
-
-						// Unsure if this is something we always want.
-						// Can be controlled by a flag later if undesirable by default.
-						libp2p.ForceReachabilityPublic(),
-					},
+					Options: opts,
 				},
 			)
 			if err != nil {
@@ -50,10 +96,38 @@ func newSeedCommand() *cobra.Command {
 			// We currently rely on the libp2p DHT for peer discovery,
 			// so the seed node needs to run the DHT as well.
 			// The validators connect to the DHT through the tmlibp2p.Connection type.
-			if _, err := dht.New(ctx, host, dht.ProtocolPrefix("/gordian")); err != nil {
+			if _, err := dht.New(
+				ctx, host,
+				dht.ProtocolPrefix("/gordian"),
+				dht.Mode(dht.ModeServer),
+			); err != nil {
 				return fmt.Errorf("failed to create DHT peer for seed: %w", err)
 			}
 
+			// Every validator connects to the seed,
+			// so the seed relays validator bindings between them.
+			// It has no genesis, so it accepts bindings for any chain,
+			// and each validator checks the chain of the bindings it receives.
+			var reg gcrypto.Registry
+			gcrypto.RegisterEd25519(&reg)
+			gblsminsig.Register(&reg)
+			bx, err := gpeer.NewBindingExchange(
+				ctx,
+				slog.New(slog.NewTextHandler(cmd.ErrOrStderr(), nil)),
+				gpeer.BindingExchangeConfig{
+					Host:     host,
+					Registry: &reg,
+					Bindings: gpeer.NewValidatorBindings(""),
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("failed to start validator binding exchange for seed: %w", err)
+			}
+			defer func() {
+				cancel()
+				bx.Wait()
+			}()
+
 			hostInfo := libp2phost.InfoFromHost(host)
 			p2pAddrs, err := libp2ppeer.AddrInfoToP2pAddrs(hostInfo)
 			if err != nil {
@@ -78,6 +152,463 @@ func newSeedCommand() *cobra.Command {
 			return nil
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&nodeKeyPath, "node-key-path", "", "Path to the seed's libp2p identity key, created if missing; if blank, uses a new random identity")
+	// Specify only tcp for the default, just for simplicity in stack traces.
+	flags.StringVar(&listenAddrs, "listen-addrs", "/ip4/0.0.0.0/tcp/0", "Comma-separated multiaddrs to listen on; include e.g. /ip4/0.0.0.0/udp/26656/quic-v1 to accept QUIC connections")
+	flags.StringVar(&announceAddrs, "announce-addrs", "", "Comma-separated multiaddrs to advertise instead of the listen addresses; these are also written to the output file")
+	flags.StringVar(&pskPath, "psk-path", "", "Path to a libp2p private network key in swarm.key format, matching the --g-psk-path of the validators")
+
+	return cmd
+}
+
+func newSignerCommand() *cobra.Command {
+	var listenAddr, keyPath, blsKeyPath, statePath, genesisPath, nodeKeyPath, allowedPeerIDs string
+
+	cmd := &cobra.Command{
+		Use:   "signer",
+		Short: "Run a stand-in remote signer holding the validator key, for use with --g-remote-signer-addr",
+		Long: `Run a stand-in remote signer holding the validator key, for use with --g-remote-signer-addr.
+
+The signer refuses to sign anything conflicting with its last signature,
+as recorded in the state file.
+It reads the height, round, and step of each request from the sign bytes,
+using the chain ID and signature scheme from the genesis file.
+
+On a tcp:// address, the signer and its validators authenticate each other with mutual TLS.
+The signer identifies itself with the key at --node-key-path,
+whose peer ID it logs at startup for the validator's --g-remote-signer-peer-id,
+and it only accepts validators whose node peer IDs are in --allowed-peer-ids.
+
+It is intended for local testing;
+production deployments would typically use a signer backed by an HSM or KMS.`,
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			home := homeDirFromCmd(cmd)
+			if keyPath == "" {
+				cometConfig := client.GetConfigFromCmd(cmd)
+				cometConfig.RootDir = home
+				keyPath = cometConfig.PrivValidatorKeyFile()
+			}
+			if statePath == "" {
+				statePath = filepath.Join(home, "data", "gordian_signer_state.json")
+			}
+			if genesisPath == "" {
+				genesisPath = filepath.Join(home, "config", "genesis.json")
+			}
+
+			gi, err := readGenesisInfo(genesisPath)
+			if err != nil {
+				return err
+			}
+			parser, err := gscheme.SigningContentParserByName(gi.Gordian.SignatureScheme, gi.ChainID)
+			if err != nil {
+				return fmt.Errorf("invalid gordian.signature_scheme in genesis: %w", err)
+			}
+
+			var signer gcrypto.Signer
+			if blsKeyPath != "" {
+				s, err := gprivval.LoadBLSKeyFile(blsKeyPath)
+				if err != nil {
+					return err
+				}
+				signer = s
+			} else {
+				privKey, err := gprivval.LoadCometKeyFile(keyPath)
+				if err != nil {
+					return err
+				}
+				signer = gcrypto.NewEd25519Signer(privKey)
+			}
+
+			wm, err := gprivval.OpenWatermark(statePath)
+			if err != nil {
+				return err
+			}
+
+			network, address, err := gprivval.ParseAddr(listenAddr)
+			if err != nil {
+				return err
+			}
+
+			log := slog.New(slog.NewTextHandler(cmd.ErrOrStderr(), nil))
+
+			var tlsConfig *tls.Config
+			if network != "unix" {
+				var allowed []ed25519.PublicKey
+				for _, id := range splitFlagList(allowedPeerIDs) {
+					pub, err := peerIDEd25519(id)
+					if err != nil {
+						return fmt.Errorf("invalid --allowed-peer-ids: %w", err)
+					}
+					allowed = append(allowed, pub)
+				}
+				if len(allowed) == 0 {
+					return fmt.Errorf("--allowed-peer-ids is required to listen on %q", listenAddr)
+				}
+
+				if nodeKeyPath == "" {
+					nodeKeyPath = filepath.Join(home, "config", signerNodeKeyFileName)
+				}
+				nk, created, err := loadOrGenerateNodeKey(nodeKeyPath)
+				if err != nil {
+					return fmt.Errorf("failed to load signer node key: %w", err)
+				}
+				if created {
+					log.Info("Generated new signer node key", "path", nodeKeyPath)
+				}
+				id, err := libp2ppeer.IDFromPrivateKey(nk)
+				if err != nil {
+					return fmt.Errorf("failed to derive signer peer ID: %w", err)
+				}
+				key, err := nodeKeyEd25519(nk)
+				if err != nil {
+					return err
+				}
+				tlsConfig, err = gprivval.ServerTLSConfig(key, allowed)
+				if err != nil {
+					return fmt.Errorf("failed to build signer TLS configuration: %w", err)
+				}
+				log.Info("Validators must set the signer peer ID", "peer_id", id)
+			}
+
+			ln, err := net.Listen(network, address)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %q: %w", listenAddr, err)
+			}
+
+			var reg gcrypto.Registry
+			gcrypto.RegisterEd25519(&reg)
+			gblsminsig.Register(&reg)
+
+			s := gprivval.NewServer(ctx, log, gprivval.ServerConfig{
+				Listener:  ln,
+				Signer:    signer,
+				Parser:    parser,
+				Registry:  &reg,
+				Watermark: wm,
+				TLSConfig: tlsConfig,
+			})
+
+			log.Info("Signer listening", "addr", listenAddr, "state_path", statePath)
+
+			<-ctx.Done()
+			s.Wait()
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&listenAddr, "listen", "", "Address to accept validator connections on, as unix:///path/to/socket or tcp://host:port (authenticated with mutual TLS; see --allowed-peer-ids)")
+	flags.StringVar(&keyPath, "key-path", "", "Path to the Comet validator key file; defaults to the priv_validator_key_file in the home directory")
+	flags.StringVar(&blsKeyPath, "bls-key-path", "", "Path to a BLS key file (see the bls-key command) to sign with instead of the Comet validator key, for chains using the bls-minsig signature proof scheme")
+	flags.StringVar(&genesisPath, "genesis-path", "", "Path to the chain's genesis file, for the chain ID and signature scheme; defaults to config/genesis.json in the home directory")
+	flags.StringVar(&statePath, "state-path", "", "Path to the file recording the last signed height, round, and step; defaults to data/gordian_signer_state.json in the home directory")
+	flags.StringVar(&nodeKeyPath, "node-key-path", "", "Path to the key identifying the signer to validators over TCP, created if missing; defaults to config/"+signerNodeKeyFileName+" in the home directory")
+	flags.StringVar(&allowedPeerIDs, "allowed-peer-ids", "", "Comma-separated node peer IDs (see node-key show) of the validators allowed to connect over TCP; required with a tcp:// --listen address")
+	_ = cmd.MarkFlagRequired("listen")
+
+	return cmd
+}
+
+func newTrainDictionaryCommand() *cobra.Command {
+	var peerAddr, pskPath string
+	var start, end uint64
+	var maxSize int
+
+	cmd := &cobra.Command{
+		Use:   "train-dictionary OUTPUT_FILE",
+		Short: "Build a zstd dictionary for block data from the block data store of a running node",
+		Long: `Build a zstd dictionary for block data from the block data store of a running node.
+
+The block data for the given heights is fetched from the node at --peer,
+which should be a node you trust, such as one of your own.
+The dictionary is written to OUTPUT_FILE.
+
+To use the dictionary, set gordian.block_data_compression to "zstd"
+and gordian.zstd_dictionary to the base64-encoded contents of OUTPUT_FILE in genesis.
+Every node on the chain must use the same dictionary.
+
+If the chain already uses zstd compression,
+the genesis file in the home directory must describe the current dictionary,
+so that the existing block data can be decompressed.`,
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			if end < start {
+				return fmt.Errorf("--end %d must not be before --start %d", end, start)
+			}
+
+			gi, err := readGenesisInfo(filepath.Join(homeDirFromCmd(cmd), "config", "genesis.json"))
+			if err != nil {
+				return err
+			}
+			zc, err := gi.blockDataZstdCodec()
+			if err != nil {
+				return err
+			}
+
+			ai, err := libp2ppeer.AddrInfoFromString(peerAddr)
+			if err != nil {
+				return fmt.Errorf("invalid --peer address %q: %w", peerAddr, err)
+			}
+
+			// We only dial out, so there is no need to listen.
+			opts := []libp2p.Option{libp2p.NoListenAddrs}
+			if pskPath != "" {
+				psk, err := loadPSK(pskPath)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, libp2p.PrivateNetwork(psk))
+			}
+
+			host, err := libp2p.New(opts...)
+			if err != nil {
+				return fmt.Errorf("failed to create libp2p host: %w", err)
+			}
+			defer host.Close()
+
+			if err := host.Connect(ctx, *ai); err != nil {
+				return fmt.Errorf("failed to connect to peer: %w", err)
+			}
+
+			var blockData [][]byte
+			if err := gp2papi.FetchBlockDataRange(
+				ctx, host, ai.ID, start, end,
+				func(_ uint64, b []byte) error {
+					if len(b) > 0 {
+						blockData = append(blockData, b)
+					}
+					return nil
+				},
+			); err != nil {
+				return fmt.Errorf("failed to fetch block data: %w", err)
+			}
+
+			dict, err := gsbd.TrainZstdDictionary(blockData, zc, maxSize)
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(args[0], dict, 0644); err != nil {
+				return fmt.Errorf("failed to write dictionary file %q: %w", args[0], err)
+			}
+
+			fmt.Fprintf(
+				cmd.ErrOrStderr(),
+				"Wrote %d-byte zstd dictionary (sha256 %s) trained on %d blocks to %s\n",
+				len(dict), zstdDictionaryHash(dict), len(blockData), args[0],
+			)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&peerAddr, "peer", "", "Multiaddr, including the /p2p/ peer ID, of the node whose block data to train on")
+	flags.StringVar(&pskPath, "psk-path", "", "Path to a libp2p private network key in swarm.key format, if the node is on a private network")
+	flags.Uint64Var(&start, "start", 1, "First height of block data to train on")
+	flags.Uint64Var(&end, "end", 0, "Last height of block data to train on; must be committed on the peer")
+	flags.IntVar(&maxSize, "max-size", gsbd.DefaultZstdDictionarySize, "Maximum size in bytes of the dictionary")
+	_ = cmd.MarkFlagRequired("peer")
+	_ = cmd.MarkFlagRequired("end")
+
+	return cmd
+}
+
+func newNodeKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node-key",
+		Short: "Manage the libp2p identity key of this node",
+	}
+
+	cmd.AddCommand(newNodeKeyInitCommand(), newNodeKeyShowCommand(), newNodeKeyBindCommand())
+	return cmd
+}
+
+func newNodeKeyInitCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generate a new libp2p identity key and print its peer ID",
+		Args:  cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if path == "" {
+				path = defaultNodeKeyPath(homeDirFromCmd(cmd))
+			}
+
+			priv, err := generateNodeKey(path)
+			if err != nil {
+				return err
+			}
+
+			id, err := libp2ppeer.IDFromPrivateKey(priv)
+			if err != nil {
+				return fmt.Errorf("failed to derive peer ID: %w", err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote node key to %s\n", path)
+			fmt.Fprintln(cmd.OutOrStdout(), id.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "path", "", "Where to write the key; defaults to the node key path in the home config directory")
+	return cmd
+}
+
+func newNodeKeyBindCommand() *cobra.Command {
+	var nodeKeyPath, peerID, outPath, chainID, keyPath, blsKeyPath string
+
+	cmd := &cobra.Command{
+		Use:   "bind",
+		Short: "Sign a binding of this node's peer ID to the validator key",
+		Long: `Sign a binding of this node's peer ID to the validator key.
+
+Nodes send the binding to their peers, so that peers recognize the node as the validator's:
+validators started with --g-peer-allowlist=validators only accept connections
+from the nodes bound to the current validators,
+and proposers push erasure coded block data shards to those nodes.
+
+The node key stays separate from the validator key;
+run bind again after changing the node key, and restart the node.
+
+With a remote signer, run bind on the signer host,
+setting --peer-id to the output of node-key show on the validator node
+and --chain-id if the signer host has no genesis file,
+then copy the output file to the node binding path on the validator node.`,
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			home := homeDirFromCmd(cmd)
+
+			var usesBLS bool
+			if chainID == "" {
+				gi, err := readGenesisInfo(filepath.Join(home, "config", "genesis.json"))
+				if err != nil {
+					return fmt.Errorf("failed to read chain ID from genesis (set --chain-id instead): %w", err)
+				}
+				chainID = gi.ChainID
+				usesBLS = gi.usesBLS()
+			}
+
+			var id libp2ppeer.ID
+			if peerID != "" {
+				var err error
+				id, err = libp2ppeer.Decode(peerID)
+				if err != nil {
+					return fmt.Errorf("invalid --peer-id %q: %w", peerID, err)
+				}
+			} else {
+				if nodeKeyPath == "" {
+					nodeKeyPath = defaultNodeKeyPath(home)
+				}
+				priv, err := loadNodeKey(nodeKeyPath)
+				if err != nil {
+					return err
+				}
+				id, err = libp2ppeer.IDFromPrivateKey(priv)
+				if err != nil {
+					return fmt.Errorf("failed to derive peer ID: %w", err)
+				}
+			}
+
+			var signer gcrypto.Signer
+			if blsKeyPath != "" || usesBLS {
+				if blsKeyPath == "" {
+					blsKeyPath = defaultBLSKeyPath(home)
+				}
+				s, err := gprivval.LoadBLSKeyFile(blsKeyPath)
+				if err != nil {
+					return err
+				}
+				signer = s
+			} else {
+				if keyPath == "" {
+					cometConfig := client.GetConfigFromCmd(cmd)
+					cometConfig.RootDir = home
+					keyPath = cometConfig.PrivValidatorKeyFile()
+				}
+				privKey, err := gprivval.LoadCometKeyFile(keyPath)
+				if err != nil {
+					return err
+				}
+				signer = gcrypto.NewEd25519Signer(privKey)
+			}
+
+			b, err := gpeer.SignValidatorBinding(cmd.Context(), signer, chainID, id, time.Now())
+			if err != nil {
+				return err
+			}
+			j, err := json.MarshalIndent(b, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal validator binding: %w", err)
+			}
+
+			if outPath == "" {
+				outPath = defaultNodeBindingPath(home)
+			}
+			if err := os.WriteFile(outPath, append(j, '\n'), 0o644); err != nil {
+				return fmt.Errorf("failed to write validator binding file %q: %w", outPath, err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote binding of peer %s to validator key to %s\n", id, outPath)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&nodeKeyPath, "node-key-path", "", "Node key whose peer ID to bind; defaults to the node key path in the home config directory")
+	flags.StringVar(&peerID, "peer-id", "", "Peer ID to bind instead of reading the node key, e.g. when signing on a remote signer host")
+	flags.StringVar(&outPath, "output", "", "Where to write the binding; defaults to the node binding path in the home config directory (see --g-node-binding-path on the start command)")
+	flags.StringVar(&chainID, "chain-id", "", "Chain ID to bind on; defaults to the chain ID in the genesis file in the home directory")
+	flags.StringVar(&keyPath, "key-path", "", "Path to the Comet validator key file; defaults to the priv_validator_key_file in the home directory")
+	flags.StringVar(&blsKeyPath, "bls-key-path", "", "Path to a BLS key file to sign with instead of the Comet validator key; defaults to the BLS key path in the home config directory when genesis uses the bls-minsig signature proof scheme")
+
+	return cmd
+}
+
+func newNodeKeyShowCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the peer ID of the existing libp2p identity key",
+		Args:  cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if path == "" {
+				path = defaultNodeKeyPath(homeDirFromCmd(cmd))
+			}
+
+			priv, err := loadNodeKey(path)
+			if err != nil {
+				return err
+			}
+
+			id, err := libp2ppeer.IDFromPrivateKey(priv)
+			if err != nil {
+				return fmt.Errorf("failed to derive peer ID: %w", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), id.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "path", "", "Key file to read; defaults to the node key path in the home config directory")
+	return cmd
 }
 
 func newPrintValPubKeyCommand() *cobra.Command {
@@ -88,15 +619,7 @@ func newPrintValPubKeyCommand() *cobra.Command {
 
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cometConfig := client.GetConfigFromCmd(cmd)
-
-			// For some reason, the wiring for the home directory isn't being set directly.
-			if cometConfig.RootDir == "" {
-				var err error
-				cometConfig.RootDir, err = cmd.Flags().GetString("home")
-				if err != nil {
-					panic(err)
-				}
-			}
+			cometConfig.RootDir = homeDirFromCmd(cmd)
 
 			fpv := privval.LoadFilePV(cometConfig.PrivValidatorKeyFile(), cometConfig.PrivValidatorStateFile())
 
@@ -115,3 +638,105 @@ func newPrintValPubKeyCommand() *cobra.Command {
 		},
 	}
 }
+
+func newBLSKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bls-key",
+		Short: "Manage the BLS consensus key of a validator, for chains using aggregated BLS signatures",
+		Long: `Manage the BLS consensus key of a validator, for chains using aggregated BLS signatures.
+
+A chain uses BLS keys when its genesis sets gordian.signature_proof_scheme to "bls-minsig",
+in which case the staking module only accepts BLS consensus keys.
+Both init and show print the JSON for the public key,
+including the proof of possession of its secret key that registration requires,
+suitable to use in the pubkey field of the create-validator JSON,
+or in the --pubkey flag of gentx.`,
+	}
+
+	cmd.AddCommand(newBLSKeyInitCommand(), newBLSKeyShowCommand())
+	return cmd
+}
+
+func newBLSKeyInitCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generate a new BLS consensus key and print its public key JSON",
+		Args:  cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if path == "" {
+				path = defaultBLSKeyPath(homeDirFromCmd(cmd))
+			}
+
+			s, err := gprivval.GenerateBLSKeyFile(path)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote BLS key to %s\n", path)
+			return printBLSPubKey(cmd, s)
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "path", "", "Where to write the key; defaults to the BLS key path in the home config directory")
+	return cmd
+}
+
+func newBLSKeyShowCommand() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Print the public key JSON of the existing BLS consensus key",
+		Args:  cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if path == "" {
+				path = defaultBLSKeyPath(homeDirFromCmd(cmd))
+			}
+
+			s, err := gprivval.LoadBLSKeyFile(path)
+			if err != nil {
+				return err
+			}
+
+			return printBLSPubKey(cmd, s)
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "path", "", "Key file to read; defaults to the BLS key path in the home config directory")
+	return cmd
+}
+
+// printBLSPubKey prints the SDK JSON for the public key of s.
+// The key includes its proof of possession, which registration requires.
+func printBLSPubKey(cmd *cobra.Command, s gblsminsig.Signer) error {
+	sdkPK, err := gcblsminsig.NewPubKeyWithProof(cmd.Context(), s)
+	if err != nil {
+		return err
+	}
+
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	j, err := clientCtx.Codec.MarshalInterfaceJSON(sdkPK)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SDK key to JSON: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(j))
+	return nil
+}
+
+// homeDirFromCmd returns the home directory that cmd is running with.
+func homeDirFromCmd(cmd *cobra.Command) string {
+	if cometConfig := client.GetConfigFromCmd(cmd); cometConfig.RootDir != "" {
+		return cometConfig.RootDir
+	}
+
+	// For some reason, the wiring for the home directory isn't being set directly.
+	home, err := cmd.Flags().GetString("home")
+	if err != nil {
+		panic(err)
+	}
+	return home
+}
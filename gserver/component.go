@@ -2,8 +2,7 @@ package gserver
 
 import (
 	"context"
-	"crypto/ed25519"
-	"encoding/json"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -11,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	coreserver "cosmossdk.io/core/server"
 	"cosmossdk.io/core/transaction"
@@ -19,22 +19,23 @@ import (
 	"cosmossdk.io/server/v2/appmanager"
 	"cosmossdk.io/store/v2"
 	cometconfig "github.com/cometbft/cometbft/config"
-	"github.com/cometbft/cometbft/privval"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/gordian-engine/gcosmos/gcstore"
 	"github.com/gordian-engine/gcosmos/gcstore/gcmemstore"
 	"github.com/gordian-engine/gcosmos/gserver/internal/ggrpc"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsi"
 	"github.com/gordian-engine/gcosmos/txstore"
 	"github.com/gordian-engine/gcosmos/txstore/txmemstore"
 	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gcrypto/gblsminsig"
 	"github.com/gordian-engine/gordian/gdriver/gtxbuf"
 	"github.com/gordian-engine/gordian/gwatchdog"
 	"github.com/gordian-engine/gordian/tm/tmcodec/tmjson"
 	"github.com/gordian-engine/gordian/tm/tmconsensus"
-	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
 	"github.com/gordian-engine/gordian/tm/tmdriver"
 	"github.com/gordian-engine/gordian/tm/tmengine"
 	"github.com/gordian-engine/gordian/tm/tmengine/tmelink"
@@ -44,9 +45,12 @@ import (
 	"github.com/gordian-engine/gordian/tm/tmstore/tmmemstore"
 	"github.com/gordian-engine/tmsqlite"
 	"github.com/libp2p/go-libp2p"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
 	libp2pevent "github.com/libp2p/go-libp2p/core/event"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/pnet"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -74,8 +78,39 @@ type Component struct {
 	txc   transaction.Codec[transaction.Tx]
 	codec codec.Codec
 
+	// Selected through genesis.
+	hashScheme  tmconsensus.HashScheme
+	sigScheme   tmconsensus.SignatureScheme
+	proofScheme gcrypto.CommonMessageSignatureProofScheme
+
+	// Also selected through genesis.
+	// The zstd codec is nil when block data is compressed with snappy.
+	blockDataFormat    gsbd.Format
+	blockDataZstdCodec *gsbd.ZstdCodec
+	txsHashScheme      gsbd.TxsHashScheme
+
+	// The validator public key types that the staking module accepts,
+	// which must be compatible with the proof scheme.
+	validatorKeyTypes []string
+
+	// Describes the chain parameters that peers must share,
+	// for the compatibility check that starts with the libp2p host.
+	compatFingerprint string
+	compat            *gpeer.CompatCheck
+
 	signer tmconsensus.Signer
 
+	// Set when the signer is a remote process, so the connection can be closed on Stop.
+	remoteSigner *gprivval.Client
+
+	// Set when the startup double sign check is enabled,
+	// so that it can observe incoming consensus messages.
+	dsCheck *gprivval.DoubleSignCheck
+
+	// The signer guarding c.signer against double signs,
+	// which needs to observe the engine entering rounds.
+	guardedSigner *gprivval.GuardedSigner
+
 	// Partially set up during Init,
 	// then used during Start.
 	opts []tmengine.Opt
@@ -90,6 +125,65 @@ type Component struct {
 
 	seedAddrs string
 
+	// The persistent libp2p identity, if configured,
+	// and the listen and announce address options for the libp2p host.
+	nodeKey      libp2pcrypto.PrivKey
+	hostAddrOpts []libp2p.Option
+
+	// Tracks misbehaving peers across restarts.
+	peerRep *gpeer.Reputation
+
+	// Private network settings; all optional.
+	// The allowlist restricts which peers may connect,
+	// and the PSK encrypts all libp2p traffic with a shared network key.
+	peerAllowlist       *gpeer.Allowlist
+	allowValidatorPeers bool
+	psk                 pnet.PSK
+
+	// Which peers operate which validators, as learned from signed bindings.
+	// Our own binding is only set on validators that have one.
+	valBindings     *gpeer.ValidatorBindings
+	nodeBinding     *gpeer.ValidatorBinding
+	bindingExchange *gpeer.BindingExchange
+
+	// Sentry topology settings.
+	// A full node never signs, and it relays proposed block data while still retrieving it.
+	// Persistent peers, such as a validator's sentries, are redialed whenever disconnected.
+	// Disabling DHT advertisement keeps other peers from discovering this node,
+	// by running our DHT peer in client mode.
+	mode            string
+	persistentPeers []libp2ppeer.AddrInfo
+	dhtAdvertise    bool
+	pp              *gpeer.PersistentPeers
+	dht             *dht.IpfsDHT
+
+	// If set, proposed block data is also uploaded to this web server,
+	// and proposals point other nodes at the public URL.
+	blockDataUploadURL, blockDataPublicURL string
+
+	// If set, our proposed block data is erasure coded and its shards pushed to the validators.
+	blockDataErasure bool
+
+	// How many proposed block data retrievals may run concurrently.
+	blockDataFetchWorkers int
+
+	// Limits on the size of each committed header and block data
+	// that the catchup client accepts from peers.
+	catchupMaxHeaderSize, catchupMaxBlockDataSize int
+
+	// If set, the catchup client only fetches headers from peers,
+	// and it retrieves block data from this web server instead.
+	catchupBlockDataURL string
+
+	// How far proposed block times may be ahead of our clock.
+	maxClockDrift time.Duration
+
+	// Whether and how often to propose blocks without transactions.
+	// Selected through genesis.
+	createEmptyBlocks   bool
+	emptyBlocksInterval time.Duration
+	noEmptyBlocksWait   time.Duration
+
 	httpLn net.Listener
 	grpcLn net.Listener
 
@@ -106,6 +200,7 @@ type Component struct {
 	chs tmstore.CommittedHeaderStore
 	fs  tmstore.FinalizationStore
 	ms  tmstore.MirrorStore
+	rs  tmstore.RoundStore
 
 	// This is always separate from the consensus-layer store,
 	// as it is unique to the gcosmos integration.
@@ -123,8 +218,19 @@ type Config struct {
 	AppManager appmanager.AppManager[transaction.Tx]
 
 	ConfigMap coreserver.ConfigMap
+
+	// If set, the application prepares this node's proposed blocks
+	// and validates every proposed block, like ABCI's PrepareProposal and ProcessProposal.
+	// It must be safe for concurrent use.
+	ProposalHandler ProposalHandler
 }
 
+// ProposalHandler is the application's hook into building and validating proposed blocks.
+type ProposalHandler = gsi.ProposalHandler
+
+// ProposalInfo describes a proposed block to a [ProposalHandler].
+type ProposalInfo = gsi.ProposalInfo
+
 // NewComponent returns a new server component
 // ready to be supplied to the Cosmos SDK server module.
 //
@@ -157,6 +263,7 @@ func NewComponent(
 	c.rootCtx, c.cancel = context.WithCancelCause(rootCtx)
 
 	gcrypto.RegisterEd25519(c.reg)
+	gblsminsig.Register(c.reg)
 
 	return &c, nil
 }
@@ -230,11 +337,99 @@ func (c *Component) init(cfg map[string]any, log cosmoslog.Logger) error {
 		c.log.Warn("No seed addresses provided; relying on incoming connections to discover peers")
 	}
 
-	// Load the comet config, in order to read the privval key from disk.
-	// We don't really care about the state file,
-	// but we need to to call LoadFilePV,
-	// to get to the FilePVKey,
-	// which gives us the PrivKey.
+	// A blank path uses a new random identity on every start.
+	if nkPath, _ := cfg[nodeKeyPathFlag].(string); nkPath != "" {
+		nk, created, err := loadOrGenerateNodeKey(nkPath)
+		if err != nil {
+			return fmt.Errorf("failed to load node key: %w", err)
+		}
+		if created {
+			c.log.Info("Generated new node key", "path", nkPath)
+		}
+		c.nodeKey = nk
+	}
+
+	listenAddrs, _ := cfg[listenAddrsFlag].(string)
+	announceAddrs, _ := cfg[announceAddrsFlag].(string)
+	c.hostAddrOpts, err = hostAddrOptions(listenAddrs, announceAddrs)
+	if err != nil {
+		return fmt.Errorf("failed to parse libp2p addresses: %w", err)
+	}
+
+	mode, _ := cfg[modeFlag].(string)
+	c.mode, err = parseNodeMode(mode)
+	if err != nil {
+		return err
+	}
+
+	persistentPeers, _ := cfg[persistentPeersFlag].(string)
+	c.persistentPeers, err = parsePersistentPeers(persistentPeers)
+	if err != nil {
+		return fmt.Errorf("failed to parse persistent peers: %w", err)
+	}
+
+	c.dhtAdvertise, err = boolFlag(cfg, dhtAdvertiseFlag, true)
+	if err != nil {
+		return err
+	}
+
+	c.blockDataErasure, err = boolFlag(cfg, blockDataErasureFlag, false)
+	if err != nil {
+		return err
+	}
+
+	fetchWorkers, err := uint64Flag(cfg, blockDataFetchWorkersFlag, defaultBlockDataFetchWorkers)
+	if err != nil {
+		return err
+	}
+	if fetchWorkers == 0 || fetchWorkers > maxBlockDataFetchWorkers {
+		return fmt.Errorf(
+			"--%s must be between 1 and %d (got %d)",
+			blockDataFetchWorkersFlag, maxBlockDataFetchWorkers, fetchWorkers,
+		)
+	}
+	c.blockDataFetchWorkers = int(fetchWorkers)
+
+	if err := c.configureCatchup(cfg); err != nil {
+		return err
+	}
+
+	c.maxClockDrift, err = durationFlag(cfg, maxClockDriftFlag, gsi.DefaultMaxClockDrift)
+	if err != nil {
+		return err
+	}
+	if c.maxClockDrift <= 0 {
+		return fmt.Errorf("--%s must be positive (got %s)", maxClockDriftFlag, c.maxClockDrift)
+	}
+
+	c.blockDataUploadURL, _ = cfg[blockDataUploadURLFlag].(string)
+	c.blockDataPublicURL, _ = cfg[blockDataPublicURLFlag].(string)
+	if err := checkBlockDataURLs(c.blockDataUploadURL, c.blockDataPublicURL); err != nil {
+		return err
+	}
+
+	if pskPath, _ := cfg[pskPathFlag].(string); pskPath != "" {
+		if err := checkPSKListenAddrs(listenAddrs); err != nil {
+			return err
+		}
+		c.psk, err = loadPSK(pskPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	// A blank path keeps peer reputation in memory only.
+	repPath, _ := cfg[peerReputationPathFlag].(string)
+	c.peerRep, err = gpeer.NewReputation(
+		c.log.With("sys", "peer_reputation"),
+		gpeer.ReputationConfig{Path: repPath},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load peer reputation: %w", err)
+	}
+
+	// Load the comet config, in order to find the privval key on disk
+	// (and the genesis file, which the driver needs later).
 	cometConfig := cometconfig.DefaultConfig().SetRoot(c.homeDir)
 	if err := serverv2.UnmarshalSubConfig(cfg, "", &cometConfig); err != nil {
 		return fmt.Errorf("failed to unmarshal comet config (to get private key info): %w", err)
@@ -242,19 +437,125 @@ func (c *Component) init(cfg map[string]any, log cosmoslog.Logger) error {
 
 	c.genesisForDriver = filepath.Join(c.homeDir, cometConfig.Genesis)
 
-	fpv := privval.LoadFilePV(cometConfig.PrivValidatorKeyFile(), cometConfig.PrivValidatorStateFile())
-	privKey := fpv.Key.PrivKey
-	if privKey.Type() != "ed25519" {
-		panic(fmt.Errorf(
-			"gcosmos only understands ed25519 signing keys; got %q",
-			privKey.Type(),
-		))
+	// Is it possible for the genesis path to ever be rooted somewhere else?
+	gi, err := readGenesisInfo(filepath.Join(c.homeDir, "config", "genesis.json"))
+	if err != nil {
+		return err
+	}
+
+	// Store the chain ID on the component, because the driver needs it during Start.
+	c.chainID = gi.ChainID
+
+	c.valBindings = gpeer.NewValidatorBindings(c.chainID)
+	allowlist, _ := cfg[peerAllowlistFlag].(string)
+	c.peerAllowlist, c.allowValidatorPeers, err = parsePeerAllowlist(
+		allowlist, c.seedAddrs, libp2ppeer.AddrInfosToIDs(c.persistentPeers), c.valBindings,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to parse peer allowlist: %w", err)
 	}
 
-	// TODO: we should allow a way to explicitly NOT provide a signer.
-	c.signer = tmconsensus.PassthroughSigner{
-		Signer:          gcrypto.NewEd25519Signer(ed25519.PrivateKey(privKey.Bytes())),
-		SignatureScheme: tmconsensustest.SimpleSignatureScheme{},
+	c.hashScheme, c.sigScheme, err = gi.schemes()
+	if err != nil {
+		return err
+	}
+	c.proofScheme, c.validatorKeyTypes, err = gi.proofScheme()
+	if err != nil {
+		return err
+	}
+	c.blockDataFormat, err = gi.blockDataFormat()
+	if err != nil {
+		return err
+	}
+	c.blockDataZstdCodec, err = gi.blockDataZstdCodec()
+	if err != nil {
+		return err
+	}
+	c.txsHashScheme, err = gi.txsHashScheme()
+	if err != nil {
+		return err
+	}
+	c.createEmptyBlocks, c.emptyBlocksInterval, c.noEmptyBlocksWait, err = gi.emptyBlocks()
+	if err != nil {
+		return err
+	}
+	c.compatFingerprint = gi.fingerprint()
+	c.log.Info(
+		"Using consensus schemes from genesis",
+		"hash_scheme", gi.Gordian.HashScheme,
+		"signature_scheme", gi.Gordian.SignatureScheme,
+		"signature_proof_scheme", gi.Gordian.SignatureProofScheme,
+		"block_data_format", gi.Gordian.BlockDataFormat,
+		"block_data_compression", gi.Gordian.BlockDataCompression,
+		"zstd_dictionary_sha256", zstdDictionaryHash(gi.Gordian.ZstdDictionary),
+		"txs_hash", gi.Gordian.TxsHash,
+		"create_empty_blocks", c.createEmptyBlocks,
+		"create_empty_blocks_interval", c.emptyBlocksInterval,
+		"no_empty_blocks_wait", c.noEmptyBlocksWait,
+	)
+
+	// Full nodes have no signer at all, so they never propose or vote,
+	// and they never look at the privval key file.
+	// A validator must have a usable key;
+	// we would rather fail to start than silently run without signing.
+	remoteSignerAddr, _ := cfg[remoteSignerAddrFlag].(string)
+	switch c.mode {
+	case validatorNodeMode:
+		if remoteSignerAddr != "" {
+			tlsConfig, err := c.remoteSignerTLSConfig(cfg, remoteSignerAddr)
+			if err != nil {
+				return err
+			}
+
+			// The key lives in the signer process, so we never read the local key file.
+			rs, err := gprivval.NewClient(
+				c.rootCtx,
+				c.log.With("sys", "remote_signer"),
+				gprivval.ClientConfig{
+					Addr:            remoteSignerAddr,
+					TLSConfig:       tlsConfig,
+					Registry:        c.reg,
+					SignatureScheme: c.sigScheme,
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("failed to set up remote signer: %w", err)
+			}
+			c.remoteSigner = rs
+			c.signer = rs
+			break
+		}
+
+		signer, err := c.loadValidatorSigner(cfg, gi, cometConfig.PrivValidatorKeyFile())
+		if err != nil {
+			return fmt.Errorf(
+				"failed to load validator key (use --%s=%s to run without one): %w",
+				modeFlag, fullNodeMode, err,
+			)
+		}
+
+		c.signer = tmconsensus.PassthroughSigner{
+			Signer:          signer,
+			SignatureScheme: c.sigScheme,
+		}
+	case fullNodeMode:
+		if remoteSignerAddr != "" {
+			return fmt.Errorf("--%s cannot be used with --%s=%s", remoteSignerAddrFlag, modeFlag, fullNodeMode)
+		}
+		c.log.Info("Running as a full node without a validator key; will not propose or vote")
+	default:
+		panic(fmt.Errorf("BUG: unhandled node mode %q", c.mode))
+	}
+
+	if c.signer != nil {
+		nbPath, _ := cfg[nodeBindingPathFlag].(string)
+		if err := c.loadNodeBinding(nbPath); err != nil {
+			return err
+		}
+
+		if err := c.guardSigner(cfg); err != nil {
+			return err
+		}
 	}
 
 	if err := c.initializeSQLite(cfg[sqlitePathFlag].(string)); err != nil {
@@ -265,7 +566,7 @@ func (c *Component) init(cfg map[string]any, log cosmoslog.Logger) error {
 	c.bds = gcmemstore.NewBlockDataStore()
 
 	var as tmstore.ActionStore
-	var rs tmstore.RoundStore = c.tmsql
+	c.rs = c.tmsql
 	var sms tmstore.StateMachineStore = c.tmsql
 	var vs tmstore.ValidatorStore = c.tmsql
 
@@ -273,9 +574,9 @@ func (c *Component) init(cfg map[string]any, log cosmoslog.Logger) error {
 		if c.signer != nil {
 			as = tmmemstore.NewActionStore()
 		}
-		rs = tmmemstore.NewRoundStore()
+		c.rs = tmmemstore.NewRoundStore()
 		sms = tmmemstore.NewStateMachineStore()
-		vs = tmmemstore.NewValidatorStore(tmconsensustest.SimpleHashScheme{})
+		vs = tmmemstore.NewValidatorStore(c.hashScheme)
 
 		c.chs = tmmemstore.NewCommittedHeaderStore()
 		c.fs = tmmemstore.NewFinalizationStore()
@@ -290,28 +591,14 @@ func (c *Component) init(cfg map[string]any, log cosmoslog.Logger) error {
 		c.ms = c.tmsql
 	}
 
-	// Is it possible for the genesis path to ever be rooted somewhere else?
-	genesisPath := filepath.Join(c.homeDir, "config", "genesis.json")
-	gf, err := os.Open(genesisPath)
-	if err != nil {
-		return fmt.Errorf("failed to open genesis file to extract chain ID: %w", err)
-	}
-	defer gf.Close()
-
-	var cid struct {
-		ChainID string `json:"chain_id"`
+	if c.guardedSigner != nil {
+		// Until the double sign check passes, the engine does not participate,
+		// and it starts participating upon entering a new round.
+		sms = c.guardedSigner.StateMachineStore(sms)
 	}
-	if err := json.NewDecoder(gf).Decode(&cid); err != nil {
-		return fmt.Errorf("failed to parse JSON from genesis file at %s: %w", genesisPath, err)
-	}
-	// Even though we have a defer above, close it explicitly now that we are done parsing.
-	_ = gf.Close()
-
-	// Store the chain ID on the component, because the driver needs it during Start.
-	c.chainID = cid.ChainID
 
 	genesis := &tmconsensus.ExternalGenesis{
-		ChainID:         cid.ChainID,
+		ChainID:         c.chainID,
 		InitialHeight:   1,
 		InitialAppState: strings.NewReader(""), // No initial app state yet.
 		// TODO: where will GenesisValidators come from?
@@ -324,13 +611,13 @@ func (c *Component) init(cfg map[string]any, log cosmoslog.Logger) error {
 		tmengine.WithCommittedHeaderStore(c.chs),
 		tmengine.WithFinalizationStore(c.fs),
 		tmengine.WithMirrorStore(c.ms),
-		tmengine.WithRoundStore(rs),
+		tmengine.WithRoundStore(c.rs),
 		tmengine.WithStateMachineStore(sms),
 		tmengine.WithValidatorStore(vs),
 
-		tmengine.WithHashScheme(tmconsensustest.SimpleHashScheme{}),
-		tmengine.WithSignatureScheme(tmconsensustest.SimpleSignatureScheme{}),
-		tmengine.WithCommonMessageSignatureProofScheme(gcrypto.SimpleCommonMessageSignatureProofScheme{}),
+		tmengine.WithHashScheme(c.hashScheme),
+		tmengine.WithSignatureScheme(c.sigScheme),
+		tmengine.WithCommonMessageSignatureProofScheme(c.proofScheme),
 
 		tmengine.WithGenesis(genesis),
 
@@ -345,6 +632,44 @@ func (c *Component) init(cfg map[string]any, log cosmoslog.Logger) error {
 	return nil
 }
 
+// remoteSignerTLSConfig returns the TLS configuration to authenticate with the remote signer at addr,
+// or nil if addr is a Unix socket, which needs no further authentication.
+// Over TCP, the node presents its node key, and it pins the signer's key
+// from the peer ID given by the remote signer peer ID flag.
+func (c *Component) remoteSignerTLSConfig(cfg map[string]any, addr string) (*tls.Config, error) {
+	network, _, err := gprivval.ParseAddr(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", remoteSignerAddrFlag, err)
+	}
+	if network == "unix" {
+		return nil, nil
+	}
+
+	peerID, _ := cfg[remoteSignerPeerIDFlag].(string)
+	if peerID == "" {
+		return nil, fmt.Errorf("--%s is required with a TCP remote signer address", remoteSignerPeerIDFlag)
+	}
+	signerPub, err := peerIDEd25519(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", remoteSignerPeerIDFlag, err)
+	}
+
+	// The signer allows this node by its peer ID, so the identity must be stable.
+	if c.nodeKey == nil {
+		return nil, fmt.Errorf("a TCP remote signer requires a node key (see --%s)", nodeKeyPathFlag)
+	}
+	key, err := nodeKeyEd25519(c.nodeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := gprivval.ClientTLSConfig(key, signerPub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build remote signer TLS configuration: %w", err)
+	}
+	return tlsConfig, nil
+}
+
 func (c *Component) initializeSQLite(sqlitePath string) error {
 	// First special case: empty means don't set c.tmsql at all,
 	// and the rest of the Init method will use tmmemstore.
@@ -359,7 +684,7 @@ func (c *Component) initializeSQLite(sqlitePath string) error {
 	if sqlitePath == ":memory:" {
 		c.tmsql, err = tmsqlite.NewInMemStore(
 			c.rootCtx,
-			tmconsensustest.SimpleHashScheme{},
+			c.hashScheme,
 			c.reg,
 		)
 		if err != nil {
@@ -372,7 +697,7 @@ func (c *Component) initializeSQLite(sqlitePath string) error {
 	c.tmsql, err = tmsqlite.NewOnDiskStore(
 		c.rootCtx,
 		sqlitePath,
-		tmconsensustest.SimpleHashScheme{},
+		c.hashScheme,
 		c.reg,
 	)
 	if err != nil {
@@ -389,16 +714,39 @@ func (c *Component) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize gcosmos server component: %w", err)
 	}
 
+	hostOpts := []libp2p.Option{
+		// Refuse connections to and from banned peers,
+		// and to and from unlisted peers if we have an allowlist.
+		libp2p.ConnectionGater(gpeer.ConnectionGater{
+			Reputation: c.peerRep,
+			Allowlist:  c.peerAllowlist,
+		}),
+	}
+	if c.dhtAdvertise {
+		// Unsure if this is something we always want.
+		// Can be controlled by a flag later if undesirable by default.
+		//
+		// The DHT inside the tmlibp2p connection runs in automatic mode,
+		// switching to server mode when the host is publicly reachable,
+		// so this is only safe when we advertise ourselves anyway.
+		hostOpts = append(hostOpts, libp2p.ForceReachabilityPublic())
+	}
+	if c.nodeKey != nil {
+		hostOpts = append(hostOpts, libp2p.Identity(c.nodeKey))
+	}
+	if c.psk != nil {
+		hostOpts = append(hostOpts, libp2p.PrivateNetwork(c.psk))
+	}
+	if c.allowValidatorPeers {
+		c.allowLatestValidators(ctx)
+	}
+	// Listen and announce addresses, if any were set.
+	hostOpts = append(hostOpts, c.hostAddrOpts...)
+
 	h, err := tmlibp2p.NewHost(
 		c.rootCtx,
 		tmlibp2p.HostOptions{
-			Options: []libp2p.Option{
-				// No explicit listen address.
-
-				// Unsure if this is something we always want.
-				// Can be controlled by a flag later if undesirable by default.
-				libp2p.ForceReachabilityPublic(),
-			},
+			Options: hostOpts,
 		},
 	)
 	if err != nil {
@@ -406,7 +754,60 @@ func (c *Component) Start(ctx context.Context) error {
 	}
 	c.h = h
 
-	c.log.Info("Started libp2p host", "id", h.Libp2pHost().ID().String())
+	c.log.Info(
+		"Started libp2p host",
+		"id", h.Libp2pHost().ID().String(),
+		"addrs", h.Libp2pHost().Addrs(),
+	)
+
+	// The gater only refuses new connections,
+	// so drop any existing connections when a peer becomes banned.
+	c.peerRep.SetBanHook(func(p libp2ppeer.ID) {
+		_ = h.Libp2pHost().Network().ClosePeer(p)
+	})
+
+	// Refuse to stay connected to peers with different consensus schemes,
+	// whose messages we could never validate.
+	c.compat, err = gpeer.NewCompatCheck(
+		c.rootCtx,
+		c.log.With("sys", "compat_check"),
+		gpeer.CompatCheckConfig{
+			Host:        h.Libp2pHost(),
+			Fingerprint: c.compatFingerprint,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start peer compatibility check: %w", err)
+	}
+
+	// Learn which peers operate the validators, and tell peers which validator we are.
+	c.bindingExchange, err = gpeer.NewBindingExchange(
+		c.rootCtx,
+		c.log.With("sys", "binding_exchange"),
+		gpeer.BindingExchangeConfig{
+			Host:     h.Libp2pHost(),
+			Registry: c.reg,
+			Bindings: c.valBindings,
+			Own:      c.nodeBinding,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start validator binding exchange: %w", err)
+	}
+
+	if len(c.persistentPeers) > 0 {
+		c.pp, err = gpeer.NewPersistentPeers(
+			c.rootCtx,
+			c.log.With("sys", "persistent_peers"),
+			gpeer.PersistentPeersConfig{
+				Host:  h.Libp2pHost(),
+				Peers: c.persistentPeers,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to start persistent peers: %w", err)
+		}
+	}
 
 	for _, seedAddr := range strings.Split(c.seedAddrs, "\n") {
 		if seedAddr == "" {
@@ -435,11 +836,30 @@ func (c *Component) Start(ctx context.Context) error {
 	c.dh = gp2papi.NewDataHost(
 		c.rootCtx,
 		c.log.With("sys", "datahost"),
+		gp2papi.DataHostConfig{
+			Host:                 h.Libp2pHost(),
+			CommittedHeaderStore: c.chs,
+			BlockDataStore:       c.bds,
+			Codec:                codec,
+			CryptoRegistry:       c.reg,
+		},
+	)
+
+	// Only a DHT server is added to other peers' routing tables,
+	// so a DHT client lets us discover peers without being discovered.
+	dhtMode := dht.ModeServer
+	if !c.dhtAdvertise {
+		dhtMode = dht.ModeClient
+	}
+	c.dht, err = dht.New(
+		c.rootCtx,
 		h.Libp2pHost(),
-		c.chs,
-		c.bds,
-		codec,
+		dht.ProtocolPrefix("/gordian"),
+		dht.Mode(dhtMode),
 	)
+	if err != nil {
+		return fmt.Errorf("failed to create DHT peer: %w", err)
+	}
 
 	conn, err := tmlibp2p.NewConnection(
 		c.rootCtx,
@@ -467,9 +887,15 @@ func (c *Component) Start(ctx context.Context) error {
 		gp2papi.CatchupClientConfig{
 			Host:               h.Libp2pHost(),
 			Unmarshaler:        codec,
+			CryptoRegistry:     c.reg,
 			TxDecoder:          c.txc,
+			ZstdCodec:          c.blockDataZstdCodec,
 			RequestCache:       bdrCache,
 			ReplayedHeadersOut: rhCh,
+			MaxHeaderSize:      c.catchupMaxHeaderSize,
+			MaxBlockDataSize:   c.catchupMaxBlockDataSize,
+			BlockDataSource:    c.catchupBlockDataSource(),
+			PeerReporter:       c.peerRep,
 		},
 	)
 
@@ -502,6 +928,47 @@ func (c *Component) Start(ctx context.Context) error {
 	initChainCh := make(chan tmdriver.InitChainRequest)
 	blockFinCh := make(chan tmdriver.FinalizeBlockRequest)
 	lagStateCh := make(chan tmelink.LagState)
+
+	// The driver only updates the allowlist if validators are allowed to connect.
+	var driverAllowlist *gpeer.Allowlist
+	if c.allowValidatorPeers {
+		driverAllowlist = c.peerAllowlist
+	}
+
+	// Our proposals also point at the persistent peers,
+	// which relay our block data to anyone who cannot reach us directly.
+	bdProvider := gsbd.NewLibp2pProviderHost(
+		c.log.With("s_sys", "block_provider"), h.Libp2pHost(),
+	)
+	// Once the driver prunes the handlers for old proposals,
+	// their data is served from the block data store.
+	bdProvider.SetBlockDataStore(c.rootCtx, c.bds)
+	bdProvider.SetFormat(c.blockDataFormat)
+	bdProvider.SetZstdCodec(c.blockDataZstdCodec)
+	bdProvider.SetTxsHashScheme(c.txsHashScheme)
+	if c.blockDataUploadURL != "" {
+		bdProvider.SetHTTPPublisher(gsbd.NewHTTPPublisher(nil, c.blockDataUploadURL, c.blockDataPublicURL))
+	}
+	persistentPeerIDs := libp2ppeer.AddrInfosToIDs(c.persistentPeers)
+	bdProvider.SetRelayPeers(persistentPeerIDs)
+
+	// Every node holds and serves the shards that erasure-coding proposers push to it,
+	// but we only erasure code our own proposals if configured to.
+	bdShardHost := gsbd.NewErasureHost(c.log.With("s_sys", "block_shards"), bdProvider)
+	var blockDataProvider gsbd.Provider = bdProvider
+	if c.blockDataErasure {
+		blockDataProvider = bdShardHost
+	}
+
+	// Every node serves the proposed block data it retrieves,
+	// so that peers can fall back to it if the proposer is unreachable.
+	// Full nodes serve it while still retrieving it, so they can serve as sentries.
+	relayInFlight := c.mode == fullNodeMode
+
+	// The consensus strategy executes proposed blocks ahead of finalization,
+	// and the driver reuses that execution when the same block is finalized.
+	execCache := gsi.NewExecutionCache()
+
 	d, err := gsi.NewDriver(
 		c.rootCtx,
 		ctx,
@@ -527,6 +994,24 @@ func (c *Component) Start(ctx context.Context) error {
 
 			BlockDataRequestCache: bdrCache,
 			BlockDataStore:        c.bds,
+			BlockDataZstdCodec:    c.blockDataZstdCodec,
+
+			BlockDataHost:      bdProvider,
+			BlockDataShardHost: bdShardHost,
+
+			RoundStore: c.rs,
+			Host:       h.Libp2pHost(),
+
+			PeerAllowlist:     driverAllowlist,
+			ValidatorBindings: c.valBindings,
+
+			ExecutionCache: execCache,
+
+			CommittedHeaderStore: c.chs,
+			MaxClockDrift:        c.maxClockDrift,
+
+			CryptoRegistry:       c.reg,
+			ValidatorPubKeyTypes: c.validatorKeyTypes,
 		},
 	)
 	if err != nil {
@@ -548,13 +1033,36 @@ func (c *Component) Start(ctx context.Context) error {
 		tmengine.WithReplayedHeaderRequestChannel(rhCh),
 	)
 
+	// The consensus strategy stops waiting for transactions to propose
+	// once the proposal timeout elapses.
+	var ts tmengine.TimeoutStrategy = tmengine.LinearTimeoutStrategy{}
+	if !c.createEmptyBlocks || c.emptyBlocksInterval > 0 {
+		// Give proposers time to wait for transactions.
+		ts = gsi.EmptyBlocksTimeoutStrategy{
+			TimeoutStrategy: ts,
+			NoEmptyBlocks:   !c.createEmptyBlocks,
+			Interval:        c.emptyBlocksInterval,
+			Wait:            c.noEmptyBlocksWait,
+		}
+	}
+
 	// We needed the driver before we could make the consensus strategy.
 	csCfg := gsi.ConsensusStrategyConfig{
-		AppManager: c.config.AppManager,
-		TxBuf:      txBuf,
-		BlockDataProvider: gsbd.NewLibp2pProviderHost(
-			c.log.With("s_sys", "block_provider"), h.Libp2pHost(),
-		),
+		AppManager:        c.config.AppManager,
+		TxBuf:             txBuf,
+		BlockDataProvider: blockDataProvider,
+		TxsHashScheme:     c.txsHashScheme,
+
+		ChainID:        c.chainID,
+		Store:          c.config.RootStore,
+		ExecutionCache: execCache,
+
+		CommittedHeaderStore: c.chs,
+		MaxClockDrift:        c.maxClockDrift,
+
+		NoEmptyBlocks:      !c.createEmptyBlocks,
+		EmptyBlockInterval: c.emptyBlocksInterval,
+		TimeoutStrategy:    ts,
 
 		ProposedBlockDataRetriever: gsi.NewPBDRetriever(
 			ctx,
@@ -562,17 +1070,29 @@ func (c *Component) Start(ctx context.Context) error {
 			gsi.PBDRetrieverConfig{
 				RequestCache: bdrCache,
 				Decoder:      c.txc,
+				ZstdCodec:    c.blockDataZstdCodec,
 
 				Host: h.Libp2pHost(),
 
-				NWorkers: 4, // TODO: don't hardcode this.
+				NWorkers: c.blockDataFetchWorkers,
+
+				PeerReporter: c.peerRep,
+
+				Relay:         bdProvider,
+				RelayInFlight: relayInFlight,
+				FallbackPeers: persistentPeerIDs,
 			},
 		),
 
 		BlockDataRequestCache: bdrCache,
+
+		BlockDataShardHost: bdShardHost,
+		ValidatorBindings:  c.valBindings,
+
+		ProposalHandler: c.config.ProposalHandler,
 	}
 	if c.signer != nil {
-		csCfg.SignerPubKey = c.signer.PubKey()
+		csCfg.Signer = c.signer
 	}
 	c.cStrat = gsi.NewConsensusStrategy(
 		c.rootCtx,
@@ -594,7 +1114,7 @@ func (c *Component) Start(ctx context.Context) error {
 
 	// The timeout strategy pairs with a context,
 	// so it makes sense to delay this until we have a watchdog context available.
-	opts = append(opts, tmengine.WithTimeoutStrategy(wdCtx, tmengine.LinearTimeoutStrategy{}))
+	opts = append(opts, tmengine.WithTimeoutStrategy(wdCtx, ts))
 
 	e, err := tmengine.New(wdCtx, c.log.With("sys", "engine"), opts...)
 	if err != nil {
@@ -602,10 +1122,16 @@ func (c *Component) Start(ctx context.Context) error {
 	}
 	c.e = e
 
-	// Plain context here; if canceled, this will fail, which is fine.
-	conn.SetConsensusHandler(ctx, tmconsensus.AcceptAllValidFeedbackMapper{
+	var ch tmconsensus.ConsensusHandler = tmconsensus.AcceptAllValidFeedbackMapper{
 		Handler: e,
-	})
+	}
+	if c.dsCheck != nil {
+		// Inspect incoming messages for our own key until the check completes.
+		ch = c.dsCheck.Handler(ch)
+	}
+
+	// Plain context here; if canceled, this will fail, which is fine.
+	conn.SetConsensusHandler(ctx, ch)
 
 	if c.grpcLn != nil {
 		// TODO; share this with the http server as a wrapper.
@@ -615,6 +1141,10 @@ func (c *Component) Start(ctx context.Context) error {
 
 			MirrorStore:       c.ms,
 			FinalizationStore: c.fs,
+			TxStore:           c.txs,
+
+			BlockDataStore:     c.bds,
+			BlockDataZstdCodec: c.blockDataZstdCodec,
 
 			CryptoRegistry: c.reg,
 
@@ -643,6 +1173,10 @@ func (c *Component) Start(ctx context.Context) error {
 			Codec:      c.codec,
 
 			TxBuffer: txBuf,
+
+			TxStore:            c.txs,
+			BlockDataStore:     c.bds,
+			BlockDataZstdCodec: c.blockDataZstdCodec,
 		})
 	}
 
@@ -667,9 +1201,28 @@ func (c *Component) Stop(_ context.Context) error {
 	if c.cStrat != nil {
 		c.cStrat.Wait()
 	}
+	if c.pp != nil {
+		c.pp.Wait()
+	}
+	if c.compat != nil {
+		c.compat.Wait()
+	}
+	if c.bindingExchange != nil {
+		c.bindingExchange.Wait()
+	}
+	if c.remoteSigner != nil {
+		if err := c.remoteSigner.Close(); err != nil {
+			c.log.Warn("Error closing remote signer connection", "err", err)
+		}
+	}
 	if c.conn != nil {
 		c.conn.Disconnect()
 	}
+	if c.dht != nil {
+		if err := c.dht.Close(); err != nil {
+			c.log.Warn("Error closing DHT peer", "err", err)
+		}
+	}
 	if c.h != nil {
 		if err := c.h.Close(); err != nil {
 			c.log.Warn("Error closing tmp2p host", "err", err)
@@ -721,6 +1274,48 @@ const (
 	seedAddrsFlag = "g-seed-addrs"
 
 	sqlitePathFlag = "g-sqlite-path"
+
+	peerReputationPathFlag = "g-peer-reputation-path"
+
+	nodeKeyPathFlag     = "g-node-key-path"
+	nodeBindingPathFlag = "g-node-binding-path"
+	listenAddrsFlag     = "g-listen-addrs"
+	announceAddrsFlag   = "g-announce-addrs"
+
+	peerAllowlistFlag = "g-peer-allowlist"
+	pskPathFlag       = "g-psk-path"
+
+	modeFlag            = "g-mode"
+	persistentPeersFlag = "g-persistent-peers"
+	dhtAdvertiseFlag    = "g-dht-advertise"
+
+	blockDataUploadURLFlag = "g-block-data-upload-url"
+	blockDataPublicURLFlag = "g-block-data-public-url"
+	blockDataErasureFlag   = "g-block-data-erasure"
+
+	blockDataFetchWorkersFlag = "g-block-data-fetch-workers"
+
+	catchupMaxHeaderSizeFlag    = "g-catchup-max-header-size"
+	catchupMaxBlockDataSizeFlag = "g-catchup-max-block-data-size"
+	catchupBlockDataURLFlag     = "g-catchup-block-data-url"
+
+	maxClockDriftFlag = "g-max-clock-drift"
+
+	remoteSignerAddrFlag   = "g-remote-signer-addr"
+	remoteSignerPeerIDFlag = "g-remote-signer-peer-id"
+	blsKeyPathFlag         = "g-bls-key-path"
+
+	signStatePathFlag          = "g-sign-state-path"
+	doubleSignCheckHeightsFlag = "g-double-sign-check-heights"
+)
+
+// Bounds for the block data fetch workers flag.
+const (
+	defaultBlockDataFetchWorkers = 4
+
+	// Each worker may hold several streams open at once,
+	// so an arbitrary cap guards against a misplaced extra digit.
+	maxBlockDataFetchWorkers = 64
 )
 
 // StartCmdFlags satisfies the optional [serverv2.HasStartFlags] interface,
@@ -742,12 +1337,80 @@ func (c *Component) StartCmdFlags() *pflag.FlagSet {
 	defaultSQLitePath := filepath.Join(c.homeDir, "data", "gordian.sqlite")
 	flags.String(sqlitePathFlag, defaultSQLitePath, "Path to Gordian's consensus database; if blank, uses primitive in-memory store; if the exact string :memory:, uses SQLite in-memory database; otherwise path to on-disk SQLite database")
 
+	flags.String(nodeKeyPathFlag, defaultNodeKeyPath(c.homeDir), "Path to the node's libp2p identity key, created if missing (see also the node-key command); if blank, uses a new random identity on every start")
+	flags.String(nodeBindingPathFlag, defaultNodeBindingPath(c.homeDir), "Path to the validator's signed binding to this node's peer ID, created with the node-key bind command and sent to peers so they recognize this node as the validator's; ignored on full nodes")
+	flags.String(listenAddrsFlag, "", "Comma-separated multiaddrs for libp2p to listen on, e.g. /ip4/0.0.0.0/tcp/26656,/ip4/0.0.0.0/udp/26656/quic-v1; if blank, uses libp2p's default listen addresses on random ports")
+	flags.String(announceAddrsFlag, "", "Comma-separated multiaddrs to advertise to peers instead of the listen addresses, e.g. when behind NAT or a load balancer")
+
+	flags.String(peerAllowlistFlag, "", "Comma-separated peer IDs allowed to connect, plus the special entry \"validators\" to allow the current validators (each validator must sign a binding to its node with the node-key bind command); seed addresses are always allowed; if blank, any peer may connect")
+	flags.String(pskPathFlag, "", "Path to a libp2p private network key in swarm.key format; if set, only peers with the same key can connect, and QUIC listen addresses are not allowed")
+
+	flags.String(modeFlag, validatorNodeMode, "Node mode: \"validator\" signs with the validator key; \"full\" follows the chain without signing and relays proposed block data, e.g. as a sentry")
+	flags.String(persistentPeersFlag, "", "Comma-separated multiaddrs, including /p2p/ peer IDs, to stay connected to, redialing on disconnect (e.g. a validator's sentries); persistent peers are implicitly allowed and relay our proposed block data")
+	flags.String(remoteSignerAddrFlag, "", "Address of a remote signer holding the validator key, as unix:///path/to/socket or tcp://host:port (TCP requires --"+remoteSignerPeerIDFlag+" and a node key); if blank, signs with the local Comet validator key file (see also the gordian signer command)")
+	flags.String(remoteSignerPeerIDFlag, "", "Peer ID of the remote signer (as logged by the gordian signer command), to authenticate it with mutual TLS over tcp://; the signer must allow this node's peer ID")
+	flags.String(blsKeyPathFlag, defaultBLSKeyPath(c.homeDir), "Path to the validator's BLS key file (see the bls-key command); only used instead of the Comet validator key when genesis sets gordian.signature_proof_scheme to \"bls-minsig\"")
+	flags.Bool(dhtAdvertiseFlag, true, "Advertise this node in the DHT; set false on validators behind sentries so that other peers cannot discover them")
+
+	flags.String(blockDataUploadURLFlag, "", "HTTP(S) base URL to upload our proposed block data to with PUT requests, so that proposals also point at a web server or CDN; if blank, block data is only served over libp2p")
+	flags.String(blockDataPublicURLFlag, "", "HTTP(S) base URL from which other nodes retrieve the block data uploaded to --"+blockDataUploadURLFlag+", such as a CDN in front of it; if blank, the upload URL is used")
+
+	flags.Bool(blockDataErasureFlag, false, "Erasure code our proposed block data and push a shard to each validator, so that validators reconstruct it from each other instead of all fetching it from us; only validators that have bound their node to their validator key (see node-key bind) can hold shards")
+
+	flags.Uint64(blockDataFetchWorkersFlag, defaultBlockDataFetchWorkers, "How many proposed blocks' data may be retrieved concurrently; each retrieval races the proposer's locations and any connected peers that have the data")
+
+	flags.Uint64(catchupMaxHeaderSizeFlag, gp2papi.DefaultMaxHeaderSize, "Maximum encoded size in bytes of a committed header received while catching up; raise it for chains with very large validator sets or annotations")
+	flags.Uint64(catchupMaxBlockDataSizeFlag, gp2papi.DefaultMaxBlockDataSize, "Maximum encoded size in bytes of a block's data received while catching up")
+	flags.String(catchupBlockDataURLFlag, "", "HTTP(S) base URL of an archive of block data, laid out as by --"+blockDataUploadURLFlag+"; if set, catch up in header-only mode, fetching only headers from peers and each block's data from this URL")
+
+	flags.Duration(maxClockDriftFlag, gsi.DefaultMaxClockDrift, "How far a proposed block's time may be ahead of the local clock; validators ignore proposals further ahead until their clock catches up")
+
+	defaultSignStatePath := filepath.Join(c.homeDir, "data", "gordian_sign_state.json")
+	flags.String(signStatePathFlag, defaultSignStatePath, "Path to the file recording the last height, round, and step signed by the validator key, so that a restart never signs a conflicting message; if blank, only kept in memory")
+	flags.Uint64(doubleSignCheckHeightsFlag, 0, "If positive, do not sign until this many heights of consensus messages have been observed without our validator key signing any of them, to detect another process running with the same key; if our key is seen, never sign (requires other validators to make progress)")
+
+	defaultPeerReputationPath := filepath.Join(c.homeDir, "data", "peer_reputation.json")
+	flags.String(peerReputationPathFlag, defaultPeerReputationPath, "Path to the file tracking misbehaving peers and bans across restarts; if blank, peer reputation is only kept in memory")
+
 	// Adds --g-assert-rules in debug builds, no-op otherwise.
 	addAssertRuleFlag(flags)
 
 	return flags
 }
 
+// allowLatestValidators adds the most recently finalized validator set
+// to the peer allowlist.
+// Otherwise, after a restart, the allowlist would not include the validators
+// until the driver handled a finalization,
+// which it cannot do without catching up from those same validators.
+func (c *Component) allowLatestValidators(ctx context.Context) {
+	_, _, committingH, _, err := c.ms.NetworkHeightRound(ctx)
+	if err != nil || committingH == 0 {
+		// Most likely a fresh chain, in which case
+		// the driver will set the genesis validators.
+		return
+	}
+
+	// The committing height may not have been finalized yet,
+	// in which case the previous height must have been.
+	for _, h := range []uint64{committingH, committingH - 1} {
+		if h == 0 {
+			break
+		}
+		_, _, valSet, _, err := c.fs.LoadFinalizationByHeight(ctx, h)
+		if err != nil {
+			continue
+		}
+		c.peerAllowlist.SetValidators(valSet.Validators)
+		return
+	}
+
+	c.log.Warn(
+		"Failed to load recent validators for peer allowlist",
+		"committing_height", committingH,
+	)
+}
+
 // WriteCustomConfigAt satisfies an undocumented interface,
 // and here we emulate what Comet does in order to get past some error expecting this file to exist.
 func (c *Component) WriteCustomConfigAt(configPath string) error {
@@ -764,6 +1427,10 @@ func (c *Component) CLICommands() serverv2.CLIConfig {
 			// These commands are all declared in commands.go.
 			newSeedCommand(),
 			newPrintValPubKeyCommand(),
+			newNodeKeyCommand(),
+			newBLSKeyCommand(),
+			newSignerCommand(),
+			newTrainDictionaryCommand(),
 		},
 	}
 }
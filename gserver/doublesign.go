@@ -0,0 +1,87 @@
+package gserver
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval"
+)
+
+// guardSigner wraps c.signer so that it never signs a message
+// conflicting with one it signed before a restart,
+// and, if configured, so that it does not sign at all
+// until it has watched the network for a few heights
+// without seeing our key in use elsewhere.
+func (c *Component) guardSigner(cfg map[string]any) error {
+	statePath, _ := cfg[signStatePathFlag].(string)
+	wm, err := gprivval.OpenWatermark(statePath)
+	if err != nil {
+		return fmt.Errorf("failed to open sign state: %w", err)
+	}
+	if statePath == "" {
+		c.log.Warn(
+			"Sign state is only kept in memory; restarting with a wiped consensus store could cause a double sign",
+			"flag", signStatePathFlag,
+		)
+	}
+
+	checkHeights, err := uint64Flag(cfg, doubleSignCheckHeightsFlag, 0)
+	if err != nil {
+		return err
+	}
+	if checkHeights > 0 {
+		c.dsCheck = gprivval.NewDoubleSignCheck(
+			c.log.With("sys", "double_sign_check"),
+			gprivval.DoubleSignCheckConfig{
+				PubKey:          c.signer.PubKey(),
+				SignatureScheme: c.sigScheme,
+				Watermark:       wm,
+				Heights:         checkHeights,
+			},
+		)
+	}
+
+	c.guardedSigner = gprivval.NewGuardedSigner(gprivval.GuardedSignerConfig{
+		Signer:          c.signer,
+		SignatureScheme: c.sigScheme,
+		Watermark:       wm,
+		Check:           c.dsCheck,
+	})
+	c.signer = c.guardedSigner
+	return nil
+}
+
+// uint64Flag returns the unsigned integer value of the named flag in cfg,
+// or def if the flag is not set.
+// Depending on its source, the value may be an integer or a string.
+func uint64Flag(cfg map[string]any, name string, def uint64) (uint64, error) {
+	switch v := cfg[name].(type) {
+	case nil:
+		return def, nil
+	case uint64:
+		return v, nil
+	case uint:
+		return uint64(v), nil
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("invalid negative value %d for %s", v, name)
+		}
+		return uint64(v), nil
+	case int64:
+		if v < 0 {
+			return 0, fmt.Errorf("invalid negative value %d for %s", v, name)
+		}
+		return uint64(v), nil
+	case string:
+		if v == "" {
+			return def, nil
+		}
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value %q for %s: %w", v, name, err)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("invalid type %T for %s", v, name)
+	}
+}
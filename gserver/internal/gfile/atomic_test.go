@@ -0,0 +1,33 @@
+package gfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gfile"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sub", "state.json")
+
+	// The parent directory is created on first write.
+	require.NoError(t, gfile.WriteAtomic(path, []byte("first")))
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "first", string(got))
+
+	require.NoError(t, gfile.WriteAtomic(path, []byte("second")))
+	got, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "second", string(got))
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
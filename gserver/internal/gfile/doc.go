@@ -0,0 +1,3 @@
+// Package gfile contains file helpers shared by the gserver packages
+// that persist small state files outside of the SQLite database.
+package gfile
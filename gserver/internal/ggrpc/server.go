@@ -9,6 +9,8 @@ import (
 	"cosmossdk.io/core/transaction"
 	"cosmossdk.io/server/v2/appmanager"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/gordian-engine/gcosmos/gcstore"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsi"
 	"github.com/gordian-engine/gcosmos/txstore"
 	"github.com/gordian-engine/gordian/gcrypto"
@@ -28,6 +30,10 @@ type GordianGRPC struct {
 	ms  tmstore.MirrorStore
 	txs txstore.Store
 
+	// For proving transaction inclusion.
+	bds       gcstore.BlockDataStore
+	zstdCodec *gsbd.ZstdCodec
+
 	reg *gcrypto.Registry
 
 	// debug handler
@@ -46,6 +52,11 @@ type GRPCServerConfig struct {
 	MirrorStore       tmstore.MirrorStore
 	TxStore           txstore.Store
 
+	// Where to load block data from when proving transaction inclusion,
+	// and how to decompress it if the chain uses zstd compression.
+	BlockDataStore     gcstore.BlockDataStore
+	BlockDataZstdCodec *gsbd.ZstdCodec
+
 	CryptoRegistry *gcrypto.Registry
 
 	TxCodec    transaction.Codec[transaction.Tx]
@@ -67,6 +78,9 @@ func NewGordianGRPCServer(ctx context.Context, log *slog.Logger, cfg GRPCServerC
 		ms:  cfg.MirrorStore,
 		txs: cfg.TxStore,
 
+		bds:       cfg.BlockDataStore,
+		zstdCodec: cfg.BlockDataZstdCodec,
+
 		reg:   cfg.CryptoRegistry,
 		txc:   cfg.TxCodec,
 		am:    cfg.AppManager,
@@ -3,11 +3,13 @@ package ggrpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"cosmossdk.io/core/event"
 	coreserver "cosmossdk.io/core/server"
 	banktypes "cosmossdk.io/x/bank/types"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 )
 
 // This file contains methods on GordianGRPC that satisfy
@@ -134,17 +136,46 @@ func (g *GordianGRPC) QueryTx(ctx context.Context, req *SDKQueryTxRequest) (*SDK
 		panic(err)
 	}
 
-	return &SDKQueryTxResponse{
+	resp := &SDKQueryTxResponse{
 		TxHash: req.TxHash,
 
 		Height: int64(height),
 
-		// TODO: what do we use for the Index field?
+		// The Index field is only known once we load the block data,
+		// which we only do when proving.
 
 		Result: getGordianResponseFromSDKResult(txResult),
 
 		TxBytes: txBytes,
-	}, nil
+	}
+
+	if req.Prove {
+		if g.bds == nil {
+			return nil, errors.New("cannot prove transaction: no block data store configured")
+		}
+
+		p, err := gsbd.LoadTxProof(ctx, g.bds, height, req.TxHash, g.txc, g.zstdCodec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prove transaction: %w", err)
+		}
+		root, err := p.RootHash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get root hash of transaction proof: %w", err)
+		}
+
+		resp.Index = uint32(p.Proof.Index)
+		resp.Proof = &TxProof{
+			DataId:   p.DataID,
+			RootHash: root,
+
+			Total:    p.Proof.Total,
+			Index:    p.Proof.Index,
+			LeafHash: p.Proof.LeafHash,
+			Aunts:    p.Proof.Aunts,
+		}
+	}
+
+	return resp, nil
 }
 
 // getGordianResponseFromSDKResult converts an app manager TxResult to the gRPC proto result.
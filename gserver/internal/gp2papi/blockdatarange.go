@@ -0,0 +1,106 @@
+package gp2papi
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+)
+
+// FetchBlockDataRange retrieves the stored block data
+// for heights start through end, inclusive,
+// from the [DataHost] of peer p, over the v2 JSON range protocol.
+// It calls fn with each height and its encoded block data, in increasing height order;
+// heights without transactions have nil block data.
+//
+// Unlike the [CatchupClient], FetchBlockDataRange does not parse or verify the headers;
+// it is meant for offline tools that read the block data of a trusted node,
+// such as training a compression dictionary.
+//
+// If the peer reports an error, such as for a height it has not committed,
+// or if fn returns an error, FetchBlockDataRange stops and returns that error.
+func FetchBlockDataRange(
+	ctx context.Context,
+	host libp2phost.Host,
+	p libp2ppeer.ID,
+	start, end uint64,
+	fn func(height uint64, blockData []byte) error,
+) error {
+	if end < start {
+		return fmt.Errorf("invalid range: end height %d before start height %d", end, start)
+	}
+
+	for start <= end {
+		next, err := fetchBlockDataBatch(ctx, host, p, start, end, fn)
+		if err != nil {
+			return err
+		}
+		if next == start {
+			return fmt.Errorf("peer served no records starting at height %d", start)
+		}
+		start = next
+	}
+	return nil
+}
+
+// fetchBlockDataBatch requests start through end on a single stream,
+// returning the next height to request,
+// as the host may serve fewer heights than requested.
+func fetchBlockDataBatch(
+	ctx context.Context,
+	host libp2phost.Host,
+	p libp2ppeer.ID,
+	start, end uint64,
+	fn func(height uint64, blockData []byte) error,
+) (next uint64, err error) {
+	s, err := host.NewStream(ctx, p, fullBlockV2RangeProtocol)
+	if err != nil {
+		return start, fmt.Errorf("failed to open range stream to peer: %w", err)
+	}
+	defer s.Close()
+
+	// Reading from the stream does not respect a context.
+	stopReset := context.AfterFunc(ctx, func() {
+		_ = s.Reset()
+	})
+	defer stopReset()
+
+	if err := writeRangeRequest(s, start, end); err != nil {
+		return start, fmt.Errorf("failed to write range request: %w", err)
+	}
+	_ = s.CloseWrite()
+
+	// Same arbitrary per-record timeout as the CatchupClient.
+	const recordTimeout = 2 * time.Second
+
+	r := bufio.NewReader(s)
+	for height := start; height <= end; height++ {
+		_ = s.SetReadDeadline(time.Now().Add(recordTimeout))
+		rec, err := readRangeRecord(r, DefaultMaxHeaderSize, DefaultMaxBlockDataSize)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// The host is allowed to serve fewer heights than we requested.
+				return height, nil
+			}
+			if ctx.Err() != nil {
+				return height, context.Cause(ctx)
+			}
+			return height, fmt.Errorf("failed to read range record at height %d: %w", height, err)
+		}
+
+		if rec.Err != "" {
+			return height, fmt.Errorf("peer returned error at height %d: %s", height, rec.Err)
+		}
+
+		if err := fn(height, rec.BlockData); err != nil {
+			return height, err
+		}
+	}
+
+	return end + 1, nil
+}
@@ -0,0 +1,90 @@
+package gp2papi_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchBlockDataRange(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dhfx := NewFixture(t, ctx)
+
+	fx := tmconsensustest.NewEd25519Fixture(4)
+
+	// Heights 1 and 3 have data; height 2 is empty.
+	wantData := map[uint64][]byte{}
+	var headers []tmconsensus.Header
+	for h := uint64(1); h <= 3; h++ {
+		dataID := gsbd.DataID(h, 0, 0, nil)
+		if h != 2 {
+			txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(h)}
+
+			var buf bytes.Buffer
+			sz, err := gsbd.EncodeBlockData(&buf, txs)
+			require.NoError(t, err)
+
+			dataID = gsbd.DataID(h, 0, uint32(sz), txs)
+			require.NoError(t, dhfx.BlockDataStore.SaveBlockData(ctx, h, dataID, buf.Bytes()))
+			wantData[h] = buf.Bytes()
+		}
+
+		ph := fx.NextProposedHeader([]byte(dataID), 0)
+		fx.SignProposal(ctx, &ph, 0)
+
+		precommitProofs := fx.PrecommitProofMap(ctx, h, 0, map[string][]int{
+			string(ph.Header.Hash): {0, 1, 2, 3},
+		})
+		fx.CommitBlock(ph.Header, []byte("app_state"), 0, precommitProofs)
+		headers = append(headers, ph.Header)
+	}
+	nextPH := fx.NextProposedHeader([]byte("whatever"), 0)
+
+	for i, h := range headers {
+		proof := nextPH.Header.PrevCommitProof
+		if i < len(headers)-1 {
+			proof = headers[i+1].PrevCommitProof
+		}
+		require.NoError(t, dhfx.CommittedHeaderStore.SaveCommittedHeader(ctx, tmconsensus.CommittedHeader{
+			Header: h,
+			Proof:  proof,
+		}))
+	}
+
+	clientHost := dhfx.P2PClientConn.Host().Libp2pHost()
+	hostID := dhfx.P2PHostConn.Host().Libp2pHost().ID()
+
+	gotData := map[uint64][]byte{}
+	var gotHeights []uint64
+	require.NoError(t, gp2papi.FetchBlockDataRange(
+		ctx, clientHost, hostID, 1, 3,
+		func(height uint64, blockData []byte) error {
+			gotHeights = append(gotHeights, height)
+			if blockData != nil {
+				gotData[height] = blockData
+			}
+			return nil
+		},
+	))
+	require.Equal(t, []uint64{1, 2, 3}, gotHeights)
+	require.Equal(t, wantData, gotData)
+
+	// Requesting past the committed heights reports the peer's error.
+	err := gp2papi.FetchBlockDataRange(
+		ctx, clientHost, hostID, 3, 4,
+		func(uint64, []byte) error { return nil },
+	)
+	require.ErrorContains(t, err, "height 4")
+}
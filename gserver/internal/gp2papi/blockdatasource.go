@@ -0,0 +1,161 @@
+package gp2papi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/trace"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gcstore"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+)
+
+// BlockDataSource supplies block data to a [CatchupClient] in header-only mode.
+//
+// The CatchupClient only fetches committed headers from its peers,
+// and it defers to the BlockDataSource for the block data
+// of any header with a non-zero data ID.
+type BlockDataSource interface {
+	// LoadBlockData returns the encoded block data for the given header,
+	// in the same format as is stored in a [gcstore.BlockDataStore].
+	//
+	// LoadBlockData may block until the data is available,
+	// for instance if it is waiting on a snapshot to be restored.
+	// The CatchupClient verifies the returned data against the header's data ID,
+	// and it calls LoadBlockData again, after a delay,
+	// if LoadBlockData returns an error or data that does not match.
+	LoadBlockData(ctx context.Context, h tmconsensus.Header) ([]byte, error)
+}
+
+// StoreBlockDataSource is a [BlockDataSource] that loads block data
+// from a [gcstore.BlockDataStore] that is populated by some other process,
+// such as a snapshot restore or a separate archive fetcher.
+type StoreBlockDataSource struct {
+	Store gcstore.BlockDataStore
+}
+
+// LoadBlockData implements [BlockDataSource].
+func (s StoreBlockDataSource) LoadBlockData(ctx context.Context, h tmconsensus.Header) ([]byte, error) {
+	_, b, err := s.Store.LoadBlockDataByID(ctx, string(h.DataID), nil)
+	return b, err
+}
+
+// HTTPBlockDataSource is a [BlockDataSource] that retrieves block data from a web server
+// laid out as by a [gsbd.HTTPPublisher], such as an archive of proposers' uploads.
+type HTTPBlockDataSource struct {
+	Client *gsbd.HTTPClient
+
+	// The base URL under which block data is stored by data ID.
+	BaseURL string
+}
+
+// LoadBlockData implements [BlockDataSource].
+func (s HTTPBlockDataSource) LoadBlockData(ctx context.Context, h tmconsensus.Header) ([]byte, error) {
+	_, b, err := s.Client.Retrieve(ctx, s.BaseURL, string(h.DataID))
+	return b, err
+}
+
+// blockDataLoadRequest is sent from the fetch worker to the block data worker,
+// after the fetch worker has added an in-flight entry to the request cache.
+type blockDataLoadRequest struct {
+	Header tmconsensus.Header
+
+	// The in-flight request to populate.
+	Req *gsbd.BlockDataRequest
+
+	// Closed once the fields of Req are set.
+	Ready chan<- struct{}
+}
+
+// blockDataWorker loads block data from c's BlockDataSource,
+// on behalf of a header-only CatchupClient.
+//
+// Requests are handled in order, so that the data for lower heights,
+// which the driver will need sooner, are loaded first.
+func (c *CatchupClient) blockDataWorker(ctx context.Context) {
+	defer c.wg.Done()
+
+	ctx, task := trace.NewTask(ctx, "gp2papi.CatchupClient.blockDataWorker")
+	defer task.End()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info("Block data worker stopping due to context cancellation", "cause", context.Cause(ctx))
+			return
+
+		case req := <-c.blockDataLoadRequests:
+			c.loadBlockData(ctx, req)
+		}
+	}
+}
+
+// loadBlockData calls c's BlockDataSource until it returns valid data for req,
+// or until ctx is canceled.
+func (c *CatchupClient) loadBlockData(ctx context.Context, req blockDataLoadRequest) {
+	defer trace.StartRegion(ctx, "loadBlockData").End()
+
+	// Arbitrarily chosen retry delays.
+	const (
+		minDelay = 250 * time.Millisecond
+		maxDelay = 16 * time.Second
+	)
+	delay := minDelay
+
+	dataID := string(req.Header.DataID)
+	for {
+		err := c.tryLoadBlockData(ctx, req)
+		if err == nil {
+			return
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.log.Info(
+			"Failed to load block data from source; will retry",
+			"height", req.Header.Height,
+			"data_id", dataID,
+			"delay", delay,
+			"err", err,
+		)
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+
+		delay = min(2*delay, maxDelay)
+	}
+}
+
+func (c *CatchupClient) tryLoadBlockData(ctx context.Context, req blockDataLoadRequest) error {
+	dataID := string(req.Header.DataID)
+
+	b, err := c.bdSource.LoadBlockData(ctx, req.Header)
+	if err != nil {
+		return fmt.Errorf("failed to load block data: %w", err)
+	}
+
+	dec, err := gsbd.NewBlockDataDecoder(dataID, c.txDecoder)
+	if err != nil {
+		return fmt.Errorf("failed to create block data decoder: %w", err)
+	}
+	dec.SetZstdCodec(c.zstdCodec)
+
+	txs, err := dec.Decode(bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to decode block data: %w", err)
+	}
+
+	req.Req.Transactions = txs
+	req.Req.EncodedTransactions = b
+	close(req.Ready)
+	return nil
+}
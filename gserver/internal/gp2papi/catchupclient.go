@@ -1,8 +1,10 @@
 package gp2papi
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,14 +15,20 @@ import (
 	"time"
 
 	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi/gp2papipb"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/internal/copy/gchan"
+	"github.com/gordian-engine/gordian/gcrypto"
 	"github.com/gordian-engine/gordian/tm/tmcodec"
 	"github.com/gordian-engine/gordian/tm/tmconsensus"
 	"github.com/gordian-engine/gordian/tm/tmengine/tmelink"
 	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
 	libp2pprotocol "github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
 )
 
 type resumeFetchRequest struct {
@@ -58,15 +66,33 @@ type newFetchStateRequest struct {
 // and optionally their block data, from peers
 // when the engine indicates that the mirror subsystem is lagging
 // behind the rest of the network.
+//
+// If the CatchupClient is configured with a [BlockDataSource],
+// it runs in header-only mode:
+// it only fetches committed headers from peers,
+// and it loads block data from the source in the background.
 type CatchupClient struct {
 	log *slog.Logger
 
 	host        libp2phost.Host
 	unmarshaler tmcodec.Unmarshaler
+	reg         *gcrypto.Registry
 	txDecoder   transaction.Codec[transaction.Tx]
+	zstdCodec   *gsbd.ZstdCodec
 
 	rCache *gsbd.RequestCache
 
+	rangeBatchSize uint64
+
+	maxHeaderSize, maxBlockDataSize int
+
+	// Optional; nil if peer misbehavior is not being tracked.
+	peerReporter gpeer.Reporter
+
+	// Only set in header-only mode.
+	bdSource              BlockDataSource
+	blockDataLoadRequests chan blockDataLoadRequest
+
 	// Requests that originate externally (should be from the Driver specifically),
 	// via calling an exported method on CatchupClient.
 	resumeRequests      chan resumeFetchRequest
@@ -90,12 +116,20 @@ type CatchupClientConfig struct {
 	// The host from which we will open libp2p streams to other hosts.
 	Host libp2phost.Host
 
-	// How to unmarshal Gordian consensus messages.
+	// How to unmarshal Gordian consensus messages,
+	// when the peer only supports the JSON protocols.
 	Unmarshaler tmcodec.Unmarshaler
 
+	// How to decode validator public keys on the protobuf protocols.
+	CryptoRegistry *gcrypto.Registry
+
 	// How to decode SDK transactions encoded in block data.
 	TxDecoder transaction.Codec[transaction.Tx]
 
+	// How to decompress zstd-compressed block data,
+	// if the chain uses zstd compression.
+	ZstdCodec *gsbd.ZstdCodec
+
 	// Side channel for block data requests,
 	// so that the driver's finalization handler
 	// can be notified when block data is available.
@@ -105,8 +139,38 @@ type CatchupClientConfig struct {
 	// This same channel should be passed to the
 	// [tmengine.WithReplayedHeaderRequestChannel] option.
 	ReplayedHeadersOut chan<- tmelink.ReplayedHeaderRequest
+
+	// The maximum number of heights to request on a single stream,
+	// when the peer supports the v2 range protocol.
+	// If zero or greater than [MaxFullBlockRangeSize],
+	// MaxFullBlockRangeSize is used.
+	RangeBatchSize uint64
+
+	// The maximum encoded size of a single committed header
+	// and of a single block's data, respectively.
+	// Responses exceeding either limit are rejected
+	// and the peer who sent them is excluded.
+	// If zero, [DefaultMaxHeaderSize] and [DefaultMaxBlockDataSize] are used.
+	MaxHeaderSize, MaxBlockDataSize int
+
+	// If set, the CatchupClient runs in header-only mode,
+	// loading block data from this source instead of from peers.
+	BlockDataSource BlockDataSource
+
+	// If set, peers who serve malformed or invalid responses
+	// are reported here in addition to being excluded from further fetches.
+	PeerReporter gpeer.Reporter
 }
 
+const (
+	// DefaultMaxHeaderSize is the default value for [CatchupClientConfig.MaxHeaderSize].
+	// It is large enough to accommodate headers with large validator sets and annotations.
+	DefaultMaxHeaderSize = 1024 * 1024
+
+	// DefaultMaxBlockDataSize is the default value for [CatchupClientConfig.MaxBlockDataSize].
+	DefaultMaxBlockDataSize = 32 * 1024 * 1024
+)
+
 func NewCatchupClient(
 	ctx context.Context,
 	log *slog.Logger,
@@ -118,10 +182,21 @@ func NewCatchupClient(
 		host: cfg.Host,
 
 		unmarshaler: cfg.Unmarshaler,
+		reg:         cfg.CryptoRegistry,
 		txDecoder:   cfg.TxDecoder,
+		zstdCodec:   cfg.ZstdCodec,
 
 		rCache: cfg.RequestCache,
 
+		rangeBatchSize: cfg.RangeBatchSize,
+
+		maxHeaderSize:    cfg.MaxHeaderSize,
+		maxBlockDataSize: cfg.MaxBlockDataSize,
+
+		peerReporter: cfg.PeerReporter,
+
+		bdSource: cfg.BlockDataSource,
+
 		resumeRequests: make(chan resumeFetchRequest),
 		pauseRequests:  make(chan pauseFetchRequest),
 
@@ -138,10 +213,30 @@ func NewCatchupClient(
 		nextPeerRequests: make(chan nextPeerRequest),
 	}
 
+	if c.rangeBatchSize == 0 || c.rangeBatchSize > MaxFullBlockRangeSize {
+		c.rangeBatchSize = MaxFullBlockRangeSize
+	}
+	if c.maxHeaderSize <= 0 {
+		c.maxHeaderSize = DefaultMaxHeaderSize
+	}
+	if c.maxBlockDataSize <= 0 {
+		c.maxBlockDataSize = DefaultMaxBlockDataSize
+	}
+
 	c.wg.Add(2)
 	go c.mainLoop(ctx)
 	go c.fetchWorker(ctx)
 
+	if c.bdSource != nil {
+		// Buffered by the range batch size,
+		// so that header fetches can stay slightly ahead of block data loads
+		// without running arbitrarily far ahead.
+		c.blockDataLoadRequests = make(chan blockDataLoadRequest, c.rangeBatchSize)
+
+		c.wg.Add(1)
+		go c.blockDataWorker(ctx)
+	}
+
 	return c
 }
 
@@ -292,8 +387,18 @@ func (c *CatchupClient) doFetches(ctx context.Context, start, stop uint64) {
 			return
 		}
 
-		res := c.doFetch(ctx, height, p)
+		res := c.doFetch(ctx, height, stop, p)
+
+		// A range fetch may have applied some heights
+		// before encountering a problem with the peer,
+		// so always advance by the number of heights fetched.
+		height += res.Fetched
+
 		if res.ExcludePeer {
+			if res.Offense != gpeer.InvalidOffense && c.peerReporter != nil {
+				c.peerReporter.ReportPeer(p, res.Offense)
+			}
+
 			// This should be an exceptional case,
 			// so let's go ahead and do a blocking here.
 			// Note, the c.excludePeerRequests channel is buffered,
@@ -312,14 +417,9 @@ func (c *CatchupClient) doFetches(ctx context.Context, start, stop uint64) {
 			continue
 		}
 
-		if !res.Success {
-			// Try again with the same height,
-			// and hopefully a new peer.
-			continue
-		}
-
-		// We succeeded, so we can increment the height now.
-		height++
+		// If we did not fetch anything,
+		// we will try again with the same height,
+		// and hopefully a new peer.
 
 		// TODO: should do a non-blocking check on c.newFetchStateRequests here,
 		// in case the stop value has been adjusted.
@@ -328,17 +428,34 @@ func (c *CatchupClient) doFetches(ctx context.Context, start, stop uint64) {
 
 // fetchResult is the outcome of a call to [*CatchupClient.doFetch].
 type fetchResult struct {
-	Success bool
+	// The number of consecutive heights, starting at the requested height,
+	// that were successfully applied.
+	// This is at most 1 for a v1 fetch,
+	// but it may be larger for a v2 range fetch.
+	Fetched uint64
 
 	// Implies that it can be retried.
 	ExcludePeer bool
+
+	// When ExcludePeer is set, the offense to report for the peer.
+	// Left as the zero value when the peer is merely unreachable,
+	// as that is not evidence of misbehavior.
+	Offense gpeer.Offense
 }
 
 var errFetchHeaderDeadlineExceeded = errors.New("deadline for retrieving header exceeded")
 
-// doFetch executes a single committed header and block data fetch,
-// at the given height, from the given peer.
-func (c *CatchupClient) doFetch(ctx context.Context, height uint64, p libp2ppeer.ID) fetchResult {
+// doFetch executes a committed header and block data fetch,
+// starting at the given height, from the given peer.
+//
+// If the peer supports the v2 range protocol,
+// doFetch requests as many heights as allowed by c's range batch size,
+// stopping before the given stop height (if non-zero).
+// Otherwise, it falls back to fetching the single height with the v1 protocol.
+// In either case, the protobuf encoding is preferred over JSON.
+//
+// In header-only mode, doFetch only fetches the single committed header.
+func (c *CatchupClient) doFetch(ctx context.Context, height, stop uint64, p libp2ppeer.ID) fetchResult {
 	defer trace.StartRegion(ctx, "doFetch").End()
 
 	const timeout = 2 * time.Second // Arbitrarily chosen.
@@ -347,11 +464,21 @@ func (c *CatchupClient) doFetch(ctx context.Context, height uint64, p libp2ppeer
 	)
 	defer cancel()
 
-	// If we want to support a header-only CatchupClient,
-	// we would need to use headerV1HeightPrefix here.
-	s, err := c.host.NewStream(streamCtx, p, libp2pprotocol.ID(
-		fmt.Sprintf("%s%d", fullBlockV1HeightPrefix, height),
-	))
+	if c.bdSource != nil {
+		return c.doHeaderFetch(ctx, streamCtx, height, p)
+	}
+
+	// Offering all the protocol IDs in order of preference
+	// lets the peer choose the best one it supports
+	// without any further round trips.
+	pbHeightID := libp2pprotocol.ID(fmt.Sprintf("%s%d", fullBlockV1PBHeightPrefix, height))
+	s, err := c.host.NewStream(
+		streamCtx, p,
+		libp2pprotocol.ID(fullBlockV2PBRangeProtocol),
+		libp2pprotocol.ID(fullBlockV2RangeProtocol),
+		pbHeightID,
+		libp2pprotocol.ID(fmt.Sprintf("%s%d", fullBlockV1HeightPrefix, height)),
+	)
 	if err != nil {
 		c.log.Info("Failed to open stream to peer", "peer_id", p, "err", err)
 		return fetchResult{
@@ -360,10 +487,24 @@ func (c *CatchupClient) doFetch(ctx context.Context, height uint64, p libp2ppeer
 	}
 	defer s.Close()
 
-	// We have a stream to the right protocol, let's parse the result.
-	// Arbitrary limit on header size.
-	var res JSONResult
-	err = json.NewDecoder(io.LimitReader(s, 16*1024)).Decode(&res)
+	var rec fullBlockRecord
+	switch s.Protocol() {
+	case fullBlockV2PBRangeProtocol, fullBlockV2RangeProtocol:
+		// The range fetch manages its own deadlines.
+		cancel()
+
+		end := height + c.rangeBatchSize - 1
+		if stop > 0 && end >= stop {
+			end = stop - 1
+		}
+		return c.doRangeFetch(ctx, s, p, height, end)
+
+	case pbHeightID:
+		rec, err = c.readPBFullBlock(bufio.NewReader(s))
+
+	default:
+		rec, err = c.readJSONFullBlock(s)
+	}
 	_ = s.Close() // Nothing left to do with stream.
 	cancel()      // And free up any streamCtx resources as early as possible.
 	if err != nil {
@@ -375,80 +516,415 @@ func (c *CatchupClient) doFetch(ctx context.Context, height uint64, p libp2ppeer
 		)
 		return fetchResult{
 			ExcludePeer: true,
+			Offense:     gpeer.CatchupFailureOffense,
 		}
 	}
 
-	if res.Err != "" {
-		if res.Err == "height unknown" {
-			// Special case of the height isn't ready.
-			// Assume we trust this peer.
-			// Just back off slightly.
-			// Nothing to log in this case.
-			t := time.NewTimer(time.Second)
-			defer t.Stop()
-			select {
-			case <-ctx.Done():
-			case <-t.C:
-			}
-		} else {
-			c.log.Info(
-				"Got error response from peer",
-				"peer_id", p,
-				"height", height,
-				"err", res.Err,
-			)
-		}
-
+	if rec.Err != "" {
+		c.handleErrorResponse(ctx, p, height, rec.Err)
 		return fetchResult{
 			// They sent a valid error back,
 			// so we aren't going to exclude them on these grounds.
 		}
 	}
 
-	var fbr FullBlockResult
-	if err := json.Unmarshal(res.Result, &fbr); err != nil {
+	return c.applyFullBlock(ctx, p, height, rec.Header, rec.BlockData)
+}
+
+// doHeaderFetch fetches only the committed header at the given height from p,
+// for a header-only CatchupClient.
+func (c *CatchupClient) doHeaderFetch(
+	ctx, streamCtx context.Context, height uint64, p libp2ppeer.ID,
+) fetchResult {
+	pbHeightID := libp2pprotocol.ID(fmt.Sprintf("%s%d", headerV1PBHeightPrefix, height))
+	s, err := c.host.NewStream(
+		streamCtx, p,
+		pbHeightID,
+		libp2pprotocol.ID(fmt.Sprintf("%s%d", headerV1HeightPrefix, height)),
+	)
+	if err != nil {
+		c.log.Info("Failed to open stream to peer", "peer_id", p, "err", err)
+		return fetchResult{
+			ExcludePeer: true,
+		}
+	}
+	defer s.Close()
+
+	var rec fullBlockRecord
+	if s.Protocol() == pbHeightID {
+		rec, err = c.readPBCommittedHeader(bufio.NewReader(s))
+	} else {
+		rec, err = c.readJSONCommittedHeader(s)
+	}
+	_ = s.Close()
+	if err != nil {
 		c.log.Info(
-			"Failed to parse full block result",
+			"Failed to parse stream response from peer",
 			"peer_id", p,
 			"height", height,
 			"err", err,
 		)
 		return fetchResult{
 			ExcludePeer: true,
+			Offense:     gpeer.CatchupFailureOffense,
 		}
 	}
 
-	// Parsed the entire result,
-	// now parse the committed header.
-	var ch tmconsensus.CommittedHeader
-	if err := c.unmarshaler.UnmarshalCommittedHeader(fbr.Header, &ch); err != nil {
+	if rec.Err != "" {
+		c.handleErrorResponse(ctx, p, height, rec.Err)
+		return fetchResult{}
+	}
+
+	return c.applyHeader(ctx, p, height, rec.Header)
+}
+
+// doRangeFetch reads full block records from s,
+// which must already be using one of the v2 range protocols,
+// applying each record in turn.
+func (c *CatchupClient) doRangeFetch(
+	ctx context.Context,
+	s libp2pnetwork.Stream,
+	p libp2ppeer.ID,
+	start, end uint64,
+) fetchResult {
+	defer trace.StartRegion(ctx, "doRangeFetch").End()
+
+	// Reading from the stream does not respect a context,
+	// so reset the stream if the fetch is paused or otherwise canceled.
+	stopReset := context.AfterFunc(ctx, func() {
+		_ = s.Reset()
+	})
+	defer stopReset()
+
+	if err := writeRangeRequest(s, start, end); err != nil {
 		c.log.Info(
-			"Failed to parse header from result",
+			"Failed to write range request to peer",
 			"peer_id", p,
-			"height", height,
+			"start", start, "end", end,
 			"err", err,
 		)
 		return fetchResult{
 			ExcludePeer: true,
 		}
 	}
+	if err := s.CloseWrite(); err != nil {
+		c.log.Info("Failed to close stream for write", "peer_id", p, "err", err)
+		// Okay to continue anyway.
+	}
+
+	readRecord := c.readJSONRangeRecord
+	if s.Protocol() == fullBlockV2PBRangeProtocol {
+		readRecord = c.readPBFullBlock
+	}
+
+	// The per-record deadline matches the single height timeout.
+	// Since we apply each record before reading the next,
+	// the host sees backpressure while we are busy with the engine.
+	const recordTimeout = 2 * time.Second
+
+	var res fetchResult
+	r := bufio.NewReader(s)
+	for height := start; height <= end; height++ {
+		_ = s.SetReadDeadline(time.Now().Add(recordTimeout))
+		rec, err := readRecord(r)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Stream was reset due to cancellation.
+				return res
+			}
+
+			if errors.Is(err, io.EOF) && res.Fetched > 0 {
+				// The host is allowed to serve fewer heights than we requested.
+				return res
+			}
+
+			c.log.Info(
+				"Failed to read range record from peer",
+				"peer_id", p,
+				"height", height,
+				"err", err,
+			)
+			res.ExcludePeer = true
+			res.Offense = gpeer.CatchupFailureOffense
+			return res
+		}
+
+		if rec.Err != "" {
+			if res.Fetched == 0 {
+				c.handleErrorResponse(ctx, p, height, rec.Err)
+			}
+			// Otherwise, we made progress,
+			// so let the next fetch determine whether we need to back off.
+			return res
+		}
+
+		one := c.applyFullBlock(ctx, p, height, rec.Header, rec.BlockData)
+		res.Fetched += one.Fetched
+		if one.ExcludePeer {
+			res.ExcludePeer = true
+			res.Offense = one.Offense
+			return res
+		}
+		if one.Fetched == 0 {
+			return res
+		}
+	}
+
+	return res
+}
+
+// fullBlockRecord is a decoded full block response,
+// independent of the protocol it was received on.
+// Exactly one of Err or Header is set.
+type fullBlockRecord struct {
+	Header    tmconsensus.CommittedHeader
+	BlockData []byte
+
+	Err string
+}
+
+// readPBFullBlock reads a single length-delimited FullBlockResponse message from r.
+// At the end of the stream, it returns [io.EOF].
+func (c *CatchupClient) readPBFullBlock(r *bufio.Reader) (fullBlockRecord, error) {
+	// Allow a little extra room for the message framing.
+	const overhead = 1024
+	opts := protodelim.UnmarshalOptions{
+		MaxSize: int64(c.maxHeaderSize) + int64(c.maxBlockDataSize) + overhead,
+	}
+
+	var resp gp2papipb.FullBlockResponse
+	if err := opts.UnmarshalFrom(r, &resp); err != nil {
+		// Return io.EOF unwrapped, so the caller can detect the clean end of the stream.
+		if err == io.EOF {
+			return fullBlockRecord{}, err
+		}
+		return fullBlockRecord{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if errMsg := resp.GetError(); errMsg != "" {
+		if len(errMsg) > maxErrorMessageSize {
+			return fullBlockRecord{}, fmt.Errorf(
+				"error message size %d exceeds maximum %d", len(errMsg), maxErrorMessageSize,
+			)
+		}
+		return fullBlockRecord{Err: errMsg}, nil
+	}
+
+	fb := resp.GetFullBlock()
+	if fb == nil {
+		return fullBlockRecord{}, errors.New("response had neither full block nor error")
+	}
+
+	if sz := proto.Size(fb.CommittedHeader); sz > c.maxHeaderSize {
+		return fullBlockRecord{}, fmt.Errorf(
+			"header size %d exceeds maximum %d", sz, c.maxHeaderSize,
+		)
+	}
+	if sz := len(fb.BlockData); sz > c.maxBlockDataSize {
+		return fullBlockRecord{}, fmt.Errorf(
+			"block data size %d exceeds maximum %d", sz, c.maxBlockDataSize,
+		)
+	}
+
+	ch, err := committedHeaderFromPB(fb.CommittedHeader, c.reg)
+	if err != nil {
+		return fullBlockRecord{}, err
+	}
+
+	return fullBlockRecord{
+		Header:    ch,
+		BlockData: fb.BlockData,
+	}, nil
+}
+
+// readJSONRangeRecord reads a single record from a JSON range stream.
+// At the end of the stream, it returns [io.EOF].
+func (c *CatchupClient) readJSONRangeRecord(r *bufio.Reader) (fullBlockRecord, error) {
+	rec, err := readRangeRecord(r, c.maxHeaderSize, c.maxBlockDataSize)
+	if err != nil {
+		return fullBlockRecord{}, err
+	}
+
+	if rec.Err != "" {
+		return fullBlockRecord{Err: rec.Err}, nil
+	}
+
+	var ch tmconsensus.CommittedHeader
+	if err := c.unmarshaler.UnmarshalCommittedHeader(rec.Header, &ch); err != nil {
+		return fullBlockRecord{}, fmt.Errorf("failed to parse header: %w", err)
+	}
+
+	return fullBlockRecord{
+		Header:    ch,
+		BlockData: rec.BlockData,
+	}, nil
+}
+
+// readPBCommittedHeader reads a single length-delimited CommittedHeaderResponse message from r.
+// The BlockData field of the returned record is always nil.
+func (c *CatchupClient) readPBCommittedHeader(r *bufio.Reader) (fullBlockRecord, error) {
+	// Allow a little extra room for the message framing.
+	const overhead = 1024
+	opts := protodelim.UnmarshalOptions{
+		MaxSize: int64(c.maxHeaderSize) + overhead,
+	}
+
+	var resp gp2papipb.CommittedHeaderResponse
+	if err := opts.UnmarshalFrom(r, &resp); err != nil {
+		return fullBlockRecord{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if errMsg := resp.GetError(); errMsg != "" {
+		if len(errMsg) > maxErrorMessageSize {
+			return fullBlockRecord{}, fmt.Errorf(
+				"error message size %d exceeds maximum %d", len(errMsg), maxErrorMessageSize,
+			)
+		}
+		return fullBlockRecord{Err: errMsg}, nil
+	}
+
+	pbch := resp.GetCommittedHeader()
+	if pbch == nil {
+		return fullBlockRecord{}, errors.New("response had neither committed header nor error")
+	}
+
+	ch, err := committedHeaderFromPB(pbch, c.reg)
+	if err != nil {
+		return fullBlockRecord{}, err
+	}
+
+	return fullBlockRecord{Header: ch}, nil
+}
+
+// readJSONCommittedHeader reads the single JSON response from a v1 committed header stream.
+// The BlockData field of the returned record is always nil.
+func (c *CatchupClient) readJSONCommittedHeader(r io.Reader) (fullBlockRecord, error) {
+	// Allow a little extra room for the surrounding JSON.
+	const overhead = 1024
+	limit := int64(c.maxHeaderSize) + overhead
+
+	var res JSONResult
+	if err := json.NewDecoder(io.LimitReader(r, limit)).Decode(&res); err != nil {
+		return fullBlockRecord{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if res.Err != "" {
+		return fullBlockRecord{Err: res.Err}, nil
+	}
+
+	var ch tmconsensus.CommittedHeader
+	if err := c.unmarshaler.UnmarshalCommittedHeader(res.Result, &ch); err != nil {
+		return fullBlockRecord{}, fmt.Errorf("failed to parse header: %w", err)
+	}
+
+	return fullBlockRecord{Header: ch}, nil
+}
+
+// readJSONFullBlock reads the single JSON response from a v1 full block stream.
+func (c *CatchupClient) readJSONFullBlock(r io.Reader) (fullBlockRecord, error) {
+	// The block data is base64-encoded inside the JSON,
+	// and allow a little extra room for the surrounding JSON.
+	const overhead = 1024
+	limit := int64(c.maxHeaderSize) +
+		int64(base64.StdEncoding.EncodedLen(c.maxBlockDataSize)) +
+		overhead
+
+	var res JSONResult
+	if err := json.NewDecoder(io.LimitReader(r, limit)).Decode(&res); err != nil {
+		return fullBlockRecord{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if res.Err != "" {
+		return fullBlockRecord{Err: res.Err}, nil
+	}
+
+	var fbr FullBlockResult
+	if err := json.Unmarshal(res.Result, &fbr); err != nil {
+		return fullBlockRecord{}, fmt.Errorf("failed to parse full block result: %w", err)
+	}
+
+	if sz := len(fbr.Header); sz > c.maxHeaderSize {
+		return fullBlockRecord{}, fmt.Errorf(
+			"header size %d exceeds maximum %d", sz, c.maxHeaderSize,
+		)
+	}
+	if sz := len(fbr.BlockData); sz > c.maxBlockDataSize {
+		return fullBlockRecord{}, fmt.Errorf(
+			"block data size %d exceeds maximum %d", sz, c.maxBlockDataSize,
+		)
+	}
+
+	var ch tmconsensus.CommittedHeader
+	if err := c.unmarshaler.UnmarshalCommittedHeader(fbr.Header, &ch); err != nil {
+		return fullBlockRecord{}, fmt.Errorf("failed to parse header: %w", err)
+	}
+
+	return fullBlockRecord{
+		Header:    ch,
+		BlockData: fbr.BlockData,
+	}, nil
+}
+
+// handleErrorResponse handles a well-formed error from the peer,
+// backing off if the error indicates that the height is not yet available.
+func (c *CatchupClient) handleErrorResponse(
+	ctx context.Context, p libp2ppeer.ID, height uint64, errMsg string,
+) {
+	if errMsg == "height unknown" {
+		// Special case of the height isn't ready.
+		// Assume we trust this peer.
+		// Just back off slightly.
+		// Nothing to log in this case.
+		t := time.NewTimer(time.Second)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+		case <-t.C:
+		}
+		return
+	}
+
+	c.log.Info(
+		"Got error response from peer",
+		"peer_id", p,
+		"height", height,
+		"err", errMsg,
+	)
+}
+
+// applyFullBlock validates the block data against the header's data ID,
+// and sends the header to the engine as a replayed header.
+func (c *CatchupClient) applyFullBlock(
+	ctx context.Context,
+	p libp2ppeer.ID,
+	height uint64,
+	ch tmconsensus.CommittedHeader,
+	blockData []byte,
+) fetchResult {
+	if !c.checkHeaderHeight(p, height, ch) {
+		return fetchResult{
+			ExcludePeer: true,
+			Offense:     gpeer.CatchupFailureOffense,
+		}
+	}
 
 	// Confirm that the block data is appropriate for the header.
 	if gsbd.IsZeroTxDataID(string(ch.Header.DataID)) {
-		if len(fbr.BlockData) > 0 {
+		if len(blockData) > 0 {
 			c.log.Info(
 				"Got non-empty block data for zero data ID",
 				"peer_id", p,
 				"height", height,
 				"data_id", ch.Header.DataID,
-				"block_data_size", len(fbr.BlockData),
+				"block_data_size", len(blockData),
 			)
 			return fetchResult{
 				ExcludePeer: true,
+				Offense:     gpeer.BadBlockDataOffense,
 			}
 		}
 	} else {
-		if len(fbr.BlockData) == 0 {
+		if len(blockData) == 0 {
 			c.log.Info(
 				"Got empty block data for non-zero data ID",
 				"peer_id", p,
@@ -457,11 +933,12 @@ func (c *CatchupClient) doFetch(ctx context.Context, height uint64, p libp2ppeer
 			)
 			return fetchResult{
 				ExcludePeer: true,
+				Offense:     gpeer.BadBlockDataOffense,
 			}
 		}
 	}
 
-	if len(fbr.BlockData) > 0 {
+	if len(blockData) > 0 {
 		dec, err := gsbd.NewBlockDataDecoder(string(ch.Header.DataID), c.txDecoder)
 		if err != nil {
 			c.log.Info(
@@ -472,10 +949,12 @@ func (c *CatchupClient) doFetch(ctx context.Context, height uint64, p libp2ppeer
 			)
 			return fetchResult{
 				ExcludePeer: true,
+				Offense:     gpeer.BadBlockDataOffense,
 			}
 		}
+		dec.SetZstdCodec(c.zstdCodec)
 
-		txs, err := dec.Decode(bytes.NewReader(fbr.BlockData))
+		txs, err := dec.Decode(bytes.NewReader(blockData))
 		if err != nil {
 			c.log.Info(
 				"Got error when parsing block data",
@@ -485,14 +964,91 @@ func (c *CatchupClient) doFetch(ctx context.Context, height uint64, p libp2ppeer
 			)
 			return fetchResult{
 				ExcludePeer: true,
+				Offense:     gpeer.BadBlockDataOffense,
 			}
 		}
 
 		// Since we have the block data and it matches the header's data ID,
 		// we can set it in the request cache as completed.
-		c.rCache.SetImmediatelyAvailable(string(ch.Header.DataID), txs, fbr.BlockData)
+		c.rCache.SetImmediatelyAvailable(string(ch.Header.DataID), txs, blockData)
+	}
+
+	return c.replayHeader(ctx, p, height, ch)
+}
+
+// applyHeader is the header-only counterpart to [*CatchupClient.applyFullBlock].
+// It requests the header's block data from c's BlockDataSource,
+// and then sends the header to the engine as a replayed header,
+// without waiting for the block data to load.
+func (c *CatchupClient) applyHeader(
+	ctx context.Context,
+	p libp2ppeer.ID,
+	height uint64,
+	ch tmconsensus.CommittedHeader,
+) fetchResult {
+	if !c.checkHeaderHeight(p, height, ch) {
+		return fetchResult{
+			ExcludePeer: true,
+			Offense:     gpeer.CatchupFailureOffense,
+		}
 	}
 
+	dataID := string(ch.Header.DataID)
+	if !gsbd.IsZeroTxDataID(dataID) {
+		// The in-flight entry must be in the request cache
+		// before the engine can send the finalization for this header to the driver.
+		// If there is already an entry, we are retrying after a failed replay,
+		// and the block data worker already has the load request.
+		if _, ok := c.rCache.Get(dataID); !ok {
+			ready := make(chan struct{})
+			req := &gsbd.BlockDataRequest{Ready: ready}
+			c.rCache.SetInFlight(dataID, req)
+
+			if !gchan.SendC(
+				ctx, c.log,
+				c.blockDataLoadRequests, blockDataLoadRequest{
+					Header: ch.Header,
+					Req:    req,
+					Ready:  ready,
+				},
+				"sending block data load request",
+			) {
+				// Context was cancelled, so result is meaningless here.
+				// The entry stays in the cache,
+				// but nothing else can proceed on a cancelled fetch anyway.
+				return fetchResult{}
+			}
+		}
+	}
+
+	return c.replayHeader(ctx, p, height, ch)
+}
+
+// checkHeaderHeight reports whether ch is for the requested height,
+// logging the mismatch if it is not.
+func (c *CatchupClient) checkHeaderHeight(
+	p libp2ppeer.ID, height uint64, ch tmconsensus.CommittedHeader,
+) bool {
+	if ch.Header.Height != height {
+		c.log.Info(
+			"Got header for wrong height",
+			"peer_id", p,
+			"want_height", height,
+			"got_height", ch.Header.Height,
+		)
+		return false
+	}
+	return true
+}
+
+// replayHeader sends the committed header to the engine
+// and reports the result.
+func (c *CatchupClient) replayHeader(
+	ctx context.Context,
+	p libp2ppeer.ID,
+	height uint64,
+	ch tmconsensus.CommittedHeader,
+) fetchResult {
 	// Now we have a committed header, so we have to send it to the engine.
 	respCh := make(chan tmelink.ReplayedHeaderResponse, 1)
 	req := tmelink.ReplayedHeaderRequest{
@@ -522,11 +1078,12 @@ func (c *CatchupClient) doFetch(ctx context.Context, height uint64, p libp2ppeer
 		)
 		return fetchResult{
 			ExcludePeer: true,
+			Offense:     gpeer.CatchupFailureOffense,
 		}
 	}
 
 	return fetchResult{
-		Success: true,
+		Fetched: 1,
 	}
 }
 
@@ -580,3 +1137,54 @@ func (c *CatchupClient) RemovePeer(ctx context.Context, p libp2ppeer.ID) (ok boo
 		"making remove peer request",
 	)
 }
+
+// FetchBlockData fetches the encoded block data for the committed header h from peer p,
+// using the single-height full block protocol served by [DataHost].
+//
+// Unlike the rest of the CatchupClient, FetchBlockData does not go through the main loop,
+// so it is safe to call concurrently and regardless of whether fetching is paused.
+// It is intended for a driver that is finalizing a block whose data it never received.
+//
+// FetchBlockData only confirms that the peer's header has the same data ID as h;
+// the caller must still verify the returned data against the data ID.
+func (c *CatchupClient) FetchBlockData(
+	ctx context.Context, p libp2ppeer.ID, h tmconsensus.Header,
+) ([]byte, error) {
+	const timeout = 2 * time.Second // Same arbitrary timeout as doFetch.
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pbHeightID := libp2pprotocol.ID(fmt.Sprintf("%s%d", fullBlockV1PBHeightPrefix, h.Height))
+	s, err := c.host.NewStream(
+		ctx, p,
+		pbHeightID,
+		libp2pprotocol.ID(fmt.Sprintf("%s%d", fullBlockV1HeightPrefix, h.Height)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream to peer: %w", err)
+	}
+	defer s.Close()
+
+	var rec fullBlockRecord
+	if s.Protocol() == pbHeightID {
+		rec, err = c.readPBFullBlock(bufio.NewReader(s))
+	} else {
+		rec, err = c.readJSONFullBlock(s)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse full block response: %w", err)
+	}
+
+	if rec.Err != "" {
+		return nil, fmt.Errorf("peer returned error: %s", rec.Err)
+	}
+
+	if !bytes.Equal(rec.Header.Header.DataID, h.DataID) {
+		return nil, fmt.Errorf(
+			"peer's header had data ID %q, expected %q",
+			rec.Header.Header.DataID, h.DataID,
+		)
+	}
+
+	return rec.BlockData, nil
+}
@@ -8,11 +8,14 @@ import (
 	"cosmossdk.io/core/transaction"
 	"github.com/gordian-engine/gcosmos/gserver/gservertest"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
 	"github.com/gordian-engine/gordian/tm/tmconsensus"
 	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
 	"github.com/gordian-engine/gordian/tm/tmengine/tmelink"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	libp2pprotocol "github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/stretchr/testify/require"
 )
 
@@ -52,6 +55,7 @@ func TestCatchupClient_fullBlock_zeroData(t *testing.T) {
 		gp2papi.CatchupClientConfig{
 			Host:               dhfx.P2PClientConn.Host().Libp2pHost(),
 			Unmarshaler:        dhfx.Codec,
+			CryptoRegistry:     dhfx.CryptoRegistry,
 			TxDecoder:          txDecoder,
 			RequestCache:       dhfx.Cache,
 			ReplayedHeadersOut: rhCh,
@@ -122,6 +126,7 @@ func TestCatchupClient_fullBlock_withData_correct(t *testing.T) {
 		gp2papi.CatchupClientConfig{
 			Host:               dhfx.P2PClientConn.Host().Libp2pHost(),
 			Unmarshaler:        dhfx.Codec,
+			CryptoRegistry:     dhfx.CryptoRegistry,
 			TxDecoder:          gservertest.HashOnlyTransactionDecoder{},
 			RequestCache:       dhfx.Cache,
 			ReplayedHeadersOut: rhCh,
@@ -195,26 +200,35 @@ func TestCatchupClient_fullBlock_withData_badHash(t *testing.T) {
 	}))
 
 	rhCh := make(chan tmelink.ReplayedHeaderRequest)
+	reporter := make(chanPeerReporter, 1)
 	sc := gp2papi.NewCatchupClient(
 		ctx,
 		gtest.NewLogger(t).With("sys", "syncclient"),
 		gp2papi.CatchupClientConfig{
 			Host:               dhfx.P2PClientConn.Host().Libp2pHost(),
 			Unmarshaler:        dhfx.Codec,
+			CryptoRegistry:     dhfx.CryptoRegistry,
 			TxDecoder:          gservertest.HashOnlyTransactionDecoder{},
 			RequestCache:       dhfx.Cache,
 			ReplayedHeadersOut: rhCh,
+			PeerReporter:       reporter,
 		},
 	)
 	defer sc.Wait()
 	defer cancel()
 
 	// Add the host peer first.
-	require.True(t, sc.AddPeer(ctx, dhfx.P2PHostConn.Host().Libp2pHost().ID()))
+	hostID := dhfx.P2PHostConn.Host().Libp2pHost().ID()
+	require.True(t, sc.AddPeer(ctx, hostID))
 
 	// Now fetch height 1.
 	require.True(t, sc.ResumeFetching(ctx, 1, 2)) // Fetch Height 1 and stop at 2.
 
+	// The host is reported for serving data that does not match the data ID.
+	rep := gtest.ReceiveSoon(t, reporter)
+	require.Equal(t, hostID, rep.P)
+	require.Equal(t, gpeer.BadBlockDataOffense, rep.O)
+
 	// We don't receive a replayed header
 	// on account of the hash mismatch,
 	// and we don't have any alternate peers who are hosting the data either.
@@ -224,3 +238,398 @@ func TestCatchupClient_fullBlock_withData_badHash(t *testing.T) {
 	_, ok := dhfx.Cache.Get(dataID)
 	require.False(t, ok)
 }
+
+func TestCatchupClient_fullBlock_range(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dhfx := NewFixture(t, ctx)
+
+	fx := tmconsensustest.NewEd25519Fixture(4)
+
+	// Commit three heights with zero data,
+	// so that a single range request covers all of them.
+	var headers []tmconsensus.Header
+	for h := uint64(1); h <= 3; h++ {
+		ph := fx.NextProposedHeader([]byte(gsbd.DataID(h, 0, 0, nil)), 0)
+		fx.SignProposal(ctx, &ph, 0)
+
+		precommitProofs := fx.PrecommitProofMap(ctx, h, 0, map[string][]int{
+			string(ph.Header.Hash): {0, 1, 2, 3},
+		})
+		fx.CommitBlock(ph.Header, []byte("app_state"), 0, precommitProofs)
+		headers = append(headers, ph.Header)
+	}
+	nextPH := fx.NextProposedHeader([]byte("whatever"), 0)
+
+	for i, h := range headers {
+		proof := nextPH.Header.PrevCommitProof
+		if i < len(headers)-1 {
+			proof = headers[i+1].PrevCommitProof
+		}
+		require.NoError(t, dhfx.CommittedHeaderStore.SaveCommittedHeader(ctx, tmconsensus.CommittedHeader{
+			Header: h,
+			Proof:  proof,
+		}))
+	}
+
+	rhCh := make(chan tmelink.ReplayedHeaderRequest)
+	sc := gp2papi.NewCatchupClient(
+		ctx,
+		gtest.NewLogger(t).With("sys", "syncclient"),
+		gp2papi.CatchupClientConfig{
+			Host:               dhfx.P2PClientConn.Host().Libp2pHost(),
+			Unmarshaler:        dhfx.Codec,
+			CryptoRegistry:     dhfx.CryptoRegistry,
+			TxDecoder:          gservertest.HashOnlyTransactionDecoder{},
+			RequestCache:       dhfx.Cache,
+			ReplayedHeadersOut: rhCh,
+		},
+	)
+	defer sc.Wait()
+	defer cancel()
+
+	require.True(t, sc.AddPeer(ctx, dhfx.P2PHostConn.Host().Libp2pHost().ID()))
+	require.True(t, sc.ResumeFetching(ctx, 1, 4)) // Fetch heights 1-3.
+
+	for _, h := range headers {
+		replayReq := gtest.ReceiveSoon(t, rhCh)
+		require.Equal(t, h, replayReq.Header)
+		gtest.SendSoon(t, replayReq.Resp, tmelink.ReplayedHeaderResponse{})
+	}
+
+	// Nothing beyond the stop height.
+	gtest.NotSendingSoon(t, rhCh)
+}
+
+func TestCatchupClient_fullBlock_protocolFallback(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		removed []string
+	}{
+		{
+			name: "v2 JSON range",
+			removed: []string{
+				"/gcosmos/full_blocks/v2/pb/range",
+			},
+		},
+		{
+			name: "v1 protobuf",
+			removed: []string{
+				"/gcosmos/full_blocks/v2/pb/range",
+				"/gcosmos/full_blocks/v2/range",
+			},
+		},
+		{
+			name: "v1 JSON",
+			removed: []string{
+				"/gcosmos/full_blocks/v2/pb/range",
+				"/gcosmos/full_blocks/v2/range",
+				"/gcosmos/full_blocks/v1/pb/height/",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			dhfx := NewFixture(t, ctx)
+
+			// Simulate a host that only supports older protocols.
+			for _, id := range tc.removed {
+				dhfx.P2PHostConn.Host().Libp2pHost().RemoveStreamHandler(libp2pprotocol.ID(id))
+			}
+
+			fx := tmconsensustest.NewEd25519Fixture(4)
+			ph1 := fx.NextProposedHeader([]byte(gsbd.DataID(1, 0, 0, nil)), 0)
+			fx.SignProposal(ctx, &ph1, 0)
+
+			precommitProofs := fx.PrecommitProofMap(ctx, 1, 0, map[string][]int{
+				string(ph1.Header.Hash): {0, 1, 2, 3},
+			})
+			fx.CommitBlock(ph1.Header, []byte("app_state_1"), 0, precommitProofs)
+			nextPH := fx.NextProposedHeader([]byte("whatever"), 0)
+
+			require.NoError(t, dhfx.CommittedHeaderStore.SaveCommittedHeader(ctx, tmconsensus.CommittedHeader{
+				Header: ph1.Header,
+				Proof:  nextPH.Header.PrevCommitProof,
+			}))
+
+			rhCh := make(chan tmelink.ReplayedHeaderRequest)
+			sc := gp2papi.NewCatchupClient(
+				ctx,
+				gtest.NewLogger(t).With("sys", "syncclient"),
+				gp2papi.CatchupClientConfig{
+					Host:               dhfx.P2PClientConn.Host().Libp2pHost(),
+					Unmarshaler:        dhfx.Codec,
+					CryptoRegistry:     dhfx.CryptoRegistry,
+					TxDecoder:          gservertest.HashOnlyTransactionDecoder{},
+					RequestCache:       dhfx.Cache,
+					ReplayedHeadersOut: rhCh,
+				},
+			)
+			defer sc.Wait()
+			defer cancel()
+
+			require.True(t, sc.AddPeer(ctx, dhfx.P2PHostConn.Host().Libp2pHost().ID()))
+			require.True(t, sc.ResumeFetching(ctx, 1, 2))
+
+			replayReq := gtest.ReceiveSoon(t, rhCh)
+			require.Equal(t, ph1.Header, replayReq.Header)
+			require.Equal(t, nextPH.Header.PrevCommitProof, replayReq.Proof)
+			gtest.SendSoon(t, replayReq.Resp, tmelink.ReplayedHeaderResponse{})
+		})
+	}
+}
+
+func TestCatchupClient_fullBlock_blockDataTooLarge(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dhfx := NewFixture(t, ctx)
+
+	fx := tmconsensustest.NewEd25519Fixture(4)
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+
+	dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+	require.NoError(t, dhfx.BlockDataStore.SaveBlockData(ctx, 1, dataID, buf.Bytes()))
+
+	ph1 := fx.NextProposedHeader([]byte(dataID), 0)
+	fx.SignProposal(ctx, &ph1, 0)
+
+	precommitProofs := fx.PrecommitProofMap(ctx, 1, 0, map[string][]int{
+		string(ph1.Header.Hash): {0, 1, 2, 3},
+	})
+	fx.CommitBlock(ph1.Header, []byte("app_state_1"), 0, precommitProofs)
+	nextPH := fx.NextProposedHeader([]byte("whatever"), 0)
+
+	require.NoError(t, dhfx.CommittedHeaderStore.SaveCommittedHeader(ctx, tmconsensus.CommittedHeader{
+		Header: ph1.Header,
+		Proof:  nextPH.Header.PrevCommitProof,
+	}))
+
+	rhCh := make(chan tmelink.ReplayedHeaderRequest)
+	sc := gp2papi.NewCatchupClient(
+		ctx,
+		gtest.NewLogger(t).With("sys", "syncclient"),
+		gp2papi.CatchupClientConfig{
+			Host:               dhfx.P2PClientConn.Host().Libp2pHost(),
+			Unmarshaler:        dhfx.Codec,
+			CryptoRegistry:     dhfx.CryptoRegistry,
+			TxDecoder:          gservertest.HashOnlyTransactionDecoder{},
+			RequestCache:       dhfx.Cache,
+			ReplayedHeadersOut: rhCh,
+
+			// Just short of the stored block data.
+			MaxBlockDataSize: buf.Len() - 1,
+		},
+	)
+	defer sc.Wait()
+	defer cancel()
+
+	require.True(t, sc.AddPeer(ctx, dhfx.P2PHostConn.Host().Libp2pHost().ID()))
+	require.True(t, sc.ResumeFetching(ctx, 1, 2))
+
+	// The oversized response is rejected,
+	// and there is no other peer to fetch from.
+	gtest.NotSendingSoon(t, rhCh)
+
+	_, ok := dhfx.Cache.Get(dataID)
+	require.False(t, ok)
+}
+
+func TestCatchupClient_headerOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dhfx := NewFixture(t, ctx)
+
+	fx := tmconsensustest.NewEd25519Fixture(4)
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+
+	// The host has the committed header but deliberately not the block data,
+	// so the client cannot be getting it from the host.
+	dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+	ph1 := fx.NextProposedHeader([]byte(dataID), 0)
+	fx.SignProposal(ctx, &ph1, 0)
+
+	precommitProofs := fx.PrecommitProofMap(ctx, 1, 0, map[string][]int{
+		string(ph1.Header.Hash): {0, 1, 2, 3},
+	})
+	fx.CommitBlock(ph1.Header, []byte("app_state_1"), 0, precommitProofs)
+	nextPH := fx.NextProposedHeader([]byte("whatever"), 0)
+
+	require.NoError(t, dhfx.CommittedHeaderStore.SaveCommittedHeader(ctx, tmconsensus.CommittedHeader{
+		Header: ph1.Header,
+		Proof:  nextPH.Header.PrevCommitProof,
+	}))
+
+	src := &chanBlockDataSource{
+		Headers: make(chan tmconsensus.Header, 1),
+		Data:    make(chan []byte),
+	}
+
+	rhCh := make(chan tmelink.ReplayedHeaderRequest)
+	sc := gp2papi.NewCatchupClient(
+		ctx,
+		gtest.NewLogger(t).With("sys", "syncclient"),
+		gp2papi.CatchupClientConfig{
+			Host:               dhfx.P2PClientConn.Host().Libp2pHost(),
+			Unmarshaler:        dhfx.Codec,
+			CryptoRegistry:     dhfx.CryptoRegistry,
+			TxDecoder:          gservertest.HashOnlyTransactionDecoder{},
+			RequestCache:       dhfx.Cache,
+			ReplayedHeadersOut: rhCh,
+
+			BlockDataSource: src,
+		},
+	)
+	defer sc.Wait()
+	defer cancel()
+
+	require.True(t, sc.AddPeer(ctx, dhfx.P2PHostConn.Host().Libp2pHost().ID()))
+	require.True(t, sc.ResumeFetching(ctx, 1, 2))
+
+	// The header is replayed without waiting for the block data.
+	replayReq := gtest.ReceiveSoon(t, rhCh)
+	require.Equal(t, ph1.Header, replayReq.Header)
+	gtest.SendSoon(t, replayReq.Resp, tmelink.ReplayedHeaderResponse{})
+
+	// The block data request is in flight.
+	r, ok := dhfx.Cache.Get(dataID)
+	require.True(t, ok)
+	gtest.NotSending(t, r.Ready)
+
+	// The source was asked for the header's data.
+	require.Equal(t, ph1.Header, gtest.ReceiveSoon(t, src.Headers))
+
+	// Data that does not match the data ID is rejected.
+	gtest.SendSoon(t, src.Data, []byte("bad data"))
+	gtest.NotSendingSoon(t, r.Ready)
+
+	// And then the client retries after a short delay,
+	// and the correct data completes the request.
+	require.Equal(t, ph1.Header, gtest.ReceiveOrTimeout(t, src.Headers, gtest.ScaleMs(1000)))
+	gtest.SendSoon(t, src.Data, buf.Bytes())
+
+	_ = gtest.ReceiveSoon(t, r.Ready)
+	require.Equal(t, txs, r.Transactions)
+	require.Equal(t, buf.Bytes(), r.EncodedTransactions)
+}
+
+// chanBlockDataSource is a [gp2papi.BlockDataSource]
+// that is controlled through its channels.
+type chanBlockDataSource struct {
+	Headers chan tmconsensus.Header
+	Data    chan []byte
+}
+
+func (s *chanBlockDataSource) LoadBlockData(ctx context.Context, h tmconsensus.Header) ([]byte, error) {
+	select {
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	case s.Headers <- h:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	case b := <-s.Data:
+		return b, nil
+	}
+}
+
+type peerReport struct {
+	P libp2ppeer.ID
+	O gpeer.Offense
+}
+
+// chanPeerReporter is a [gpeer.Reporter] that sends every report on the channel.
+type chanPeerReporter chan peerReport
+
+func (r chanPeerReporter) ReportPeer(p libp2ppeer.ID, o gpeer.Offense) {
+	r <- peerReport{P: p, O: o}
+}
+
+func TestCatchupClient_FetchBlockData(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dhfx := NewFixture(t, ctx)
+
+	fx := tmconsensustest.NewEd25519Fixture(4)
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+
+	dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+	require.NoError(t, dhfx.BlockDataStore.SaveBlockData(ctx, 1, dataID, buf.Bytes()))
+
+	ph1 := fx.NextProposedHeader([]byte(dataID), 0)
+	fx.SignProposal(ctx, &ph1, 0)
+
+	precommitProofs := fx.PrecommitProofMap(ctx, 1, 0, map[string][]int{
+		string(ph1.Header.Hash): {0, 1, 2},
+		"":                      {3},
+	})
+	fx.CommitBlock(ph1.Header, []byte("app_state_1"), 0, precommitProofs)
+	nextPH := fx.NextProposedHeader([]byte("whatever"), 0)
+
+	require.NoError(t, dhfx.CommittedHeaderStore.SaveCommittedHeader(ctx, tmconsensus.CommittedHeader{
+		Header: ph1.Header,
+		Proof:  nextPH.Header.PrevCommitProof,
+	}))
+
+	rhCh := make(chan tmelink.ReplayedHeaderRequest)
+	sc := gp2papi.NewCatchupClient(
+		ctx,
+		gtest.NewLogger(t).With("sys", "syncclient"),
+		gp2papi.CatchupClientConfig{
+			Host:               dhfx.P2PClientConn.Host().Libp2pHost(),
+			Unmarshaler:        dhfx.Codec,
+			CryptoRegistry:     dhfx.CryptoRegistry,
+			TxDecoder:          gservertest.HashOnlyTransactionDecoder{},
+			RequestCache:       dhfx.Cache,
+			ReplayedHeadersOut: rhCh,
+		},
+	)
+	defer sc.Wait()
+	defer cancel()
+
+	// No peers were added, and fetching was never resumed;
+	// the peer is given directly.
+	hostID := dhfx.P2PHostConn.Host().Libp2pHost().ID()
+	got, err := sc.FetchBlockData(ctx, hostID, ph1.Header)
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), got)
+
+	// A header with a different data ID at the same height is rejected.
+	otherHeader := ph1.Header
+	otherHeader.DataID = []byte(gsbd.DataID(1, 1, 0, nil))
+	_, err = sc.FetchBlockData(ctx, hostID, otherHeader)
+	require.ErrorContains(t, err, "data ID")
+
+	// And an unknown height is an error.
+	_, err = sc.FetchBlockData(ctx, hostID, nextPH.Header)
+	require.Error(t, err)
+}
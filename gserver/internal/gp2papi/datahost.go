@@ -1,7 +1,9 @@
 package gp2papi
 
 import (
+	"bufio"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,20 +13,28 @@ import (
 	"time"
 
 	"github.com/gordian-engine/gcosmos/gcstore"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi/gp2papipb"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gordian/gcrypto"
 	"github.com/gordian-engine/gordian/tm/tmcodec"
 	"github.com/gordian-engine/gordian/tm/tmconsensus"
 	"github.com/gordian-engine/gordian/tm/tmstore"
 	libp2phost "github.com/libp2p/go-libp2p/core/host"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	libp2pprotocol "github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/protobuf/encoding/protodelim"
 )
 
 // DataHost provides header and block data over libp2p.
 //
-// It is currently coupled to JSON encoding (see the tm/tmcodec/tmjson package).
-// The codec interfaces will need updated to handle wrapping the value or an error,
-// in order to decouple this from JSON.
+// Full blocks are served one height per stream on the v1 protocol,
+// or as a contiguous range of heights on the v2 range protocol.
+//
+// Each protocol is served in two encodings, selected by protocol ID.
+// The original protocol IDs use JSON (see the tm/tmcodec/tmjson package),
+// and the protocol IDs with a "pb" segment use the protobuf messages in the gp2papipb package.
+// Clients should prefer the protobuf encoding;
+// the JSON encoding remains for compatibility with older clients.
 type DataHost struct {
 	// We unfortunately store the life context of the DataHost as a field,
 	// because there does not appear to be a way to get
@@ -39,33 +49,61 @@ type DataHost struct {
 	bds gcstore.BlockDataStore
 
 	codec tmcodec.MarshalCodec
+	reg   *gcrypto.Registry
 
 	done chan struct{}
 }
 
+// DataHostConfig is the configuration for a [DataHost].
+type DataHostConfig struct {
+	// The host on which to register the stream handlers.
+	Host libp2phost.Host
+
+	// Where to load the served headers and block data.
+	CommittedHeaderStore tmstore.CommittedHeaderStore
+	BlockDataStore       gcstore.BlockDataStore
+
+	// The codec for the JSON protocols.
+	Codec tmcodec.MarshalCodec
+
+	// Used to encode validator public keys on the protobuf protocols.
+	CryptoRegistry *gcrypto.Registry
+}
+
 const (
 	// Just the committed header.
 	headerV1HeightPrefix = "/gcosmos/committed_headers/v1/height/"
 
 	// The committed header with the block data.
 	fullBlockV1HeightPrefix = "/gcosmos/full_blocks/v1/height/"
+
+	// A range of committed headers with their block data,
+	// in a single stream; see rangeproto.go for the wire format.
+	fullBlockV2RangeProtocol = "/gcosmos/full_blocks/v2/range"
+
+	// The protobuf-encoded equivalents of the above.
+	// Each response is a single length-delimited
+	// CommittedHeaderResponse or FullBlockResponse message,
+	// except on the range protocol,
+	// where there is one FullBlockResponse per height.
+	headerV1PBHeightPrefix     = "/gcosmos/committed_headers/v1/pb/height/"
+	fullBlockV1PBHeightPrefix  = "/gcosmos/full_blocks/v1/pb/height/"
+	fullBlockV2PBRangeProtocol = "/gcosmos/full_blocks/v2/pb/range"
 )
 
 func NewDataHost(
 	ctx context.Context,
 	log *slog.Logger,
-	host libp2phost.Host,
-	chs tmstore.CommittedHeaderStore,
-	bds gcstore.BlockDataStore,
-	codec tmcodec.MarshalCodec,
+	cfg DataHostConfig,
 ) *DataHost {
 	h := &DataHost{
 		ctx:   ctx,
 		log:   log,
-		host:  host,
-		chs:   chs,
-		bds:   bds,
-		codec: codec,
+		host:  cfg.Host,
+		chs:   cfg.CommittedHeaderStore,
+		bds:   cfg.BlockDataStore,
+		codec: cfg.Codec,
+		reg:   cfg.CryptoRegistry,
 
 		done: make(chan struct{}),
 	}
@@ -74,53 +112,64 @@ func NewDataHost(
 
 	h.host.SetStreamHandlerMatch(
 		libp2pprotocol.ID(headerV1HeightPrefix),
-		func(id libp2pprotocol.ID) bool {
-			heightS, ok := strings.CutPrefix(string(id), headerV1HeightPrefix)
-			if !ok {
-				return false
-			}
-
-			if len(heightS) == 0 {
-				return false
-			}
-
-			for _, rn := range heightS {
-				if rn < '0' || rn > '9' {
-					return false
-				}
-			}
-
-			return true
-		},
+		heightProtocolMatcher(headerV1HeightPrefix),
 		h.handleCommittedHeaderStream,
 	)
 
 	h.host.SetStreamHandlerMatch(
 		libp2pprotocol.ID(fullBlockV1HeightPrefix),
-		func(id libp2pprotocol.ID) bool {
-			heightS, ok := strings.CutPrefix(string(id), fullBlockV1HeightPrefix)
-			if !ok {
-				return false
-			}
+		heightProtocolMatcher(fullBlockV1HeightPrefix),
+		h.handleFullBlockStream,
+	)
 
-			if len(heightS) == 0 {
-				return false
-			}
+	h.host.SetStreamHandler(
+		libp2pprotocol.ID(fullBlockV2RangeProtocol),
+		h.handleFullBlockRangeStream,
+	)
 
-			for _, rn := range heightS {
-				if rn < '0' || rn > '9' {
-					return false
-				}
-			}
+	h.host.SetStreamHandlerMatch(
+		libp2pprotocol.ID(headerV1PBHeightPrefix),
+		heightProtocolMatcher(headerV1PBHeightPrefix),
+		h.handlePBCommittedHeaderStream,
+	)
 
-			return true
-		},
-		h.handleFullBlockStream,
+	h.host.SetStreamHandlerMatch(
+		libp2pprotocol.ID(fullBlockV1PBHeightPrefix),
+		heightProtocolMatcher(fullBlockV1PBHeightPrefix),
+		h.handlePBFullBlockStream,
+	)
+
+	h.host.SetStreamHandler(
+		libp2pprotocol.ID(fullBlockV2PBRangeProtocol),
+		h.handleFullBlockRangeStream,
 	)
 
 	return h
 }
 
+// heightProtocolMatcher returns a function that reports whether a protocol ID
+// is the given prefix followed by a decimal height.
+func heightProtocolMatcher(prefix string) func(libp2pprotocol.ID) bool {
+	return func(id libp2pprotocol.ID) bool {
+		heightS, ok := strings.CutPrefix(string(id), prefix)
+		if !ok {
+			return false
+		}
+
+		if len(heightS) == 0 {
+			return false
+		}
+
+		for _, rn := range heightS {
+			if rn < '0' || rn > '9' {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
 func (h *DataHost) Wait() {
 	<-h.done
 }
@@ -131,6 +180,11 @@ func (h *DataHost) waitForCancellation() {
 	// without shutting down the whole process.
 	<-h.ctx.Done()
 	h.host.RemoveStreamHandler(libp2pprotocol.ID(headerV1HeightPrefix))
+	h.host.RemoveStreamHandler(libp2pprotocol.ID(fullBlockV1HeightPrefix))
+	h.host.RemoveStreamHandler(libp2pprotocol.ID(fullBlockV2RangeProtocol))
+	h.host.RemoveStreamHandler(libp2pprotocol.ID(headerV1PBHeightPrefix))
+	h.host.RemoveStreamHandler(libp2pprotocol.ID(fullBlockV1PBHeightPrefix))
+	h.host.RemoveStreamHandler(libp2pprotocol.ID(fullBlockV2PBRangeProtocol))
 	close(h.done)
 }
 
@@ -217,12 +271,6 @@ func (h *DataHost) handleFullBlockStream(s libp2pnetwork.Stream) {
 	// We don't read any input on this path.
 	_ = s.CloseRead()
 
-	// The stream value apparently has no way to retrieve a corresponding context,
-	// so we use the root context associated with the DataHost
-	// and add a 1-second timeout, which ought to suffice for any reasonable client.
-	ctx, cancel := context.WithTimeout(h.ctx, time.Second)
-	defer cancel()
-
 	heightS, ok := strings.CutPrefix(string(s.Protocol()), fullBlockV1HeightPrefix)
 	if !ok {
 		_ = json.NewEncoder(s).Encode(JSONResult{
@@ -239,18 +287,11 @@ func (h *DataHost) handleFullBlockStream(s libp2pnetwork.Stream) {
 		return
 	}
 
-	ch, err := h.chs.LoadCommittedHeader(ctx, height)
-	if err != nil {
-		// TODO: There are probably certain cases we do want to expose.
-		// For now, just mask it.
-		var res JSONResult
-		if errors.As(err, new(tmconsensus.HeightUnknownError)) {
-			// Special string that the client recognizes.
-			res.Err = "height unknown"
-		} else {
-			res.Err = "failed to load header at height"
-		}
-		_ = json.NewEncoder(s).Encode(res)
+	ch, data, errMsg := h.loadFullBlock(height)
+	if errMsg != "" {
+		_ = json.NewEncoder(s).Encode(JSONResult{
+			Err: errMsg,
+		})
 		return
 	}
 
@@ -267,37 +308,6 @@ func (h *DataHost) handleFullBlockStream(s libp2pnetwork.Stream) {
 		return
 	}
 
-	var data []byte
-	if !gsbd.IsZeroTxDataID(string(ch.Header.DataID)) {
-		// Only get the block data when the data ID
-		// indicates we have non-zero block data.
-		// It shouldn't matter whether we load by height or ID,
-		// but since the request was by block height
-		// we will just use that too.
-		// (We could use a sync.Pool to reduce allocations on loaded block data here.)
-		id, bd, err := h.bds.LoadBlockDataByHeight(ctx, height, nil)
-		if err != nil {
-			h.log.Info(
-				"Failed to load block data for height that does have a committed header",
-				"height", height,
-				"err", err,
-			)
-			_ = json.NewEncoder(s).Encode(JSONResult{
-				Err: "failed to load block data at height",
-			})
-			return
-		}
-
-		if id != string(ch.Header.DataID) {
-			panic(fmt.Errorf(
-				"DATA CORRUPTION: at height %d, header recorded data ID %q but block data store had ID %q",
-				height, ch.Header.DataID, id,
-			))
-		}
-
-		data = bd
-	}
-
 	fbr := FullBlockResult{
 		Header:    b,
 		BlockData: data,
@@ -320,6 +330,232 @@ func (h *DataHost) handleFullBlockStream(s libp2pnetwork.Stream) {
 	})
 }
 
+func (h *DataHost) handlePBCommittedHeaderStream(s libp2pnetwork.Stream) {
+	defer s.Close()
+
+	// We don't read any input on this path.
+	_ = s.CloseRead()
+
+	// See handleCommittedHeaderStream for the reasoning behind this timeout.
+	ctx, cancel := context.WithTimeout(h.ctx, time.Second)
+	defer cancel()
+
+	writeErr := func(msg string) {
+		_, _ = protodelim.MarshalTo(s, &gp2papipb.CommittedHeaderResponse{
+			Result: &gp2papipb.CommittedHeaderResponse_Error{Error: msg},
+		})
+	}
+
+	heightS, ok := strings.CutPrefix(string(s.Protocol()), headerV1PBHeightPrefix)
+	if !ok {
+		writeErr("invalid protocol")
+		return
+	}
+
+	height, err := strconv.ParseUint(heightS, 10, 64)
+	if err != nil {
+		writeErr("invalid height")
+		return
+	}
+
+	ch, err := h.chs.LoadCommittedHeader(ctx, height)
+	if err != nil {
+		if errors.As(err, new(tmconsensus.HeightUnknownError)) {
+			// Special string that the client recognizes.
+			writeErr("height unknown")
+		} else {
+			writeErr("failed to load header at height")
+		}
+		return
+	}
+
+	_, _ = protodelim.MarshalTo(s, &gp2papipb.CommittedHeaderResponse{
+		Result: &gp2papipb.CommittedHeaderResponse_CommittedHeader{
+			CommittedHeader: committedHeaderToPB(ch, h.reg),
+		},
+	})
+}
+
+func (h *DataHost) handlePBFullBlockStream(s libp2pnetwork.Stream) {
+	defer s.Close()
+
+	// We don't read any input on this path.
+	_ = s.CloseRead()
+
+	heightS, ok := strings.CutPrefix(string(s.Protocol()), fullBlockV1PBHeightPrefix)
+	if !ok {
+		_ = h.writePBFullBlockError(s, "invalid protocol")
+		return
+	}
+
+	height, err := strconv.ParseUint(heightS, 10, 64)
+	if err != nil {
+		_ = h.writePBFullBlockError(s, "invalid height")
+		return
+	}
+
+	ch, data, errMsg := h.loadFullBlock(height)
+	if errMsg != "" {
+		_ = h.writePBFullBlockError(s, errMsg)
+		return
+	}
+
+	_ = h.writePBFullBlock(s, ch, data)
+}
+
+func (h *DataHost) writePBFullBlock(
+	s libp2pnetwork.Stream, ch tmconsensus.CommittedHeader, blockData []byte,
+) error {
+	_, err := protodelim.MarshalTo(s, &gp2papipb.FullBlockResponse{
+		Result: &gp2papipb.FullBlockResponse_FullBlock{
+			FullBlock: &gp2papipb.FullBlock{
+				CommittedHeader: committedHeaderToPB(ch, h.reg),
+				BlockData:       blockData,
+			},
+		},
+	})
+	return err
+}
+
+func (h *DataHost) writePBFullBlockError(s libp2pnetwork.Stream, msg string) error {
+	_, err := protodelim.MarshalTo(s, &gp2papipb.FullBlockResponse{
+		Result: &gp2papipb.FullBlockResponse_Error{Error: msg},
+	})
+	return err
+}
+
+// rangeRecordWriteTimeout is how long the host waits
+// for a client to accept a single record on a v2 range stream.
+const rangeRecordWriteTimeout = 2 * time.Second
+
+// handleFullBlockRangeStream serves both the JSON and protobuf range protocols.
+// The request format is the same for both;
+// only the encoding of each record differs.
+func (h *DataHost) handleFullBlockRangeStream(s libp2pnetwork.Stream) {
+	defer s.Close()
+
+	usePB := s.Protocol() == fullBlockV2PBRangeProtocol
+	writeErr := func(msg string) error {
+		if usePB {
+			return h.writePBFullBlockError(s, msg)
+		}
+		return writeRangeError(s, msg)
+	}
+
+	// The request is only two uvarints,
+	// so it should arrive very quickly.
+	_ = s.SetReadDeadline(time.Now().Add(time.Second))
+	start, end, err := readRangeRequest(bufio.NewReaderSize(s, 2*binary.MaxVarintLen64))
+	_ = s.CloseRead()
+	if err != nil {
+		_ = writeErr("invalid range request")
+		return
+	}
+
+	// Serve no more than the maximum batch size.
+	// The client will request the remainder on a new stream.
+	if end-start >= MaxFullBlockRangeSize {
+		end = start + MaxFullBlockRangeSize - 1
+	}
+
+	for height := start; height <= end; height++ {
+		if h.ctx.Err() != nil {
+			// Shutting down; just close the stream.
+			return
+		}
+
+		ch, data, errMsg := h.loadFullBlock(height)
+
+		// Writes block when the client is not keeping up,
+		// so set a deadline on each record.
+		_ = s.SetWriteDeadline(time.Now().Add(rangeRecordWriteTimeout))
+
+		if errMsg != "" {
+			_ = writeErr(errMsg)
+			return
+		}
+
+		if usePB {
+			err = h.writePBFullBlock(s, ch, data)
+		} else {
+			var b []byte
+			b, err = h.codec.MarshalCommittedHeader(ch)
+			if err != nil {
+				h.log.Info(
+					"Failed to marshal requested committed header",
+					"height", height,
+					"err", err,
+				)
+				_ = writeErr("internal serialization error")
+				return
+			}
+			err = writeRangeFullBlock(s, b, data)
+		}
+		if err != nil {
+			h.log.Debug(
+				"Failed to write full block record to range stream",
+				"height", height,
+				"err", err,
+			)
+			return
+		}
+	}
+}
+
+// loadFullBlock loads the committed header at the given height,
+// and loads the corresponding block data if the header indicates there is any.
+//
+// On failure, errMsg is set to a message appropriate to send to the client.
+func (h *DataHost) loadFullBlock(height uint64) (
+	ch tmconsensus.CommittedHeader, blockData []byte, errMsg string,
+) {
+	// The stream value apparently has no way to retrieve a corresponding context,
+	// so we use the root context associated with the DataHost
+	// and add a 1-second timeout, which ought to suffice for any reasonable client.
+	ctx, cancel := context.WithTimeout(h.ctx, time.Second)
+	defer cancel()
+
+	ch, err := h.chs.LoadCommittedHeader(ctx, height)
+	if err != nil {
+		// TODO: There are probably certain cases we do want to expose.
+		// For now, just mask it.
+		if errors.As(err, new(tmconsensus.HeightUnknownError)) {
+			// Special string that the client recognizes.
+			return tmconsensus.CommittedHeader{}, nil, "height unknown"
+		}
+		return tmconsensus.CommittedHeader{}, nil, "failed to load header at height"
+	}
+
+	if gsbd.IsZeroTxDataID(string(ch.Header.DataID)) {
+		// Only get the block data when the data ID
+		// indicates we have non-zero block data.
+		return ch, nil, ""
+	}
+
+	// It shouldn't matter whether we load by height or ID,
+	// but since the request was by block height
+	// we will just use that too.
+	// (We could use a sync.Pool to reduce allocations on loaded block data here.)
+	id, bd, err := h.bds.LoadBlockDataByHeight(ctx, height, nil)
+	if err != nil {
+		h.log.Info(
+			"Failed to load block data for height that does have a committed header",
+			"height", height,
+			"err", err,
+		)
+		return tmconsensus.CommittedHeader{}, nil, "failed to load block data at height"
+	}
+
+	if id != string(ch.Header.DataID) {
+		panic(fmt.Errorf(
+			"DATA CORRUPTION: at height %d, header recorded data ID %q but block data store had ID %q",
+			height, ch.Header.DataID, id,
+		))
+	}
+
+	return ch, bd, ""
+}
+
 // JSONResult is currently used to wrap results from gp2papi calls.
 // It is likely to be superseded by something else soon.
 type JSONResult struct {
@@ -1,18 +1,21 @@
 package gp2papi_test
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"io"
 	"testing"
 
 	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi/gp2papipb"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gordian/tm/tmconsensus"
 	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
 	libp2phost "github.com/libp2p/go-libp2p/core/host"
 	libp2pprotocol "github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
 )
 
 func TestDataHost_serveCommittedHeader(t *testing.T) {
@@ -214,3 +217,70 @@ func TestDataHost_fullBlock(t *testing.T) {
 		require.Contains(t, jr.Err, "height unknown")
 	})
 }
+
+func TestDataHost_fullBlock_protobuf(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dhfx := NewFixture(t, ctx)
+
+	fx := tmconsensustest.NewEd25519Fixture(4)
+	ph1 := fx.NextProposedHeader([]byte("app_data_1"), 0)
+	fx.SignProposal(ctx, &ph1, 0)
+
+	precommitProofs := fx.PrecommitProofMap(ctx, 1, 0, map[string][]int{
+		string(ph1.Header.Hash): {0, 1, 2},
+		"":                      {3},
+	})
+	fx.CommitBlock(ph1.Header, []byte("app_state_1"), 0, precommitProofs)
+	nextPH := fx.NextProposedHeader([]byte("whatever"), 0)
+
+	require.NoError(t, dhfx.CommittedHeaderStore.SaveCommittedHeader(ctx, tmconsensus.CommittedHeader{
+		Header: ph1.Header,
+		Proof:  nextPH.Header.PrevCommitProof,
+	}))
+	require.NoError(t, dhfx.BlockDataStore.SaveBlockData(
+		ctx,
+		ph1.Header.Height, string(ph1.Header.DataID),
+		[]byte("some block data"),
+	))
+
+	hostInfo := libp2phost.InfoFromHost(dhfx.P2PHostConn.Host().Libp2pHost())
+	require.NoError(t, dhfx.P2PClientConn.Host().Libp2pHost().Connect(ctx, *hostInfo))
+
+	s, err := dhfx.P2PClientConn.Host().Libp2pHost().NewStream(ctx, hostInfo.ID, libp2pprotocol.ID(
+		"/gcosmos/full_blocks/v1/pb/height/1",
+	))
+	require.NoError(t, err)
+	defer s.Close()
+
+	var resp gp2papipb.FullBlockResponse
+	require.NoError(t, protodelim.UnmarshalFrom(bufio.NewReader(s), &resp))
+	require.Empty(t, resp.GetError())
+
+	fb := resp.GetFullBlock()
+	require.NotNil(t, fb)
+	require.Equal(t, "some block data", string(fb.BlockData))
+	require.Equal(t, ph1.Header.Hash, fb.CommittedHeader.Header.Hash)
+	require.Equal(t, uint64(1), fb.CommittedHeader.Header.Height)
+
+	// The commit proof entries are encoded in block hash order,
+	// so the same proof always encodes identically.
+	proofs := fb.CommittedHeader.Proof.Proofs
+	require.Len(t, proofs, 2)
+	require.Empty(t, proofs[0].BlockHash)
+	require.Equal(t, ph1.Header.Hash, proofs[1].BlockHash)
+
+	// And an unknown height is reported as an error.
+	s, err = dhfx.P2PClientConn.Host().Libp2pHost().NewStream(ctx, hostInfo.ID, libp2pprotocol.ID(
+		"/gcosmos/full_blocks/v1/pb/height/30",
+	))
+	require.NoError(t, err)
+	defer s.Close()
+
+	resp.Reset()
+	require.NoError(t, protodelim.UnmarshalFrom(bufio.NewReader(s), &resp))
+	require.Equal(t, "height unknown", resp.GetError())
+}
@@ -28,6 +28,8 @@ type Fixture struct {
 	P2PClientConn *tmlibp2p.Connection
 
 	Codec tmjson.MarshalCodec
+
+	CryptoRegistry *gcrypto.Registry
 }
 
 func NewFixture(t *testing.T, ctx context.Context) *Fixture {
@@ -59,10 +61,13 @@ func NewFixture(t *testing.T, ctx context.Context) *Fixture {
 	bds := gcmemstore.NewBlockDataStore()
 	dh := gp2papi.NewDataHost(
 		ctx, log,
-		host.Host().Libp2pHost(),
-		chs,
-		bds,
-		codec,
+		gp2papi.DataHostConfig{
+			Host:                 host.Host().Libp2pHost(),
+			CommittedHeaderStore: chs,
+			BlockDataStore:       bds,
+			Codec:                codec,
+			CryptoRegistry:       reg,
+		},
 	)
 	t.Cleanup(dh.Wait)
 
@@ -78,5 +83,7 @@ func NewFixture(t *testing.T, ctx context.Context) *Fixture {
 		P2PClientConn: client,
 
 		Codec: codec,
+
+		CryptoRegistry: reg,
 	}
 }
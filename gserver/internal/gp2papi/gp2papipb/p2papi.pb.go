@@ -0,0 +1,872 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        v5.28.3
+// source: proto/gordian/p2papi/v1/p2papi.proto
+
+package gp2papipb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SparseSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyId []byte `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Sig   []byte `protobuf:"bytes,2,opt,name=sig,proto3" json:"sig,omitempty"`
+}
+
+func (x *SparseSignature) Reset() {
+	*x = SparseSignature{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SparseSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SparseSignature) ProtoMessage() {}
+
+func (x *SparseSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SparseSignature.ProtoReflect.Descriptor instead.
+func (*SparseSignature) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SparseSignature) GetKeyId() []byte {
+	if x != nil {
+		return x.KeyId
+	}
+	return nil
+}
+
+func (x *SparseSignature) GetSig() []byte {
+	if x != nil {
+		return x.Sig
+	}
+	return nil
+}
+
+// CommitProofEntry is one entry in the tmconsensus.CommitProof Proofs map.
+// An empty block_hash indicates votes for nil.
+type CommitProofEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHash  []byte             `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Signatures []*SparseSignature `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *CommitProofEntry) Reset() {
+	*x = CommitProofEntry{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitProofEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitProofEntry) ProtoMessage() {}
+
+func (x *CommitProofEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitProofEntry.ProtoReflect.Descriptor instead.
+func (*CommitProofEntry) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CommitProofEntry) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *CommitProofEntry) GetSignatures() []*SparseSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+type CommitProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round      uint32              `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	PubKeyHash []byte              `protobuf:"bytes,2,opt,name=pub_key_hash,json=pubKeyHash,proto3" json:"pub_key_hash,omitempty"`
+	Proofs     []*CommitProofEntry `protobuf:"bytes,3,rep,name=proofs,proto3" json:"proofs,omitempty"`
+}
+
+func (x *CommitProof) Reset() {
+	*x = CommitProof{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitProof) ProtoMessage() {}
+
+func (x *CommitProof) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitProof.ProtoReflect.Descriptor instead.
+func (*CommitProof) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CommitProof) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *CommitProof) GetPubKeyHash() []byte {
+	if x != nil {
+		return x.PubKeyHash
+	}
+	return nil
+}
+
+func (x *CommitProof) GetProofs() []*CommitProofEntry {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
+type Validator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Encoded through a gcrypto.Registry.
+	EncodedPubKey []byte `protobuf:"bytes,1,opt,name=encoded_pub_key,json=encodedPubKey,proto3" json:"encoded_pub_key,omitempty"`
+	Power         uint64 `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+}
+
+func (x *Validator) Reset() {
+	*x = Validator{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Validator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Validator) ProtoMessage() {}
+
+func (x *Validator) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Validator.ProtoReflect.Descriptor instead.
+func (*Validator) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Validator) GetEncodedPubKey() []byte {
+	if x != nil {
+		return x.EncodedPubKey
+	}
+	return nil
+}
+
+func (x *Validator) GetPower() uint64 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
+type ValidatorSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Validators    []*Validator `protobuf:"bytes,1,rep,name=validators,proto3" json:"validators,omitempty"`
+	PubKeyHash    []byte       `protobuf:"bytes,2,opt,name=pub_key_hash,json=pubKeyHash,proto3" json:"pub_key_hash,omitempty"`
+	VotePowerHash []byte       `protobuf:"bytes,3,opt,name=vote_power_hash,json=votePowerHash,proto3" json:"vote_power_hash,omitempty"`
+}
+
+func (x *ValidatorSet) Reset() {
+	*x = ValidatorSet{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidatorSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorSet) ProtoMessage() {}
+
+func (x *ValidatorSet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorSet.ProtoReflect.Descriptor instead.
+func (*ValidatorSet) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ValidatorSet) GetValidators() []*Validator {
+	if x != nil {
+		return x.Validators
+	}
+	return nil
+}
+
+func (x *ValidatorSet) GetPubKeyHash() []byte {
+	if x != nil {
+		return x.PubKeyHash
+	}
+	return nil
+}
+
+func (x *ValidatorSet) GetVotePowerHash() []byte {
+	if x != nil {
+		return x.VotePowerHash
+	}
+	return nil
+}
+
+type Header struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash             []byte        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	PrevBlockHash    []byte        `protobuf:"bytes,2,opt,name=prev_block_hash,json=prevBlockHash,proto3" json:"prev_block_hash,omitempty"`
+	Height           uint64        `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	PrevCommitProof  *CommitProof  `protobuf:"bytes,4,opt,name=prev_commit_proof,json=prevCommitProof,proto3" json:"prev_commit_proof,omitempty"`
+	ValidatorSet     *ValidatorSet `protobuf:"bytes,5,opt,name=validator_set,json=validatorSet,proto3" json:"validator_set,omitempty"`
+	NextValidatorSet *ValidatorSet `protobuf:"bytes,6,opt,name=next_validator_set,json=nextValidatorSet,proto3" json:"next_validator_set,omitempty"`
+	DataId           []byte        `protobuf:"bytes,7,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	PrevAppStateHash []byte        `protobuf:"bytes,8,opt,name=prev_app_state_hash,json=prevAppStateHash,proto3" json:"prev_app_state_hash,omitempty"`
+	UserAnnotation   []byte        `protobuf:"bytes,9,opt,name=user_annotation,json=userAnnotation,proto3" json:"user_annotation,omitempty"`
+	DriverAnnotation []byte        `protobuf:"bytes,10,opt,name=driver_annotation,json=driverAnnotation,proto3" json:"driver_annotation,omitempty"`
+}
+
+func (x *Header) Reset() {
+	*x = Header{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Header) ProtoMessage() {}
+
+func (x *Header) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Header.ProtoReflect.Descriptor instead.
+func (*Header) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Header) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Header) GetPrevBlockHash() []byte {
+	if x != nil {
+		return x.PrevBlockHash
+	}
+	return nil
+}
+
+func (x *Header) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Header) GetPrevCommitProof() *CommitProof {
+	if x != nil {
+		return x.PrevCommitProof
+	}
+	return nil
+}
+
+func (x *Header) GetValidatorSet() *ValidatorSet {
+	if x != nil {
+		return x.ValidatorSet
+	}
+	return nil
+}
+
+func (x *Header) GetNextValidatorSet() *ValidatorSet {
+	if x != nil {
+		return x.NextValidatorSet
+	}
+	return nil
+}
+
+func (x *Header) GetDataId() []byte {
+	if x != nil {
+		return x.DataId
+	}
+	return nil
+}
+
+func (x *Header) GetPrevAppStateHash() []byte {
+	if x != nil {
+		return x.PrevAppStateHash
+	}
+	return nil
+}
+
+func (x *Header) GetUserAnnotation() []byte {
+	if x != nil {
+		return x.UserAnnotation
+	}
+	return nil
+}
+
+func (x *Header) GetDriverAnnotation() []byte {
+	if x != nil {
+		return x.DriverAnnotation
+	}
+	return nil
+}
+
+type CommittedHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header *Header      `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Proof  *CommitProof `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *CommittedHeader) Reset() {
+	*x = CommittedHeader{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommittedHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommittedHeader) ProtoMessage() {}
+
+func (x *CommittedHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommittedHeader.ProtoReflect.Descriptor instead.
+func (*CommittedHeader) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CommittedHeader) GetHeader() *Header {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *CommittedHeader) GetProof() *CommitProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type CommittedHeaderResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*CommittedHeaderResponse_CommittedHeader
+	//	*CommittedHeaderResponse_Error
+	Result isCommittedHeaderResponse_Result `protobuf_oneof:"result"`
+}
+
+func (x *CommittedHeaderResponse) Reset() {
+	*x = CommittedHeaderResponse{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommittedHeaderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommittedHeaderResponse) ProtoMessage() {}
+
+func (x *CommittedHeaderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommittedHeaderResponse.ProtoReflect.Descriptor instead.
+func (*CommittedHeaderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *CommittedHeaderResponse) GetResult() isCommittedHeaderResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *CommittedHeaderResponse) GetCommittedHeader() *CommittedHeader {
+	if x, ok := x.GetResult().(*CommittedHeaderResponse_CommittedHeader); ok {
+		return x.CommittedHeader
+	}
+	return nil
+}
+
+func (x *CommittedHeaderResponse) GetError() string {
+	if x, ok := x.GetResult().(*CommittedHeaderResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+type isCommittedHeaderResponse_Result interface {
+	isCommittedHeaderResponse_Result()
+}
+
+type CommittedHeaderResponse_CommittedHeader struct {
+	CommittedHeader *CommittedHeader `protobuf:"bytes,1,opt,name=committed_header,json=committedHeader,proto3,oneof"`
+}
+
+type CommittedHeaderResponse_Error struct {
+	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*CommittedHeaderResponse_CommittedHeader) isCommittedHeaderResponse_Result() {}
+
+func (*CommittedHeaderResponse_Error) isCommittedHeaderResponse_Result() {}
+
+type FullBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CommittedHeader *CommittedHeader `protobuf:"bytes,1,opt,name=committed_header,json=committedHeader,proto3" json:"committed_header,omitempty"`
+	// Encoded according to the rules in the gsbd package.
+	// Empty when the header has a zero data ID.
+	BlockData []byte `protobuf:"bytes,2,opt,name=block_data,json=blockData,proto3" json:"block_data,omitempty"`
+}
+
+func (x *FullBlock) Reset() {
+	*x = FullBlock{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FullBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FullBlock) ProtoMessage() {}
+
+func (x *FullBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FullBlock.ProtoReflect.Descriptor instead.
+func (*FullBlock) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FullBlock) GetCommittedHeader() *CommittedHeader {
+	if x != nil {
+		return x.CommittedHeader
+	}
+	return nil
+}
+
+func (x *FullBlock) GetBlockData() []byte {
+	if x != nil {
+		return x.BlockData
+	}
+	return nil
+}
+
+type FullBlockResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*FullBlockResponse_FullBlock
+	//	*FullBlockResponse_Error
+	Result isFullBlockResponse_Result `protobuf_oneof:"result"`
+}
+
+func (x *FullBlockResponse) Reset() {
+	*x = FullBlockResponse{}
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FullBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FullBlockResponse) ProtoMessage() {}
+
+func (x *FullBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FullBlockResponse.ProtoReflect.Descriptor instead.
+func (*FullBlockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *FullBlockResponse) GetResult() isFullBlockResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *FullBlockResponse) GetFullBlock() *FullBlock {
+	if x, ok := x.GetResult().(*FullBlockResponse_FullBlock); ok {
+		return x.FullBlock
+	}
+	return nil
+}
+
+func (x *FullBlockResponse) GetError() string {
+	if x, ok := x.GetResult().(*FullBlockResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+type isFullBlockResponse_Result interface {
+	isFullBlockResponse_Result()
+}
+
+type FullBlockResponse_FullBlock struct {
+	FullBlock *FullBlock `protobuf:"bytes,1,opt,name=full_block,json=fullBlock,proto3,oneof"`
+}
+
+type FullBlockResponse_Error struct {
+	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*FullBlockResponse_FullBlock) isFullBlockResponse_Result() {}
+
+func (*FullBlockResponse_Error) isFullBlockResponse_Result() {}
+
+var File_proto_gordian_p2papi_v1_p2papi_proto protoreflect.FileDescriptor
+
+var file_proto_gordian_p2papi_v1_p2papi_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2f,
+	0x70, 0x32, 0x70, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x32, 0x70, 0x61, 0x70, 0x69,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e,
+	0x70, 0x32, 0x70, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x22, 0x3a, 0x0a, 0x0f, 0x53, 0x70, 0x61,
+	0x72, 0x73, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x15, 0x0a, 0x06,
+	0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6b, 0x65,
+	0x79, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x03, 0x73, 0x69, 0x67, 0x22, 0x75, 0x0a, 0x10, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x42, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67,
+	0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e, 0x70, 0x32, 0x70, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x70, 0x61, 0x72, 0x73, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x82, 0x01, 0x0a,
+	0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e, 0x70,
+	0x32, 0x70, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x73, 0x22, 0x49, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x26,
+	0x0a, 0x0f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x5f, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64,
+	0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x22, 0x96, 0x01, 0x0a,
+	0x0c, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x12, 0x3c, 0x0a,
+	0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e, 0x70, 0x32, 0x70, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52,
+	0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x70,
+	0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x26, 0x0a,
+	0x0f, 0x76, 0x6f, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x76, 0x6f, 0x74, 0x65, 0x50, 0x6f, 0x77, 0x65,
+	0x72, 0x48, 0x61, 0x73, 0x68, 0x22, 0xdb, 0x03, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x70,
+	0x72, 0x65, 0x76, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x4a, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e, 0x70, 0x32, 0x70, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x0f, 0x70, 0x72, 0x65, 0x76, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x44, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x65,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61,
+	0x6e, 0x2e, 0x70, 0x32, 0x70, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x52, 0x0c, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x12, 0x4d, 0x0a, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e, 0x70, 0x32, 0x70,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x53, 0x65, 0x74, 0x52, 0x10, 0x6e, 0x65, 0x78, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x53, 0x65, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x2d,
+	0x0a, 0x13, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x61, 0x70, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x70, 0x72, 0x65,
+	0x76, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a,
+	0x0f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x75, 0x73, 0x65, 0x72, 0x41, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72,
+	0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x10, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x7a, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e,
+	0x2e, 0x70, 0x32, 0x70, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x05, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69,
+	0x61, 0x6e, 0x2e, 0x70, 0x32, 0x70, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22,
+	0x8c, 0x01, 0x0a, 0x17, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x10, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e,
+	0x70, 0x32, 0x70, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x79,
+	0x0a, 0x09, 0x46, 0x75, 0x6c, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x4d, 0x0a, 0x10, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e,
+	0x70, 0x32, 0x70, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x74, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x22, 0x74, 0x0a, 0x11, 0x46, 0x75, 0x6c,
+	0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d,
+	0x0a, 0x0a, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e, 0x70, 0x32, 0x70,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x75, 0x6c, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x48, 0x00, 0x52, 0x09, 0x66, 0x75, 0x6c, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42,
+	0x46, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f,
+	0x72, 0x64, 0x69, 0x61, 0x6e, 0x2d, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x67, 0x63, 0x6f,
+	0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x67, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x70, 0x32, 0x70, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x70,
+	0x32, 0x70, 0x61, 0x70, 0x69, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_gordian_p2papi_v1_p2papi_proto_rawDescOnce sync.Once
+	file_proto_gordian_p2papi_v1_p2papi_proto_rawDescData = file_proto_gordian_p2papi_v1_p2papi_proto_rawDesc
+)
+
+func file_proto_gordian_p2papi_v1_p2papi_proto_rawDescGZIP() []byte {
+	file_proto_gordian_p2papi_v1_p2papi_proto_rawDescOnce.Do(func() {
+		file_proto_gordian_p2papi_v1_p2papi_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_gordian_p2papi_v1_p2papi_proto_rawDescData)
+	})
+	return file_proto_gordian_p2papi_v1_p2papi_proto_rawDescData
+}
+
+var file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_gordian_p2papi_v1_p2papi_proto_goTypes = []any{
+	(*SparseSignature)(nil),         // 0: gordian.p2papi.v1.SparseSignature
+	(*CommitProofEntry)(nil),        // 1: gordian.p2papi.v1.CommitProofEntry
+	(*CommitProof)(nil),             // 2: gordian.p2papi.v1.CommitProof
+	(*Validator)(nil),               // 3: gordian.p2papi.v1.Validator
+	(*ValidatorSet)(nil),            // 4: gordian.p2papi.v1.ValidatorSet
+	(*Header)(nil),                  // 5: gordian.p2papi.v1.Header
+	(*CommittedHeader)(nil),         // 6: gordian.p2papi.v1.CommittedHeader
+	(*CommittedHeaderResponse)(nil), // 7: gordian.p2papi.v1.CommittedHeaderResponse
+	(*FullBlock)(nil),               // 8: gordian.p2papi.v1.FullBlock
+	(*FullBlockResponse)(nil),       // 9: gordian.p2papi.v1.FullBlockResponse
+}
+var file_proto_gordian_p2papi_v1_p2papi_proto_depIdxs = []int32{
+	0,  // 0: gordian.p2papi.v1.CommitProofEntry.signatures:type_name -> gordian.p2papi.v1.SparseSignature
+	1,  // 1: gordian.p2papi.v1.CommitProof.proofs:type_name -> gordian.p2papi.v1.CommitProofEntry
+	3,  // 2: gordian.p2papi.v1.ValidatorSet.validators:type_name -> gordian.p2papi.v1.Validator
+	2,  // 3: gordian.p2papi.v1.Header.prev_commit_proof:type_name -> gordian.p2papi.v1.CommitProof
+	4,  // 4: gordian.p2papi.v1.Header.validator_set:type_name -> gordian.p2papi.v1.ValidatorSet
+	4,  // 5: gordian.p2papi.v1.Header.next_validator_set:type_name -> gordian.p2papi.v1.ValidatorSet
+	5,  // 6: gordian.p2papi.v1.CommittedHeader.header:type_name -> gordian.p2papi.v1.Header
+	2,  // 7: gordian.p2papi.v1.CommittedHeader.proof:type_name -> gordian.p2papi.v1.CommitProof
+	6,  // 8: gordian.p2papi.v1.CommittedHeaderResponse.committed_header:type_name -> gordian.p2papi.v1.CommittedHeader
+	6,  // 9: gordian.p2papi.v1.FullBlock.committed_header:type_name -> gordian.p2papi.v1.CommittedHeader
+	8,  // 10: gordian.p2papi.v1.FullBlockResponse.full_block:type_name -> gordian.p2papi.v1.FullBlock
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_proto_gordian_p2papi_v1_p2papi_proto_init() }
+func file_proto_gordian_p2papi_v1_p2papi_proto_init() {
+	if File_proto_gordian_p2papi_v1_p2papi_proto != nil {
+		return
+	}
+	file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[7].OneofWrappers = []any{
+		(*CommittedHeaderResponse_CommittedHeader)(nil),
+		(*CommittedHeaderResponse_Error)(nil),
+	}
+	file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes[9].OneofWrappers = []any{
+		(*FullBlockResponse_FullBlock)(nil),
+		(*FullBlockResponse_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_gordian_p2papi_v1_p2papi_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_gordian_p2papi_v1_p2papi_proto_goTypes,
+		DependencyIndexes: file_proto_gordian_p2papi_v1_p2papi_proto_depIdxs,
+		MessageInfos:      file_proto_gordian_p2papi_v1_p2papi_proto_msgTypes,
+	}.Build()
+	File_proto_gordian_p2papi_v1_p2papi_proto = out.File
+	file_proto_gordian_p2papi_v1_p2papi_proto_rawDesc = nil
+	file_proto_gordian_p2papi_v1_p2papi_proto_goTypes = nil
+	file_proto_gordian_p2papi_v1_p2papi_proto_depIdxs = nil
+}
@@ -0,0 +1,191 @@
+package gp2papi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi/gp2papipb"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+)
+
+// Conversions between tmconsensus values and the protobuf messages
+// used on the binary protocol IDs.
+// These mirror the conversions in the tmjson package,
+// so that a header round-trips identically through either encoding.
+
+func committedHeaderToPB(
+	ch tmconsensus.CommittedHeader, reg *gcrypto.Registry,
+) *gp2papipb.CommittedHeader {
+	return &gp2papipb.CommittedHeader{
+		Header: headerToPB(ch.Header, reg),
+		Proof:  commitProofToPB(ch.Proof),
+	}
+}
+
+func committedHeaderFromPB(
+	pb *gp2papipb.CommittedHeader, reg *gcrypto.Registry,
+) (tmconsensus.CommittedHeader, error) {
+	if pb.GetHeader() == nil {
+		return tmconsensus.CommittedHeader{}, errors.New("missing header")
+	}
+	h, err := headerFromPB(pb.Header, reg)
+	if err != nil {
+		return tmconsensus.CommittedHeader{}, fmt.Errorf(
+			"failed to unmarshal committed header: %w", err,
+		)
+	}
+
+	return tmconsensus.CommittedHeader{
+		Header: h,
+		Proof:  commitProofFromPB(pb.Proof),
+	}, nil
+}
+
+func headerToPB(h tmconsensus.Header, reg *gcrypto.Registry) *gp2papipb.Header {
+	return &gp2papipb.Header{
+		Hash:          h.Hash,
+		PrevBlockHash: h.PrevBlockHash,
+
+		Height: h.Height,
+
+		PrevCommitProof: commitProofToPB(h.PrevCommitProof),
+
+		ValidatorSet:     validatorSetToPB(h.ValidatorSet, reg),
+		NextValidatorSet: validatorSetToPB(h.NextValidatorSet, reg),
+
+		DataId:           h.DataID,
+		PrevAppStateHash: h.PrevAppStateHash,
+
+		UserAnnotation:   h.Annotations.User,
+		DriverAnnotation: h.Annotations.Driver,
+	}
+}
+
+func headerFromPB(pb *gp2papipb.Header, reg *gcrypto.Registry) (tmconsensus.Header, error) {
+	vs, err := validatorSetFromPB(pb.ValidatorSet, reg)
+	if err != nil {
+		return tmconsensus.Header{}, fmt.Errorf("failed to unmarshal validator set: %w", err)
+	}
+	nvs, err := validatorSetFromPB(pb.NextValidatorSet, reg)
+	if err != nil {
+		return tmconsensus.Header{}, fmt.Errorf("failed to unmarshal next validator set: %w", err)
+	}
+
+	var proof tmconsensus.CommitProof
+	if pb.PrevCommitProof != nil {
+		proof = commitProofFromPB(pb.PrevCommitProof)
+	}
+
+	return tmconsensus.Header{
+		Hash:          pb.Hash,
+		PrevBlockHash: pb.PrevBlockHash,
+
+		Height: pb.Height,
+
+		PrevCommitProof: proof,
+
+		ValidatorSet:     vs,
+		NextValidatorSet: nvs,
+
+		DataID:           pb.DataId,
+		PrevAppStateHash: pb.PrevAppStateHash,
+
+		Annotations: tmconsensus.Annotations{
+			User:   pb.UserAnnotation,
+			Driver: pb.DriverAnnotation,
+		},
+	}, nil
+}
+
+func validatorSetToPB(vs tmconsensus.ValidatorSet, reg *gcrypto.Registry) *gp2papipb.ValidatorSet {
+	vals := make([]*gp2papipb.Validator, len(vs.Validators))
+	for i, v := range vs.Validators {
+		vals[i] = &gp2papipb.Validator{
+			EncodedPubKey: reg.Marshal(v.PubKey),
+			Power:         v.Power,
+		}
+	}
+
+	return &gp2papipb.ValidatorSet{
+		Validators:    vals,
+		PubKeyHash:    vs.PubKeyHash,
+		VotePowerHash: vs.VotePowerHash,
+	}
+}
+
+func validatorSetFromPB(
+	pb *gp2papipb.ValidatorSet, reg *gcrypto.Registry,
+) (tmconsensus.ValidatorSet, error) {
+	vals := make([]tmconsensus.Validator, len(pb.GetValidators()))
+	for i, v := range pb.GetValidators() {
+		pubKey, err := reg.Unmarshal(v.EncodedPubKey)
+		if err != nil {
+			return tmconsensus.ValidatorSet{}, fmt.Errorf(
+				"failed to unmarshal public key at index %d: %w", i, err,
+			)
+		}
+
+		vals[i] = tmconsensus.Validator{
+			PubKey: pubKey,
+			Power:  v.Power,
+		}
+	}
+
+	return tmconsensus.ValidatorSet{
+		Validators:    vals,
+		PubKeyHash:    pb.GetPubKeyHash(),
+		VotePowerHash: pb.GetVotePowerHash(),
+	}, nil
+}
+
+func commitProofToPB(p tmconsensus.CommitProof) *gp2papipb.CommitProof {
+	// Encode the entries in block hash order,
+	// so that the same proof always encodes to the same bytes.
+	entries := make([]*gp2papipb.CommitProofEntry, 0, len(p.Proofs))
+	for _, hash := range slices.Sorted(maps.Keys(p.Proofs)) {
+		sigs := p.Proofs[hash]
+		pbSigs := make([]*gp2papipb.SparseSignature, len(sigs))
+		for i, sig := range sigs {
+			pbSigs[i] = &gp2papipb.SparseSignature{
+				KeyId: sig.KeyID,
+				Sig:   sig.Sig,
+			}
+		}
+
+		entries = append(entries, &gp2papipb.CommitProofEntry{
+			BlockHash:  []byte(hash),
+			Signatures: pbSigs,
+		})
+	}
+
+	return &gp2papipb.CommitProof{
+		Round:      p.Round,
+		PubKeyHash: []byte(p.PubKeyHash),
+		Proofs:     entries,
+	}
+}
+
+func commitProofFromPB(pb *gp2papipb.CommitProof) tmconsensus.CommitProof {
+	p := tmconsensus.CommitProof{
+		Round:      pb.GetRound(),
+		PubKeyHash: string(pb.GetPubKeyHash()),
+
+		Proofs: make(map[string][]gcrypto.SparseSignature, len(pb.GetProofs())),
+	}
+
+	for _, e := range pb.GetProofs() {
+		sigs := make([]gcrypto.SparseSignature, len(e.Signatures))
+		for i, sig := range e.Signatures {
+			sigs[i] = gcrypto.SparseSignature{
+				KeyID: sig.KeyId,
+				Sig:   sig.Sig,
+			}
+		}
+		p.Proofs[string(e.BlockHash)] = sigs
+	}
+
+	return p
+}
@@ -0,0 +1,169 @@
+package gp2papi
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The v2 full block protocol serves a contiguous range of heights on a single stream,
+// so that a client catching up does not need to open one stream per height.
+//
+// The client opens a stream with the fullBlockV2RangeProtocol ID,
+// writes the first and last requested heights (inclusive) as uvarints,
+// and then closes its write side of the stream.
+//
+// The host responds with a sequence of records in increasing height order,
+// starting at the requested first height.
+// Each record begins with a one-byte kind:
+//
+//   - rangeRecordFullBlock is followed by the uvarint-prefixed committed header
+//     and then the uvarint-prefixed block data (which may have zero length).
+//   - rangeRecordError is followed by a uvarint-prefixed error message.
+//     An error record is always the final record on the stream.
+//
+// The host closes the stream after writing the last record.
+// The host may serve fewer heights than requested,
+// never more than [MaxFullBlockRangeSize];
+// the client is expected to request any remainder on a new stream.
+//
+// Flow control is provided by the underlying libp2p stream:
+// the host blocks on writing a record until the client has read enough of the previous ones,
+// and the host gives up if a single record cannot be written within a short deadline.
+
+// MaxFullBlockRangeSize is the maximum number of heights
+// that a [DataHost] will serve on a single v2 range stream.
+const MaxFullBlockRangeSize = 64
+
+const (
+	rangeRecordFullBlock byte = 1
+	rangeRecordError     byte = 2
+)
+
+// maxErrorMessageSize is the limit on the size of an error message in a range record.
+// Header and block data limits are configured on the [CatchupClient].
+const maxErrorMessageSize = 1024
+
+// rangeRecord is the decoded form of a single record in a range response.
+// Exactly one of Err or Header is set.
+type rangeRecord struct {
+	Header    []byte
+	BlockData []byte
+
+	Err string
+}
+
+// writeRangeRequest writes the start and end heights to w.
+func writeRangeRequest(w io.Writer, start, end uint64) error {
+	buf := make([]byte, 0, 2*binary.MaxVarintLen64)
+	buf = binary.AppendUvarint(buf, start)
+	buf = binary.AppendUvarint(buf, end)
+	_, err := w.Write(buf)
+	return err
+}
+
+// readRangeRequest parses the start and end heights from r.
+func readRangeRequest(r io.ByteReader) (start, end uint64, err error) {
+	start, err = binary.ReadUvarint(r)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read start height: %w", err)
+	}
+	end, err = binary.ReadUvarint(r)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read end height: %w", err)
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("invalid range: end height %d before start height %d", end, start)
+	}
+	return start, end, nil
+}
+
+// writeRangeFullBlock writes a single full block record to w.
+func writeRangeFullBlock(w io.Writer, header, blockData []byte) error {
+	buf := make([]byte, 0, 1+2*binary.MaxVarintLen64+len(header)+len(blockData))
+	buf = append(buf, rangeRecordFullBlock)
+	buf = binary.AppendUvarint(buf, uint64(len(header)))
+	buf = append(buf, header...)
+	buf = binary.AppendUvarint(buf, uint64(len(blockData)))
+	buf = append(buf, blockData...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// writeRangeError writes an error record to w.
+// No other records may be written after an error record.
+func writeRangeError(w io.Writer, msg string) error {
+	buf := make([]byte, 0, 1+binary.MaxVarintLen64+len(msg))
+	buf = append(buf, rangeRecordError)
+	buf = binary.AppendUvarint(buf, uint64(len(msg)))
+	buf = append(buf, msg...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// readRangeRecord reads the next record from r,
+// rejecting any header or block data exceeding the given sizes.
+// At the end of the stream, it returns [io.EOF].
+func readRangeRecord(r *bufio.Reader, maxHeaderSize, maxBlockDataSize int) (rangeRecord, error) {
+	kind, err := r.ReadByte()
+	if err != nil {
+		// Return io.EOF unwrapped, so the caller can detect the clean end of the stream.
+		return rangeRecord{}, err
+	}
+
+	switch kind {
+	case rangeRecordFullBlock:
+		header, err := readRangeValue(r, maxHeaderSize)
+		if err != nil {
+			return rangeRecord{}, fmt.Errorf("failed to read header: %w", err)
+		}
+		if len(header) == 0 {
+			return rangeRecord{}, errors.New("got empty header")
+		}
+
+		blockData, err := readRangeValue(r, maxBlockDataSize)
+		if err != nil {
+			return rangeRecord{}, fmt.Errorf("failed to read block data: %w", err)
+		}
+
+		return rangeRecord{Header: header, BlockData: blockData}, nil
+
+	case rangeRecordError:
+		msg, err := readRangeValue(r, maxErrorMessageSize)
+		if err != nil {
+			return rangeRecord{}, fmt.Errorf("failed to read error message: %w", err)
+		}
+		if len(msg) == 0 {
+			return rangeRecord{}, errors.New("got empty error message")
+		}
+
+		return rangeRecord{Err: string(msg)}, nil
+
+	default:
+		return rangeRecord{}, fmt.Errorf("unknown range record kind %d", kind)
+	}
+}
+
+// readRangeValue reads a uvarint-prefixed value from r,
+// failing if the declared size exceeds maxSize.
+// A zero-length value is returned as nil.
+func readRangeValue(r *bufio.Reader, maxSize int) ([]byte, error) {
+	sz, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read size: %w", err)
+	}
+	if sz > uint64(maxSize) {
+		return nil, fmt.Errorf("size %d exceeds maximum %d", sz, maxSize)
+	}
+	if sz == 0 {
+		return nil, nil
+	}
+
+	b := make([]byte, sz)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, fmt.Errorf("failed to read value: %w", err)
+	}
+	return b, nil
+}
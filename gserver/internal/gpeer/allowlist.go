@@ -0,0 +1,82 @@
+package gpeer
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Allowlist is the set of peers permitted to connect on a private network.
+//
+// The set is the union of a fixed list of peer IDs
+// and, optionally, the peers bound to the validators of the most recent validator sets
+// as reported through [*Allowlist.SetValidators].
+// A validator's peer is only known once its [ValidatorBinding]
+// has been added to the [ValidatorBindings] given to [NewAllowlist].
+//
+// Like [Reputation], Allowlist is guarded by a mutex
+// because the [ConnectionGater] queries it synchronously.
+type Allowlist struct {
+	mu sync.RWMutex
+
+	static map[libp2ppeer.ID]struct{}
+
+	bindings *ValidatorBindings
+
+	// Binding keys of the two most recently set validator sets.
+	// Both sets are allowed so that validators leaving the set
+	// remain reachable while the rest of the network catches up,
+	// and so that validators joining the set are allowed
+	// as soon as the first finalization including them is handled.
+	curVals, prevVals map[string]struct{}
+}
+
+// NewAllowlist returns a new Allowlist permitting the given peers,
+// and the peers bound to validators in bindings once validators are set.
+func NewAllowlist(ids []libp2ppeer.ID, bindings *ValidatorBindings) *Allowlist {
+	a := &Allowlist{
+		static: make(map[libp2ppeer.ID]struct{}, len(ids)),
+
+		bindings: bindings,
+	}
+	for _, id := range ids {
+		a.static[id] = struct{}{}
+	}
+	return a
+}
+
+// Allows reports whether p is in the allowlist.
+func (a *Allowlist) Allows(p libp2ppeer.ID) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if _, ok := a.static[p]; ok {
+		return true
+	}
+	if a.bindings == nil {
+		return false
+	}
+	return a.bindings.boundToAny(p, a.curVals, a.prevVals)
+}
+
+// SetValidators adds the peers bound to vals to the allowlist,
+// replacing the validators from the second most recent call.
+// Bindings added after the call take effect immediately.
+func (a *Allowlist) SetValidators(vals []tmconsensus.Validator) {
+	keys := make(map[string]struct{}, len(vals))
+	for _, v := range vals {
+		keys[bindingKey(v.PubKey)] = struct{}{}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if maps.Equal(keys, a.curVals) {
+		// Nothing changed, so don't evict the previous set.
+		return
+	}
+	a.prevVals = a.curVals
+	a.curVals = keys
+}
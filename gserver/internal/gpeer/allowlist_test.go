@@ -0,0 +1,197 @@
+package gpeer_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gcrypto/gblsminsig"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/libp2p/go-libp2p"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowlist_validators(t *testing.T) {
+	t.Parallel()
+
+	vb := gpeer.NewValidatorBindings(testChainID)
+
+	static := newPeerID(t)
+	a := gpeer.NewAllowlist([]libp2ppeer.ID{static}, vb)
+
+	val1, id1 := newBoundValidator(t, vb)
+	val2, id2 := newBoundValidator(t, vb)
+	val3, id3 := newBoundValidator(t, vb)
+
+	require.True(t, a.Allows(static))
+	require.False(t, a.Allows(id1))
+
+	a.SetValidators([]tmconsensus.Validator{val1, val2})
+	require.True(t, a.Allows(id1))
+	require.True(t, a.Allows(id2))
+	require.False(t, a.Allows(id3))
+
+	// The previous set is still allowed after one update.
+	a.SetValidators([]tmconsensus.Validator{val2, val3})
+	require.True(t, a.Allows(id1))
+	require.True(t, a.Allows(id3))
+
+	// Setting the same validators again does not evict the previous set.
+	a.SetValidators([]tmconsensus.Validator{val2, val3})
+	require.True(t, a.Allows(id1))
+
+	// But a second distinct update does.
+	a.SetValidators([]tmconsensus.Validator{val3})
+	require.False(t, a.Allows(id1))
+	require.True(t, a.Allows(id2))
+	require.True(t, a.Allows(id3))
+
+	// Static peers are never evicted.
+	require.True(t, a.Allows(static))
+}
+
+func TestAllowlist_blsValidators(t *testing.T) {
+	t.Parallel()
+
+	vb := gpeer.NewValidatorBindings(testChainID)
+	a := gpeer.NewAllowlist(nil, vb)
+
+	blsSigner := newBLSSigner(t)
+	blsID := newPeerID(t)
+	addBinding(t, vb, blsSigner, blsID)
+
+	edVal, edID := newBoundValidator(t, vb)
+
+	// BLS and ed25519 validators may share a set.
+	a.SetValidators([]tmconsensus.Validator{
+		{PubKey: blsSigner.PubKey(), Power: 1},
+		edVal,
+	})
+	require.True(t, a.Allows(blsID))
+	require.True(t, a.Allows(edID))
+
+	// A bound BLS key outside the validator set is not allowed.
+	otherSigner := newBLSSigner(t)
+	otherID := newPeerID(t)
+	addBinding(t, vb, otherSigner, otherID)
+	require.False(t, a.Allows(otherID))
+}
+
+func TestAllowlist_lateBinding(t *testing.T) {
+	t.Parallel()
+
+	vb := gpeer.NewValidatorBindings(testChainID)
+	a := gpeer.NewAllowlist(nil, vb)
+
+	// A validator in the set whose binding is not yet known is not allowed.
+	signer := newEd25519Signer(t)
+	id := newPeerID(t)
+	a.SetValidators([]tmconsensus.Validator{{PubKey: signer.PubKey(), Power: 1}})
+	require.False(t, a.Allows(id))
+
+	// Learning its binding allows it without another SetValidators call.
+	addBinding(t, vb, signer, id)
+	require.True(t, a.Allows(id))
+
+	// The validator moves to a new node key.
+	newID := newPeerID(t)
+	addBinding(t, vb, signer, newID)
+	require.True(t, a.Allows(newID))
+	require.False(t, a.Allows(id))
+
+	// A validator's consensus key never grants its own derived peer ID.
+	edPub := signer.PubKey().PubKeyBytes()
+	lpk, err := libp2pcrypto.UnmarshalEd25519PublicKey(edPub)
+	require.NoError(t, err)
+	derived, err := libp2ppeer.IDFromPublicKey(lpk)
+	require.NoError(t, err)
+	require.False(t, a.Allows(derived))
+}
+
+func TestConnectionGater_allowlist(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	allowed := newHost(t)
+	refused := newHost(t)
+
+	gated := newHost(t, libp2p.ConnectionGater(gpeer.ConnectionGater{
+		Allowlist: gpeer.NewAllowlist([]libp2ppeer.ID{allowed.ID()}, nil),
+	}))
+
+	require.NoError(t, allowed.Connect(ctx, *libp2phost.InfoFromHost(gated)))
+	require.NotEmpty(t, gated.Network().ConnsToPeer(allowed.ID()))
+
+	// Depending on the transport, the dialer may consider the connection established
+	// before the gated host closes it, so ignore the error here;
+	// the gated host never accepts the connection either way.
+	_ = refused.Connect(ctx, *libp2phost.InfoFromHost(gated))
+	require.Empty(t, gated.Network().ConnsToPeer(refused.ID()))
+
+	// And the gated host refuses to dial out, too.
+	require.Error(t, gated.Connect(ctx, *libp2phost.InfoFromHost(refused)))
+}
+
+const testChainID = "gpeer-test"
+
+// newBoundValidator returns a new validator bound to a new peer ID in vb.
+func newBoundValidator(t *testing.T, vb *gpeer.ValidatorBindings) (tmconsensus.Validator, libp2ppeer.ID) {
+	t.Helper()
+
+	signer := newEd25519Signer(t)
+	id := newPeerID(t)
+	addBinding(t, vb, signer, id)
+
+	return tmconsensus.Validator{PubKey: signer.PubKey(), Power: 1}, id
+}
+
+func newEd25519Signer(t *testing.T) gcrypto.Ed25519Signer {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	return gcrypto.NewEd25519Signer(priv)
+}
+
+func newBLSSigner(t *testing.T) gblsminsig.Signer {
+	t.Helper()
+
+	ikm := make([]byte, 32)
+	_, err := rand.Read(ikm)
+	require.NoError(t, err)
+	s, err := gblsminsig.NewSigner(ikm)
+	require.NoError(t, err)
+	return s
+}
+
+// addBinding signs a binding of id to signer's key, and adds it to vb.
+func addBinding(t *testing.T, vb *gpeer.ValidatorBindings, signer gcrypto.Signer, id libp2ppeer.ID) {
+	t.Helper()
+
+	b, err := gpeer.SignValidatorBinding(context.Background(), signer, testChainID, id, time.Now())
+	require.NoError(t, err)
+
+	added, err := vb.Add(b)
+	require.NoError(t, err)
+	require.True(t, added)
+}
+
+func newHost(t *testing.T, opts ...libp2p.Option) libp2phost.Host {
+	t.Helper()
+
+	opts = append(opts, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	h, err := libp2p.New(opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = h.Close() })
+
+	return h
+}
@@ -0,0 +1,290 @@
+package gpeer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gordian-engine/gordian/gcrypto"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ValidatorBinding is a validator's statement, signed with its consensus key,
+// that it operates the libp2p node with the given peer ID.
+//
+// A node's libp2p identity is a separate key from the validator's consensus key,
+// so that the consensus key can stay in a remote signer
+// and so that any consensus key type, including BLS, can be bound to a node.
+// Nodes share their bindings through a [BindingExchange],
+// and they resolve validators to peers through [ValidatorBindings].
+type ValidatorBinding struct {
+	ChainID string
+	PeerID  libp2ppeer.ID
+	PubKey  gcrypto.PubKey
+
+	// When the binding was signed.
+	// A newer binding for the same validator replaces an older one,
+	// so that a validator can move to a new node key.
+	Time time.Time
+
+	Signature []byte
+}
+
+// validatorBindingSignPrefix separates binding signatures
+// from any other message signed with a consensus key.
+const validatorBindingSignPrefix = "gcosmos/validator-binding/v1\x00"
+
+// ValidatorBindingSignBytes returns the bytes a validator signs
+// to bind its consensus key to the peer ID id on the given chain.
+func ValidatorBindingSignBytes(chainID string, id libp2ppeer.ID, t time.Time) []byte {
+	b := make(
+		[]byte, 0,
+		len(validatorBindingSignPrefix)+2*binary.MaxVarintLen64+len(chainID)+len(id)+8,
+	)
+	b = append(b, validatorBindingSignPrefix...)
+	b = binary.AppendUvarint(b, uint64(len(chainID)))
+	b = append(b, chainID...)
+	b = binary.AppendUvarint(b, uint64(len(id)))
+	b = append(b, id...)
+	b = binary.BigEndian.AppendUint64(b, uint64(t.UnixNano()))
+	return b
+}
+
+// SignValidatorBinding returns a binding of the peer ID id
+// to the public key of s, signed by s.
+func SignValidatorBinding(
+	ctx context.Context, s gcrypto.Signer, chainID string, id libp2ppeer.ID, t time.Time,
+) (ValidatorBinding, error) {
+	// Round trip through the signed representation,
+	// so that the binding verifies after encoding and decoding.
+	t = time.Unix(0, t.UnixNano()).UTC()
+
+	sig, err := s.Sign(ctx, ValidatorBindingSignBytes(chainID, id, t))
+	if err != nil {
+		return ValidatorBinding{}, fmt.Errorf("failed to sign validator binding: %w", err)
+	}
+
+	return ValidatorBinding{
+		ChainID:   chainID,
+		PeerID:    id,
+		PubKey:    s.PubKey(),
+		Time:      t,
+		Signature: sig,
+	}, nil
+}
+
+// Verify returns an error if b's signature is not authentic.
+func (b ValidatorBinding) Verify() error {
+	if b.PubKey == nil {
+		return errors.New("validator binding has no public key")
+	}
+	if err := b.PeerID.Validate(); err != nil {
+		return fmt.Errorf("validator binding has invalid peer ID: %w", err)
+	}
+	if !b.PubKey.Verify(ValidatorBindingSignBytes(b.ChainID, b.PeerID, b.Time), b.Signature) {
+		return errors.New("validator binding signature is not authentic")
+	}
+	return nil
+}
+
+// validatorBindingJSON is the JSON representation of a ValidatorBinding.
+type validatorBindingJSON struct {
+	ChainID string `json:"chain_id"`
+	PeerID  string `json:"peer_id"`
+
+	// The public key's type name and bytes, as decoded by a [gcrypto.Registry].
+	PubKeyType string `json:"pub_key_type"`
+	PubKey     []byte `json:"pub_key"`
+
+	Time      time.Time `json:"time"`
+	Signature []byte    `json:"signature"`
+}
+
+// MarshalJSON implements [json.Marshaler].
+// Use [UnmarshalValidatorBinding] to decode the result.
+func (b ValidatorBinding) MarshalJSON() ([]byte, error) {
+	if b.PubKey == nil {
+		return nil, errors.New("cannot marshal validator binding without public key")
+	}
+	return json.Marshal(validatorBindingJSON{
+		ChainID: b.ChainID,
+		PeerID:  b.PeerID.String(),
+
+		PubKeyType: b.PubKey.TypeName(),
+		PubKey:     b.PubKey.PubKeyBytes(),
+
+		Time:      b.Time,
+		Signature: b.Signature,
+	})
+}
+
+// UnmarshalValidatorBinding decodes the JSON encoding of a binding,
+// with the public key types registered in reg.
+// It does not verify the binding's signature.
+func UnmarshalValidatorBinding(reg *gcrypto.Registry, j []byte) (ValidatorBinding, error) {
+	var vbj validatorBindingJSON
+	if err := json.Unmarshal(j, &vbj); err != nil {
+		return ValidatorBinding{}, fmt.Errorf("failed to unmarshal validator binding: %w", err)
+	}
+
+	id, err := libp2ppeer.Decode(vbj.PeerID)
+	if err != nil {
+		return ValidatorBinding{}, fmt.Errorf("invalid peer ID in validator binding: %w", err)
+	}
+
+	pk, err := reg.Decode(vbj.PubKeyType, vbj.PubKey)
+	if err != nil {
+		return ValidatorBinding{}, fmt.Errorf("invalid public key in validator binding: %w", err)
+	}
+
+	return ValidatorBinding{
+		ChainID:   vbj.ChainID,
+		PeerID:    id,
+		PubKey:    pk,
+		Time:      vbj.Time,
+		Signature: vbj.Signature,
+	}, nil
+}
+
+// maxValidatorBindings bounds the number of validators
+// whose bindings a [ValidatorBindings] holds,
+// so that peers cannot exhaust our memory with bindings for arbitrary keys.
+const maxValidatorBindings = 4096
+
+// ErrTooManyBindings is returned from [*ValidatorBindings.Add]
+// when the set is full and the binding is for a validator not yet in the set.
+var ErrTooManyBindings = errors.New("too many validator bindings")
+
+// ValidatorBindings is the set of verified validator bindings known to a node,
+// holding the most recent binding for each validator public key.
+//
+// ValidatorBindings is safe for concurrent use.
+type ValidatorBindings struct {
+	// If empty, bindings for any chain are accepted,
+	// as a seed node is not associated with a chain.
+	chainID string
+
+	mu sync.RWMutex
+
+	// Keyed by bindingKey of the validator public key.
+	byKey map[string]ValidatorBinding
+
+	// The keys bound to each peer.
+	// Any validator may sign a binding to any peer ID,
+	// so more than one key may be bound to the same peer.
+	byPeer map[libp2ppeer.ID]map[string]struct{}
+}
+
+// NewValidatorBindings returns an empty set of bindings for the given chain.
+// A blank chainID accepts bindings for any chain.
+func NewValidatorBindings(chainID string) *ValidatorBindings {
+	return &ValidatorBindings{
+		chainID: chainID,
+
+		byKey:  make(map[string]ValidatorBinding),
+		byPeer: make(map[libp2ppeer.ID]map[string]struct{}),
+	}
+}
+
+// Add verifies b and adds it to the set,
+// replacing any older binding for the same validator.
+// It reports whether the set changed;
+// a binding that is not newer than the one already held is ignored.
+func (vb *ValidatorBindings) Add(b ValidatorBinding) (added bool, err error) {
+	if vb.chainID != "" && b.ChainID != vb.chainID {
+		return false, fmt.Errorf(
+			"validator binding is for chain %q, not %q", b.ChainID, vb.chainID,
+		)
+	}
+	if err := b.Verify(); err != nil {
+		return false, err
+	}
+
+	k := bindingKey(b.PubKey)
+
+	vb.mu.Lock()
+	defer vb.mu.Unlock()
+
+	old, ok := vb.byKey[k]
+	if ok {
+		if !b.Time.After(old.Time) {
+			return false, nil
+		}
+
+		delete(vb.byPeer[old.PeerID], k)
+		if len(vb.byPeer[old.PeerID]) == 0 {
+			delete(vb.byPeer, old.PeerID)
+		}
+	} else if len(vb.byKey) >= maxValidatorBindings {
+		return false, ErrTooManyBindings
+	}
+
+	vb.byKey[k] = b
+	keys := vb.byPeer[b.PeerID]
+	if keys == nil {
+		keys = make(map[string]struct{}, 1)
+		vb.byPeer[b.PeerID] = keys
+	}
+	keys[k] = struct{}{}
+
+	return true, nil
+}
+
+// PeerID returns the peer ID bound to the validator with the public key pk.
+func (vb *ValidatorBindings) PeerID(pk gcrypto.PubKey) (id libp2ppeer.ID, ok bool) {
+	vb.mu.RLock()
+	defer vb.mu.RUnlock()
+
+	b, ok := vb.byKey[bindingKey(pk)]
+	return b.PeerID, ok
+}
+
+// All returns every binding in the set, ordered by public key.
+func (vb *ValidatorBindings) All() []ValidatorBinding {
+	vb.mu.RLock()
+	defer vb.mu.RUnlock()
+
+	keys := make([]string, 0, len(vb.byKey))
+	for k := range vb.byKey {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	out := make([]ValidatorBinding, len(keys))
+	for i, k := range keys {
+		out[i] = vb.byKey[k]
+	}
+	return out
+}
+
+// boundToAny reports whether any of the keys in the given sets
+// is bound to the peer p.
+func (vb *ValidatorBindings) boundToAny(p libp2ppeer.ID, sets ...map[string]struct{}) bool {
+	vb.mu.RLock()
+	defer vb.mu.RUnlock()
+
+	for k := range vb.byPeer[p] {
+		for _, s := range sets {
+			if _, ok := s[k]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bindingKey returns the map key identifying the public key pk,
+// distinguishing keys of different types with the same bytes.
+func bindingKey(pk gcrypto.PubKey) string {
+	var buf bytes.Buffer
+	buf.WriteString(pk.TypeName())
+	buf.WriteByte(0)
+	buf.Write(pk.PubKeyBytes())
+	return buf.String()
+}
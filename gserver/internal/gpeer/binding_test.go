@@ -0,0 +1,176 @@
+package gpeer_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gcrypto/gblsminsig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorBinding_roundTrip(t *testing.T) {
+	t.Parallel()
+
+	signer := newEd25519Signer(t)
+	id := newPeerID(t)
+
+	b, err := gpeer.SignValidatorBinding(context.Background(), signer, testChainID, id, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, b.Verify())
+
+	j, err := json.Marshal(b)
+	require.NoError(t, err)
+
+	var reg gcrypto.Registry
+	gcrypto.RegisterEd25519(&reg)
+	got, err := gpeer.UnmarshalValidatorBinding(&reg, j)
+	require.NoError(t, err)
+
+	require.NoError(t, got.Verify())
+	require.Equal(t, id, got.PeerID)
+	require.True(t, signer.PubKey().Equal(got.PubKey))
+	require.True(t, b.Time.Equal(got.Time))
+}
+
+func TestValidatorBinding_bls(t *testing.T) {
+	t.Parallel()
+
+	signer := newBLSSigner(t)
+	id := newPeerID(t)
+
+	b, err := gpeer.SignValidatorBinding(context.Background(), signer, testChainID, id, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, b.Verify())
+
+	j, err := json.Marshal(b)
+	require.NoError(t, err)
+
+	// Decoding requires the BLS key type to be registered.
+	var edReg gcrypto.Registry
+	gcrypto.RegisterEd25519(&edReg)
+	_, err = gpeer.UnmarshalValidatorBinding(&edReg, j)
+	require.Error(t, err)
+
+	var reg gcrypto.Registry
+	gcrypto.RegisterEd25519(&reg)
+	gblsminsig.Register(&reg)
+	got, err := gpeer.UnmarshalValidatorBinding(&reg, j)
+	require.NoError(t, err)
+
+	require.NoError(t, got.Verify())
+	require.Equal(t, id, got.PeerID)
+	require.True(t, signer.PubKey().Equal(got.PubKey))
+
+	// The bindings resolve the BLS key to its peer.
+	vb := gpeer.NewValidatorBindings(testChainID)
+	added, err := vb.Add(got)
+	require.NoError(t, err)
+	require.True(t, added)
+	gotID, ok := vb.PeerID(signer.PubKey())
+	require.True(t, ok)
+	require.Equal(t, id, gotID)
+}
+
+func TestValidatorBinding_tampered(t *testing.T) {
+	t.Parallel()
+
+	signer := newEd25519Signer(t)
+
+	b, err := gpeer.SignValidatorBinding(context.Background(), signer, testChainID, newPeerID(t), time.Now())
+	require.NoError(t, err)
+
+	t.Run("peer ID", func(t *testing.T) {
+		bad := b
+		bad.PeerID = newPeerID(t)
+		require.Error(t, bad.Verify())
+	})
+
+	t.Run("chain ID", func(t *testing.T) {
+		bad := b
+		bad.ChainID = "other-chain"
+		require.Error(t, bad.Verify())
+	})
+
+	t.Run("time", func(t *testing.T) {
+		bad := b
+		bad.Time = b.Time.Add(time.Second)
+		require.Error(t, bad.Verify())
+	})
+
+	t.Run("public key", func(t *testing.T) {
+		bad := b
+		bad.PubKey = newEd25519Signer(t).PubKey()
+		require.Error(t, bad.Verify())
+	})
+}
+
+func TestValidatorBindings_Add(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	vb := gpeer.NewValidatorBindings(testChainID)
+	signer := newEd25519Signer(t)
+
+	now := time.Now()
+	id1 := newPeerID(t)
+	b1, err := gpeer.SignValidatorBinding(ctx, signer, testChainID, id1, now)
+	require.NoError(t, err)
+
+	added, err := vb.Add(b1)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// Adding the same binding again is not a change.
+	added, err = vb.Add(b1)
+	require.NoError(t, err)
+	require.False(t, added)
+
+	// An older binding does not replace a newer one.
+	old, err := gpeer.SignValidatorBinding(ctx, signer, testChainID, newPeerID(t), now.Add(-time.Minute))
+	require.NoError(t, err)
+	added, err = vb.Add(old)
+	require.NoError(t, err)
+	require.False(t, added)
+
+	id, ok := vb.PeerID(signer.PubKey())
+	require.True(t, ok)
+	require.Equal(t, id1, id)
+
+	// A newer binding does.
+	id2 := newPeerID(t)
+	b2, err := gpeer.SignValidatorBinding(ctx, signer, testChainID, id2, now.Add(time.Minute))
+	require.NoError(t, err)
+	added, err = vb.Add(b2)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	id, ok = vb.PeerID(signer.PubKey())
+	require.True(t, ok)
+	require.Equal(t, id2, id)
+	require.Len(t, vb.All(), 1)
+
+	// Bindings for other chains are rejected.
+	other, err := gpeer.SignValidatorBinding(ctx, newEd25519Signer(t), "other-chain", newPeerID(t), now)
+	require.NoError(t, err)
+	_, err = vb.Add(other)
+	require.Error(t, err)
+
+	// Unless the set accepts any chain, as on a seed node.
+	added, err = gpeer.NewValidatorBindings("").Add(other)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// Bindings with bad signatures are rejected.
+	bad := b2
+	bad.Time = now.Add(time.Hour)
+	_, err = vb.Add(bad)
+	require.Error(t, err)
+
+	_, ok = vb.PeerID(newEd25519Signer(t).PubKey())
+	require.False(t, ok)
+}
@@ -0,0 +1,267 @@
+package gpeer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime/trace"
+	"sync"
+	"time"
+
+	"github.com/gordian-engine/gordian/gcrypto"
+	libp2pevent "github.com/libp2p/go-libp2p/core/event"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	libp2pprotocol "github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// BindingsProtocolID is the libp2p protocol on which peers exchange
+// the validator bindings they know.
+const BindingsProtocolID libp2pprotocol.ID = "/gcosmos/validator-bindings/1"
+
+const (
+	// maxBindingsMessageSize bounds how much we read from a peer.
+	// It comfortably fits maxValidatorBindings bindings.
+	maxBindingsMessageSize = 4 * 1024 * 1024
+
+	bindingsTimeout = 10 * time.Second
+
+	// bindingsRelayDelay batches newly learned bindings
+	// before relaying them to every connected peer.
+	bindingsRelayDelay = 250 * time.Millisecond
+)
+
+// BindingExchange shares validator bindings between peers,
+// so that every node learns which peer operates each validator.
+//
+// Upon every new connection, each side sends every binding it knows to the other.
+// Whenever a node learns a new binding, it relays its bindings to all of its peers,
+// so bindings spread through nodes that every peer may connect to, such as seed nodes,
+// before validators on a private network are allowed to connect to each other.
+// Peers that do not speak [BindingsProtocolID] are left alone.
+type BindingExchange struct {
+	log *slog.Logger
+
+	host libp2phost.Host
+	reg  *gcrypto.Registry
+
+	bindings *ValidatorBindings
+
+	// Signaled when bindings were added and should be relayed.
+	changed chan struct{}
+
+	wg sync.WaitGroup
+}
+
+// BindingExchangeConfig is the configuration for [NewBindingExchange].
+type BindingExchangeConfig struct {
+	// The host whose peers exchange bindings.
+	Host libp2phost.Host
+
+	// Decodes the public keys in bindings received from peers.
+	Registry *gcrypto.Registry
+
+	// The bindings to send to peers,
+	// and where the bindings received from peers are added.
+	Bindings *ValidatorBindings
+
+	// Our own binding, if we are a validator.
+	// It is added to Bindings before the exchange starts.
+	Own *ValidatorBinding
+}
+
+// NewBindingExchange starts exchanging bindings in the background.
+// Cancel ctx to stop; use [*BindingExchange.Wait] to block until the background work finishes.
+func NewBindingExchange(
+	ctx context.Context, log *slog.Logger, cfg BindingExchangeConfig,
+) (*BindingExchange, error) {
+	if cfg.Own != nil {
+		if cfg.Own.PeerID != cfg.Host.ID() {
+			return nil, fmt.Errorf(
+				"own validator binding is for peer %s, not host peer %s",
+				cfg.Own.PeerID, cfg.Host.ID(),
+			)
+		}
+		if _, err := cfg.Bindings.Add(*cfg.Own); err != nil {
+			return nil, fmt.Errorf("invalid own validator binding: %w", err)
+		}
+	}
+
+	sub, err := cfg.Host.EventBus().Subscribe(new(libp2pevent.EvtPeerConnectednessChanged))
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to peer connectedness events: %w", err)
+	}
+
+	x := &BindingExchange{
+		log: log,
+
+		host: cfg.Host,
+		reg:  cfg.Registry,
+
+		bindings: cfg.Bindings,
+
+		changed: make(chan struct{}, 1),
+	}
+
+	cfg.Host.SetStreamHandler(BindingsProtocolID, x.handleStream)
+
+	x.wg.Add(1)
+	go x.mainLoop(ctx, sub)
+
+	return x, nil
+}
+
+// Wait blocks until all of x's background work is completed.
+func (x *BindingExchange) Wait() {
+	x.wg.Wait()
+}
+
+func (x *BindingExchange) mainLoop(ctx context.Context, sub libp2pevent.Subscription) {
+	defer x.wg.Done()
+	defer sub.Close()
+	defer x.host.RemoveStreamHandler(BindingsProtocolID)
+
+	ctx, task := trace.NewTask(ctx, "gpeer.BindingExchange.mainLoop")
+	defer task.End()
+
+	// Only set while a relay is pending.
+	var relayTimer <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			x.log.Info("Stopping due to context cancellation", "cause", context.Cause(ctx))
+			return
+
+		case e := <-sub.Out():
+			evt := e.(libp2pevent.EvtPeerConnectednessChanged)
+			if evt.Connectedness != libp2pnetwork.Connected {
+				continue
+			}
+
+			x.wg.Add(1)
+			go x.exchange(ctx, evt.Peer)
+
+		case <-x.changed:
+			if relayTimer == nil {
+				relayTimer = time.After(bindingsRelayDelay)
+			}
+
+		case <-relayTimer:
+			relayTimer = nil
+			for _, p := range x.host.Network().Peers() {
+				x.wg.Add(1)
+				go x.exchange(ctx, p)
+			}
+		}
+	}
+}
+
+// exchange sends our bindings to p and adds the bindings p sends back.
+func (x *BindingExchange) exchange(ctx context.Context, p libp2ppeer.ID) {
+	defer x.wg.Done()
+
+	log := x.log.With("peer_id", p)
+
+	ctx, cancel := context.WithTimeout(ctx, bindingsTimeout)
+	defer cancel()
+
+	s, err := x.host.NewStream(ctx, p, BindingsProtocolID)
+	if err != nil {
+		// Most likely the peer does not speak the protocol.
+		log.Debug("Failed to open validator bindings stream; skipping", "err", err)
+		return
+	}
+	defer s.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.SetDeadline(deadline)
+	}
+
+	if err := x.writeBindings(s); err != nil {
+		log.Debug("Failed to send validator bindings", "err", err)
+		return
+	}
+	if err := s.CloseWrite(); err != nil {
+		log.Debug("Failed to close validator bindings stream for writing", "err", err)
+		return
+	}
+
+	x.readBindings(log, s)
+}
+
+// handleStream adds the bindings a peer sent,
+// and responds with our bindings.
+func (x *BindingExchange) handleStream(s libp2pnetwork.Stream) {
+	defer s.Close()
+
+	log := x.log.With("peer_id", s.Conn().RemotePeer())
+
+	_ = s.SetDeadline(time.Now().Add(bindingsTimeout))
+
+	if !x.readBindings(log, s) {
+		_ = s.Reset()
+		return
+	}
+
+	if err := x.writeBindings(s); err != nil {
+		log.Debug("Failed to send validator bindings", "err", err)
+	}
+}
+
+func (x *BindingExchange) writeBindings(w io.Writer) error {
+	return json.NewEncoder(w).Encode(x.bindings.All())
+}
+
+// readBindings adds the valid bindings read from r,
+// and reports whether r held a well-formed list of bindings.
+// Individual invalid bindings, such as bindings for another chain, are skipped.
+func (x *BindingExchange) readBindings(log *slog.Logger, r io.Reader) bool {
+	b, err := io.ReadAll(io.LimitReader(r, maxBindingsMessageSize+1))
+	if err != nil {
+		log.Debug("Failed to read validator bindings", "err", err)
+		return false
+	}
+	if len(b) > maxBindingsMessageSize {
+		log.Debug("Validator bindings exceed maximum size", "max_size", maxBindingsMessageSize)
+		return false
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		log.Debug("Failed to unmarshal validator bindings", "err", err)
+		return false
+	}
+
+	nAdded := 0
+	for _, j := range raw {
+		vb, err := UnmarshalValidatorBinding(x.reg, j)
+		if err != nil {
+			log.Debug("Skipping undecodable validator binding", "err", err)
+			continue
+		}
+		added, err := x.bindings.Add(vb)
+		if err != nil {
+			log.Debug("Skipping invalid validator binding", "bound_peer_id", vb.PeerID, "err", err)
+			continue
+		}
+		if added {
+			nAdded++
+		}
+	}
+
+	if nAdded > 0 {
+		log.Debug("Learned validator bindings", "n", nAdded)
+
+		// Non-blocking; a pending relay includes these bindings anyway.
+		select {
+		case x.changed <- struct{}{}:
+		default:
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,136 @@
+package gpeer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	"github.com/gordian-engine/gordian/gcrypto"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindingExchange_relayedThroughSeed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seed := newHost(t)
+	h1 := newHost(t)
+	h2 := newHost(t)
+
+	// The seed accepts bindings for any chain and has none of its own.
+	startBindingExchange(t, ctx, seed, gpeer.NewValidatorBindings(""), nil)
+
+	signer1 := newEd25519Signer(t)
+	vb1 := gpeer.NewValidatorBindings(testChainID)
+	startBindingExchange(t, ctx, h1, vb1, signBinding(t, signer1, h1))
+
+	signer2 := newEd25519Signer(t)
+	vb2 := gpeer.NewValidatorBindings(testChainID)
+	startBindingExchange(t, ctx, h2, vb2, signBinding(t, signer2, h2))
+
+	// The validators only ever connect to the seed.
+	require.NoError(t, h1.Connect(ctx, *libp2phost.InfoFromHost(seed)))
+	require.NoError(t, h2.Connect(ctx, *libp2phost.InfoFromHost(seed)))
+
+	requireBinding(t, vb1, signer2.PubKey(), h2)
+	requireBinding(t, vb2, signer1.PubKey(), h1)
+
+	require.Equal(t, libp2pnetwork.NotConnected, h1.Network().Connectedness(h2.ID()))
+}
+
+func TestBindingExchange_rejectsOtherChain(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h1 := newHost(t)
+	h2 := newHost(t)
+
+	vb1 := gpeer.NewValidatorBindings(testChainID)
+	startBindingExchange(t, ctx, h1, vb1, nil)
+
+	signer2 := newEd25519Signer(t)
+	b2, err := gpeer.SignValidatorBinding(ctx, signer2, "other-chain", h2.ID(), time.Now())
+	require.NoError(t, err)
+	startBindingExchange(t, ctx, h2, gpeer.NewValidatorBindings("other-chain"), &b2)
+
+	require.NoError(t, h1.Connect(ctx, *libp2phost.InfoFromHost(h2)))
+
+	// Give the exchange time to run.
+	gtest.Sleep(gtest.ScaleMs(200))
+	_, ok := vb1.PeerID(signer2.PubKey())
+	require.False(t, ok)
+}
+
+func TestNewBindingExchange_ownBindingForOtherPeer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := newHost(t)
+
+	b, err := gpeer.SignValidatorBinding(ctx, newEd25519Signer(t), testChainID, newPeerID(t), time.Now())
+	require.NoError(t, err)
+
+	var reg gcrypto.Registry
+	gcrypto.RegisterEd25519(&reg)
+	_, err = gpeer.NewBindingExchange(ctx, gtest.NewLogger(t), gpeer.BindingExchangeConfig{
+		Host:     h,
+		Registry: &reg,
+		Bindings: gpeer.NewValidatorBindings(testChainID),
+		Own:      &b,
+	})
+	require.Error(t, err)
+}
+
+// requireBinding waits for vb to bind pk to h's peer ID.
+func requireBinding(t *testing.T, vb *gpeer.ValidatorBindings, pk gcrypto.PubKey, h libp2phost.Host) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Duration(gtest.ScaleMs(2000)))
+	for time.Now().Before(deadline) {
+		if id, ok := vb.PeerID(pk); ok && id == h.ID() {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("validator binding to %s was not learned in time", h.ID())
+}
+
+func signBinding(t *testing.T, signer gcrypto.Signer, h libp2phost.Host) *gpeer.ValidatorBinding {
+	t.Helper()
+
+	b, err := gpeer.SignValidatorBinding(context.Background(), signer, testChainID, h.ID(), time.Now())
+	require.NoError(t, err)
+	return &b
+}
+
+func startBindingExchange(
+	t *testing.T,
+	ctx context.Context,
+	h libp2phost.Host,
+	vb *gpeer.ValidatorBindings,
+	own *gpeer.ValidatorBinding,
+) {
+	t.Helper()
+
+	var reg gcrypto.Registry
+	gcrypto.RegisterEd25519(&reg)
+
+	x, err := gpeer.NewBindingExchange(ctx, gtest.NewLogger(t), gpeer.BindingExchangeConfig{
+		Host:     h,
+		Registry: &reg,
+		Bindings: vb,
+		Own:      own,
+	})
+	require.NoError(t, err)
+	t.Cleanup(x.Wait)
+}
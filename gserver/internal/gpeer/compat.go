@@ -0,0 +1,211 @@
+package gpeer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime/trace"
+	"sync"
+	"time"
+
+	libp2pevent "github.com/libp2p/go-libp2p/core/event"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	libp2pprotocol "github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// CompatProtocolID is the libp2p protocol on which peers exchange
+// their chain parameter fingerprints.
+const CompatProtocolID libp2pprotocol.ID = "/gcosmos/compat/1"
+
+const (
+	// maxFingerprintSize bounds how much we read from a peer.
+	maxFingerprintSize = 4 * 1024
+
+	compatTimeout = 10 * time.Second
+)
+
+// CompatCheck disconnects from peers whose chain parameters differ from ours,
+// such as peers configured with a different signature scheme,
+// rather than leaving the mismatch to surface as a stream of invalid signatures.
+//
+// Upon every new connection, each side sends its fingerprint to the other,
+// and either side closes every connection to the peer if the fingerprints differ.
+//
+// Peers that do not speak [CompatProtocolID], or that fail to complete the exchange,
+// are left connected rather than disconnected:
+// seed nodes never run the check, and nodes from before the check existed cannot.
+// Tolerating them is safe because the check is only an early diagnosis;
+// every consensus message from any peer is still verified against our own schemes,
+// so an incompatible peer that stays connected can only send messages we reject.
+type CompatCheck struct {
+	log *slog.Logger
+
+	host libp2phost.Host
+
+	fingerprint string
+
+	wg sync.WaitGroup
+}
+
+// CompatCheckConfig is the configuration for [NewCompatCheck].
+type CompatCheckConfig struct {
+	// The host whose connections are checked.
+	Host libp2phost.Host
+
+	// An opaque description of the chain parameters that must match between peers.
+	Fingerprint string
+}
+
+// NewCompatCheck starts checking new connections in the background.
+// Cancel ctx to stop; use [*CompatCheck.Wait] to block until the background work finishes.
+func NewCompatCheck(ctx context.Context, log *slog.Logger, cfg CompatCheckConfig) (*CompatCheck, error) {
+	if len(cfg.Fingerprint) > maxFingerprintSize {
+		panic(fmt.Errorf(
+			"BUG: fingerprint size %d exceeds maximum %d", len(cfg.Fingerprint), maxFingerprintSize,
+		))
+	}
+
+	sub, err := cfg.Host.EventBus().Subscribe(new(libp2pevent.EvtPeerConnectednessChanged))
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to peer connectedness events: %w", err)
+	}
+
+	c := &CompatCheck{
+		log: log,
+
+		host: cfg.Host,
+
+		fingerprint: cfg.Fingerprint,
+	}
+
+	cfg.Host.SetStreamHandler(CompatProtocolID, c.handleStream)
+
+	c.wg.Add(1)
+	go c.mainLoop(ctx, sub)
+
+	return c, nil
+}
+
+// Wait blocks until all of c's background work is completed.
+func (c *CompatCheck) Wait() {
+	c.wg.Wait()
+}
+
+func (c *CompatCheck) mainLoop(ctx context.Context, sub libp2pevent.Subscription) {
+	defer c.wg.Done()
+	defer sub.Close()
+	defer c.host.RemoveStreamHandler(CompatProtocolID)
+
+	ctx, task := trace.NewTask(ctx, "gpeer.CompatCheck.mainLoop")
+	defer task.End()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info("Stopping due to context cancellation", "cause", context.Cause(ctx))
+			return
+
+		case e := <-sub.Out():
+			evt := e.(libp2pevent.EvtPeerConnectednessChanged)
+			if evt.Connectedness != libp2pnetwork.Connected {
+				continue
+			}
+
+			c.wg.Add(1)
+			go c.checkPeer(ctx, evt.Peer)
+		}
+	}
+}
+
+// checkPeer sends our fingerprint to p and compares the fingerprint p sends back.
+func (c *CompatCheck) checkPeer(ctx context.Context, p libp2ppeer.ID) {
+	defer c.wg.Done()
+
+	log := c.log.With("peer_id", p)
+
+	ctx, cancel := context.WithTimeout(ctx, compatTimeout)
+	defer cancel()
+
+	s, err := c.host.NewStream(ctx, p, CompatProtocolID)
+	if err != nil {
+		// Most likely the peer does not speak the protocol.
+		log.Debug("Failed to open compatibility check stream; skipping", "err", err)
+		return
+	}
+	defer s.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.SetDeadline(deadline)
+	}
+
+	if _, err := io.WriteString(s, c.fingerprint); err != nil {
+		log.Debug("Failed to send fingerprint", "err", err)
+		return
+	}
+	if err := s.CloseWrite(); err != nil {
+		log.Debug("Failed to close compatibility check stream for writing", "err", err)
+		return
+	}
+
+	theirs, err := readFingerprint(s)
+	if err != nil {
+		log.Debug("Failed to read fingerprint", "err", err)
+		return
+	}
+
+	c.compare(log, p, theirs)
+}
+
+// handleStream responds to a peer's check with our fingerprint,
+// and compares the fingerprint the peer sent.
+func (c *CompatCheck) handleStream(s libp2pnetwork.Stream) {
+	defer s.Close()
+
+	p := s.Conn().RemotePeer()
+	log := c.log.With("peer_id", p)
+
+	_ = s.SetDeadline(time.Now().Add(compatTimeout))
+
+	theirs, err := readFingerprint(s)
+	if err != nil {
+		log.Debug("Failed to read fingerprint", "err", err)
+		_ = s.Reset()
+		return
+	}
+
+	if _, err := io.WriteString(s, c.fingerprint); err != nil {
+		log.Debug("Failed to send fingerprint", "err", err)
+		return
+	}
+
+	c.compare(log, p, theirs)
+}
+
+func (c *CompatCheck) compare(log *slog.Logger, p libp2ppeer.ID, theirs string) {
+	if theirs == c.fingerprint {
+		return
+	}
+
+	log.Warn(
+		"Disconnecting from peer with incompatible chain parameters",
+		"ours", c.fingerprint,
+		"theirs", theirs,
+	)
+	if err := c.host.Network().ClosePeer(p); err != nil {
+		log.Debug("Failed to close connection to incompatible peer", "err", err)
+	}
+}
+
+func readFingerprint(r io.Reader) (string, error) {
+	b, err := io.ReadAll(io.LimitReader(r, maxFingerprintSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(b) > maxFingerprintSize {
+		return "", fmt.Errorf("fingerprint exceeds maximum size %d", maxFingerprintSize)
+	}
+	return string(b), nil
+}
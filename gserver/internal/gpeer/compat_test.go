@@ -0,0 +1,82 @@
+package gpeer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	libp2pevent "github.com/libp2p/go-libp2p/core/event"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompatCheck_match(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h1 := newHost(t)
+	h2 := newHost(t)
+
+	startCompatCheck(t, ctx, h1, "chain;v1")
+	startCompatCheck(t, ctx, h2, "chain;v1")
+
+	require.NoError(t, h1.Connect(ctx, *libp2phost.InfoFromHost(h2)))
+
+	// Give the check time to run, and confirm the peers stay connected.
+	gtest.Sleep(gtest.ScaleMs(200))
+	require.Equal(t, libp2pnetwork.Connected, h1.Network().Connectedness(h2.ID()))
+}
+
+func TestCompatCheck_mismatch(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h1 := newHost(t)
+	h2 := newHost(t)
+
+	sub, err := h1.EventBus().Subscribe(new(libp2pevent.EvtPeerConnectednessChanged))
+	require.NoError(t, err)
+	defer sub.Close()
+
+	startCompatCheck(t, ctx, h1, "chain;v1")
+	startCompatCheck(t, ctx, h2, "chain;v2")
+
+	require.NoError(t, h1.Connect(ctx, *libp2phost.InfoFromHost(h2)))
+
+	requireConnectedness(t, sub.Out(), h2.ID(), libp2pnetwork.Connected)
+	requireConnectedness(t, sub.Out(), h2.ID(), libp2pnetwork.NotConnected)
+}
+
+func TestCompatCheck_unsupportedPeer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h1 := newHost(t)
+	seed := newHost(t) // Does not run the check.
+
+	startCompatCheck(t, ctx, h1, "chain;v1")
+
+	require.NoError(t, h1.Connect(ctx, *libp2phost.InfoFromHost(seed)))
+
+	gtest.Sleep(gtest.ScaleMs(200))
+	require.Equal(t, libp2pnetwork.Connected, h1.Network().Connectedness(seed.ID()))
+}
+
+func startCompatCheck(t *testing.T, ctx context.Context, h libp2phost.Host, fingerprint string) {
+	t.Helper()
+
+	c, err := gpeer.NewCompatCheck(ctx, gtest.NewLogger(t), gpeer.CompatCheckConfig{
+		Host:        h,
+		Fingerprint: fingerprint,
+	})
+	require.NoError(t, err)
+	t.Cleanup(c.Wait)
+}
@@ -0,0 +1,15 @@
+// Package gpeer tracks the reputation of libp2p peers,
+// so that peers who repeatedly misbehave are refused connections.
+//
+// Subsystems that interact with peers report offenses to a [Reputation],
+// through the narrow [Reporter] interface.
+// A [ConnectionGater] consults the Reputation,
+// and on private networks an [Allowlist],
+// whenever the libp2p host dials or accepts a connection.
+//
+// A [CompatCheck] disconnects from peers configured with different chain parameters.
+//
+// Validators sign a [ValidatorBinding] to the peer ID of the node they operate,
+// and a [BindingExchange] spreads the bindings between peers,
+// so that the [Allowlist] and other subsystems can resolve validators to peers.
+package gpeer
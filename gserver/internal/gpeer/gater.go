@@ -0,0 +1,63 @@
+package gpeer
+
+import (
+	libp2pconnmgr "github.com/libp2p/go-libp2p/core/connmgr"
+	libp2pcontrol "github.com/libp2p/go-libp2p/core/control"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// ConnectionGater is a libp2p connection gater
+// that refuses connections to and from banned peers,
+// and, on private networks, to and from peers outside an allowlist.
+//
+// Pass it to the libp2p host with the libp2p.ConnectionGater option.
+// Connections to a peer that are already open when the peer is banned
+// are not affected by the gater;
+// use [*Reputation.SetBanHook] to close those connections.
+type ConnectionGater struct {
+	// If set, banned peers are refused.
+	Reputation *Reputation
+
+	// If set, only peers in the allowlist are accepted.
+	Allowlist *Allowlist
+}
+
+var _ libp2pconnmgr.ConnectionGater = ConnectionGater{}
+
+// InterceptPeerDial implements [libp2pconnmgr.ConnectionGater].
+func (g ConnectionGater) InterceptPeerDial(p libp2ppeer.ID) (allow bool) {
+	return g.allows(p)
+}
+
+// InterceptAddrDial implements [libp2pconnmgr.ConnectionGater].
+func (g ConnectionGater) InterceptAddrDial(p libp2ppeer.ID, _ multiaddr.Multiaddr) (allow bool) {
+	return g.allows(p)
+}
+
+// InterceptAccept implements [libp2pconnmgr.ConnectionGater].
+// The remote peer ID is not known yet,
+// so the check is deferred to InterceptSecured.
+func (g ConnectionGater) InterceptAccept(libp2pnetwork.ConnMultiaddrs) (allow bool) {
+	return true
+}
+
+// InterceptSecured implements [libp2pconnmgr.ConnectionGater].
+func (g ConnectionGater) InterceptSecured(
+	_ libp2pnetwork.Direction, p libp2ppeer.ID, _ libp2pnetwork.ConnMultiaddrs,
+) (allow bool) {
+	return g.allows(p)
+}
+
+// InterceptUpgraded implements [libp2pconnmgr.ConnectionGater].
+func (g ConnectionGater) InterceptUpgraded(libp2pnetwork.Conn) (allow bool, reason libp2pcontrol.DisconnectReason) {
+	return true, 0
+}
+
+func (g ConnectionGater) allows(p libp2ppeer.ID) bool {
+	if g.Allowlist != nil && !g.Allowlist.Allows(p) {
+		return false
+	}
+	return g.Reputation == nil || !g.Reputation.IsBanned(p)
+}
@@ -0,0 +1,206 @@
+package gpeer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/trace"
+	"sync"
+	"time"
+
+	libp2pevent "github.com/libp2p/go-libp2p/core/event"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Default values for the [PersistentPeersConfig] backoff fields.
+const (
+	DefaultMinReconnectDelay = 500 * time.Millisecond
+	DefaultMaxReconnectDelay = 30 * time.Second
+)
+
+// persistentPeerTag is the connection manager protection tag
+// for connections to persistent peers.
+const persistentPeerTag = "gcosmos-persistent-peer"
+
+// PersistentPeers maintains outbound connections to a fixed set of peers,
+// such as the sentry nodes in front of a validator,
+// reconnecting with exponential backoff whenever a connection is lost.
+type PersistentPeers struct {
+	log *slog.Logger
+
+	host libp2phost.Host
+
+	minDelay, maxDelay time.Duration
+
+	wg sync.WaitGroup
+}
+
+// PersistentPeersConfig is the configuration for [NewPersistentPeers].
+type PersistentPeersConfig struct {
+	// The host that dials the peers.
+	Host libp2phost.Host
+
+	// The peers to stay connected to.
+	// Each entry must have at least one address.
+	Peers []libp2ppeer.AddrInfo
+
+	// The delay before the first reconnect attempt after a failure,
+	// and the cap on the delay as it doubles with each consecutive failure.
+	// Default to [DefaultMinReconnectDelay] and [DefaultMaxReconnectDelay] if zero.
+	MinReconnectDelay, MaxReconnectDelay time.Duration
+}
+
+// NewPersistentPeers starts dialing the configured peers in the background.
+// Cancel ctx to stop; use [*PersistentPeers.Wait] to block until the background work finishes.
+func NewPersistentPeers(
+	ctx context.Context,
+	log *slog.Logger,
+	cfg PersistentPeersConfig,
+) (*PersistentPeers, error) {
+	for _, ai := range cfg.Peers {
+		if len(ai.Addrs) == 0 {
+			return nil, fmt.Errorf("persistent peer %s has no addresses", ai.ID)
+		}
+	}
+
+	sub, err := cfg.Host.EventBus().Subscribe(new(libp2pevent.EvtPeerConnectednessChanged))
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to peer connectedness events: %w", err)
+	}
+
+	p := &PersistentPeers{
+		log: log,
+
+		host: cfg.Host,
+
+		minDelay: cfg.MinReconnectDelay,
+		maxDelay: cfg.MaxReconnectDelay,
+	}
+	if p.minDelay <= 0 {
+		p.minDelay = DefaultMinReconnectDelay
+	}
+	if p.maxDelay <= 0 {
+		p.maxDelay = DefaultMaxReconnectDelay
+	}
+
+	// One-buffered so the main loop never blocks on a busy dialer;
+	// a single pending notification is enough for the dialer to check again.
+	disconnected := make(map[libp2ppeer.ID]chan struct{}, len(cfg.Peers))
+	for _, ai := range cfg.Peers {
+		ch := make(chan struct{}, 1)
+		disconnected[ai.ID] = ch
+
+		// Keep the connection manager from trimming these connections.
+		cfg.Host.ConnManager().Protect(ai.ID, persistentPeerTag)
+
+		p.wg.Add(1)
+		go p.dialer(ctx, ai, ch)
+	}
+
+	p.wg.Add(1)
+	go p.mainLoop(ctx, sub, disconnected)
+
+	return p, nil
+}
+
+// Wait blocks until all of p's background work is completed.
+func (p *PersistentPeers) Wait() {
+	p.wg.Wait()
+}
+
+// mainLoop routes disconnect events to the dialer for the disconnected peer.
+func (p *PersistentPeers) mainLoop(
+	ctx context.Context,
+	sub libp2pevent.Subscription,
+	disconnected map[libp2ppeer.ID]chan struct{},
+) {
+	defer p.wg.Done()
+	defer sub.Close()
+
+	ctx, task := trace.NewTask(ctx, "gpeer.PersistentPeers.mainLoop")
+	defer task.End()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.log.Info("Stopping due to context cancellation", "cause", context.Cause(ctx))
+			return
+
+		case e := <-sub.Out():
+			evt := e.(libp2pevent.EvtPeerConnectednessChanged)
+			if evt.Connectedness == libp2pnetwork.Connected {
+				continue
+			}
+
+			ch, ok := disconnected[evt.Peer]
+			if !ok {
+				continue
+			}
+
+			select {
+			case ch <- struct{}{}:
+			default:
+				// Dialer already has a pending notification.
+			}
+		}
+	}
+}
+
+// dialer keeps a connection open to a single persistent peer.
+func (p *PersistentPeers) dialer(ctx context.Context, ai libp2ppeer.AddrInfo, disconnected <-chan struct{}) {
+	defer p.wg.Done()
+
+	log := p.log.With("peer_id", ai.ID)
+
+	for {
+		if !p.connect(ctx, log, ai) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-disconnected:
+			log.Info("Lost connection to persistent peer; reconnecting")
+		}
+	}
+}
+
+// connect dials ai until it succeeds or ctx is canceled,
+// in which case connect returns false.
+// connect returns immediately if the host is already connected to the peer.
+func (p *PersistentPeers) connect(ctx context.Context, log *slog.Logger, ai libp2ppeer.AddrInfo) bool {
+	delay := p.minDelay
+	for {
+		if p.host.Network().Connectedness(ai.ID) == libp2pnetwork.Connected {
+			return true
+		}
+
+		err := p.host.Connect(ctx, ai)
+		if err == nil {
+			log.Info("Connected to persistent peer")
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+
+		log.Info(
+			"Failed to connect to persistent peer; will retry",
+			"delay", delay,
+			"err", err,
+		)
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return false
+		case <-t.C:
+		}
+
+		delay = min(2*delay, p.maxDelay)
+	}
+}
@@ -0,0 +1,71 @@
+package gpeer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	libp2pevent "github.com/libp2p/go-libp2p/core/event"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentPeers_reconnect(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sentry := newHost(t)
+	validator := newHost(t)
+
+	// Watch connection events from the sentry's side.
+	sub, err := sentry.EventBus().Subscribe(new(libp2pevent.EvtPeerConnectednessChanged))
+	require.NoError(t, err)
+	defer sub.Close()
+
+	pp, err := gpeer.NewPersistentPeers(ctx, gtest.NewLogger(t), gpeer.PersistentPeersConfig{
+		Host:  validator,
+		Peers: []libp2ppeer.AddrInfo{*libp2phost.InfoFromHost(sentry)},
+
+		MinReconnectDelay: time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer pp.Wait()
+	defer cancel()
+
+	requireConnectedness(t, sub.Out(), validator.ID(), libp2pnetwork.Connected)
+
+	// The sentry drops the validator, and the validator dials back.
+	require.NoError(t, sentry.Network().ClosePeer(validator.ID()))
+	requireConnectedness(t, sub.Out(), validator.ID(), libp2pnetwork.NotConnected)
+	requireConnectedness(t, sub.Out(), validator.ID(), libp2pnetwork.Connected)
+}
+
+func TestPersistentPeers_requiresAddrs(t *testing.T) {
+	t.Parallel()
+
+	_, err := gpeer.NewPersistentPeers(context.Background(), gtest.NewLogger(t), gpeer.PersistentPeersConfig{
+		Host:  newHost(t),
+		Peers: []libp2ppeer.AddrInfo{{ID: newPeerID(t)}},
+	})
+	require.Error(t, err)
+}
+
+func requireConnectedness(
+	t *testing.T, ch <-chan any, p libp2ppeer.ID, want libp2pnetwork.Connectedness,
+) {
+	t.Helper()
+
+	for {
+		e := gtest.ReceiveOrTimeout(t, ch, gtest.ScaleMs(2000))
+		evt := e.(libp2pevent.EvtPeerConnectednessChanged)
+		if evt.Peer == p && evt.Connectedness == want {
+			return
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package gpeer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gfile"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Offense is a kind of peer misbehavior reported to a [Reputation].
+type Offense uint8
+
+const (
+	// Reserving zero value as invalid.
+	InvalidOffense Offense = iota
+
+	// The peer failed to serve a catchup request,
+	// or it served a malformed or inapplicable committed header.
+	// This is a minor offense, as it may be caused by ordinary network issues.
+	CatchupFailureOffense
+
+	// The peer served block data that did not match the requested data ID.
+	BadBlockDataOffense
+)
+
+func (o Offense) String() string {
+	switch o {
+	case CatchupFailureOffense:
+		return "catchup failure"
+	case BadBlockDataOffense:
+		return "bad block data"
+	default:
+		return fmt.Sprintf("Offense(%d)", uint8(o))
+	}
+}
+
+// Reporter accepts reports of peer misbehavior.
+// Subsystems depend on this interface rather than on [*Reputation] directly,
+// so that reporting can be disabled by passing nil.
+type Reporter interface {
+	ReportPeer(p libp2ppeer.ID, o Offense)
+}
+
+// Default values for the [ReputationConfig] fields.
+const (
+	DefaultBanThreshold     = 100
+	DefaultBanDuration      = 24 * time.Hour
+	DefaultRecoveryInterval = time.Minute
+)
+
+// offensePenalties is the number of penalty points accrued for each offense.
+var offensePenalties = map[Offense]int{
+	CatchupFailureOffense: 10,
+	BadBlockDataOffense:   50,
+}
+
+// ReputationConfig is the configuration for a [Reputation].
+type ReputationConfig struct {
+	// Path to the file where the reputation state is persisted.
+	// The file is read once by [NewReputation], if it exists,
+	// and it is rewritten whenever the state changes.
+	// If empty, the state is only held in memory.
+	Path string
+
+	// The number of penalty points at which a peer is banned.
+	// Defaults to [DefaultBanThreshold] if zero.
+	BanThreshold int
+
+	// How long a peer's first ban lasts.
+	// Every subsequent ban for the same peer lasts twice as long as the previous one,
+	// up to 64 times BanDuration.
+	// Defaults to [DefaultBanDuration] if zero.
+	BanDuration time.Duration
+
+	// A peer's penalty is reduced by one point for each RecoveryInterval that elapses,
+	// so that occasional failures from an otherwise good peer never lead to a ban.
+	// Defaults to [DefaultRecoveryInterval] if zero.
+	RecoveryInterval time.Duration
+}
+
+// Reputation tracks penalty points and bans for libp2p peers.
+//
+// Unlike most Gordian types, Reputation is guarded by a mutex rather than a main loop,
+// because the [ConnectionGater] must be able to query it synchronously
+// from the libp2p host's goroutines.
+type Reputation struct {
+	log *slog.Logger
+
+	path string
+
+	banThreshold     int
+	banDuration      time.Duration
+	recoveryInterval time.Duration
+
+	mu      sync.Mutex
+	peers   map[libp2ppeer.ID]*peerRecord
+	banHook func(libp2ppeer.ID)
+}
+
+// peerRecord is the reputation state of a single peer.
+// Its fields are exported so that it can be persisted as JSON.
+type peerRecord struct {
+	// Penalty points as of UpdatedAt.
+	Penalty   int
+	UpdatedAt time.Time
+
+	// The number of times the peer has been banned,
+	// and when the latest ban expires.
+	Bans        int
+	BannedUntil time.Time
+}
+
+// persistedReputation is the top-level JSON value in the reputation file.
+type persistedReputation struct {
+	Peers map[libp2ppeer.ID]*peerRecord
+}
+
+// NewReputation returns a new Reputation,
+// restoring its state from cfg.Path if that file exists.
+func NewReputation(log *slog.Logger, cfg ReputationConfig) (*Reputation, error) {
+	r := &Reputation{
+		log: log,
+
+		path: cfg.Path,
+
+		banThreshold:     cfg.BanThreshold,
+		banDuration:      cfg.BanDuration,
+		recoveryInterval: cfg.RecoveryInterval,
+
+		peers: make(map[libp2ppeer.ID]*peerRecord),
+	}
+
+	if r.banThreshold <= 0 {
+		r.banThreshold = DefaultBanThreshold
+	}
+	if r.banDuration <= 0 {
+		r.banDuration = DefaultBanDuration
+	}
+	if r.recoveryInterval <= 0 {
+		r.recoveryInterval = DefaultRecoveryInterval
+	}
+
+	if r.path == "" {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("failed to read peer reputation file: %w", err)
+	}
+
+	var p persistedReputation
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse peer reputation file %q: %w", r.path, err)
+	}
+	for id, rec := range p.Peers {
+		if rec == nil {
+			continue
+		}
+		r.peers[id] = rec
+	}
+
+	return r, nil
+}
+
+// SetBanHook sets a function to be called, outside of any lock,
+// whenever a peer becomes banned.
+// This is typically used to close existing connections to the banned peer,
+// as the [ConnectionGater] only intercepts new connections.
+func (r *Reputation) SetBanHook(fn func(libp2ppeer.ID)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.banHook = fn
+}
+
+// ReportPeer records an offense for the given peer,
+// banning the peer if its penalty reaches the configured threshold.
+func (r *Reputation) ReportPeer(p libp2ppeer.ID, o Offense) {
+	penalty, ok := offensePenalties[o]
+	if !ok {
+		panic(fmt.Errorf("BUG: reported invalid offense %d", uint8(o)))
+	}
+
+	now := time.Now()
+
+	r.mu.Lock()
+	rec := r.peers[p]
+	if rec == nil {
+		rec = &peerRecord{UpdatedAt: now}
+		r.peers[p] = rec
+	}
+
+	r.recover(rec, now)
+	rec.Penalty += penalty
+
+	banned := false
+	if rec.Penalty >= r.banThreshold && !now.Before(rec.BannedUntil) {
+		// Double the ban duration for each previous ban, within reason.
+		dur := r.banDuration << min(rec.Bans, 6)
+		rec.Bans++
+		rec.BannedUntil = now.Add(dur)
+		rec.Penalty = 0
+		banned = true
+
+		r.log.Warn(
+			"Banning peer",
+			"peer_id", p,
+			"offense", o.String(),
+			"duration", dur,
+		)
+	} else {
+		r.log.Info(
+			"Recorded peer offense",
+			"peer_id", p,
+			"offense", o.String(),
+			"penalty", rec.Penalty,
+		)
+	}
+
+	err := r.saveLocked()
+	hook := r.banHook
+	r.mu.Unlock()
+
+	if err != nil {
+		r.log.Warn("Failed to persist peer reputation", "err", err)
+	}
+
+	if banned && hook != nil {
+		hook(p)
+	}
+}
+
+// IsBanned reports whether the given peer is currently banned.
+func (r *Reputation) IsBanned(p libp2ppeer.ID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec := r.peers[p]
+	return rec != nil && time.Now().Before(rec.BannedUntil)
+}
+
+// recover reduces rec's penalty according to the time elapsed since it was last updated.
+// The caller must hold r.mu.
+func (r *Reputation) recover(rec *peerRecord, now time.Time) {
+	elapsed := now.Sub(rec.UpdatedAt)
+	if elapsed <= 0 {
+		return
+	}
+
+	recovered := int(elapsed / r.recoveryInterval)
+	if recovered == 0 {
+		// Leave UpdatedAt alone so that partial intervals accumulate.
+		return
+	}
+
+	rec.Penalty = max(rec.Penalty-recovered, 0)
+	rec.UpdatedAt = rec.UpdatedAt.Add(time.Duration(recovered) * r.recoveryInterval)
+}
+
+// saveLocked writes the current state to r.path, if set.
+// The caller must hold r.mu.
+func (r *Reputation) saveLocked() error {
+	if r.path == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(persistedReputation{Peers: r.peers})
+	if err != nil {
+		return fmt.Errorf("failed to marshal peer reputation: %w", err)
+	}
+
+	if err := gfile.WriteAtomic(r.path, b); err != nil {
+		return fmt.Errorf("failed to save peer reputation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package gpeer_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReputation_banAtThreshold(t *testing.T) {
+	t.Parallel()
+
+	r, err := gpeer.NewReputation(gtest.NewLogger(t), gpeer.ReputationConfig{})
+	require.NoError(t, err)
+
+	banned := make(chan libp2ppeer.ID, 1)
+	r.SetBanHook(func(p libp2ppeer.ID) {
+		banned <- p
+	})
+
+	p := newPeerID(t)
+	other := newPeerID(t)
+
+	// The default penalty for bad block data is half the default threshold.
+	r.ReportPeer(p, gpeer.BadBlockDataOffense)
+	require.False(t, r.IsBanned(p))
+	gtest.NotSending(t, banned)
+
+	r.ReportPeer(p, gpeer.BadBlockDataOffense)
+	require.True(t, r.IsBanned(p))
+	require.Equal(t, p, gtest.ReceiveSoon(t, banned))
+
+	// Other peers are unaffected.
+	require.False(t, r.IsBanned(other))
+
+	g := gpeer.ConnectionGater{Reputation: r}
+	require.False(t, g.InterceptPeerDial(p))
+	require.True(t, g.InterceptPeerDial(other))
+}
+
+func TestReputation_banExpires(t *testing.T) {
+	t.Parallel()
+
+	r, err := gpeer.NewReputation(gtest.NewLogger(t), gpeer.ReputationConfig{
+		BanThreshold: 1,
+		BanDuration:  time.Duration(gtest.ScaleMs(50)),
+	})
+	require.NoError(t, err)
+
+	p := newPeerID(t)
+	r.ReportPeer(p, gpeer.CatchupFailureOffense)
+	require.True(t, r.IsBanned(p))
+
+	gtest.Sleep(gtest.ScaleMs(75))
+	require.False(t, r.IsBanned(p))
+}
+
+func TestReputation_penaltyRecovers(t *testing.T) {
+	t.Parallel()
+
+	r, err := gpeer.NewReputation(gtest.NewLogger(t), gpeer.ReputationConfig{
+		BanThreshold:     60,
+		RecoveryInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	p := newPeerID(t)
+	r.ReportPeer(p, gpeer.BadBlockDataOffense)
+
+	// After enough time, the earlier penalty has fully decayed,
+	// so a second offense does not reach the threshold.
+	gtest.Sleep(gtest.ScaleMs(75))
+	r.ReportPeer(p, gpeer.BadBlockDataOffense)
+	require.False(t, r.IsBanned(p))
+}
+
+func TestReputation_persisted(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "data", "peer_reputation.json")
+	cfg := gpeer.ReputationConfig{
+		Path:         path,
+		BanThreshold: 20,
+	}
+
+	r, err := gpeer.NewReputation(gtest.NewLogger(t), cfg)
+	require.NoError(t, err)
+
+	banned := newPeerID(t)
+	penalized := newPeerID(t)
+
+	r.ReportPeer(banned, gpeer.BadBlockDataOffense)
+	r.ReportPeer(penalized, gpeer.CatchupFailureOffense)
+	require.True(t, r.IsBanned(banned))
+	require.False(t, r.IsBanned(penalized))
+
+	// A new Reputation reading the same file keeps the ban
+	// and the outstanding penalty.
+	r2, err := gpeer.NewReputation(gtest.NewLogger(t), cfg)
+	require.NoError(t, err)
+
+	require.True(t, r2.IsBanned(banned))
+	require.False(t, r2.IsBanned(penalized))
+
+	r2.ReportPeer(penalized, gpeer.CatchupFailureOffense)
+	require.True(t, r2.IsBanned(penalized))
+}
+
+func newPeerID(t *testing.T) libp2ppeer.ID {
+	t.Helper()
+
+	_, pub, err := libp2pcrypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+
+	id, err := libp2ppeer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	return id
+}
@@ -0,0 +1,30 @@
+package gprivval
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseAddr splits a signer address into the network and address
+// to pass to [net.Dial] or [net.Listen].
+//
+// The address must be either unix:///path/to/socket or tcp://host:port.
+// Unix socket connections are authenticated only by the socket's file permissions.
+// TCP connections use mutual TLS with pinned keys (see [ServerTLSConfig] and [ClientTLSConfig]),
+// and both [NewClient] and the [Server] refuse TCP without it.
+func ParseAddr(s string) (network, address string, err error) {
+	if a, ok := strings.CutPrefix(s, "unix://"); ok && a != "" {
+		return "unix", a, nil
+	}
+	if a, ok := strings.CutPrefix(s, "tcp://"); ok && a != "" {
+		return "tcp", a, nil
+	}
+	return "", "", fmt.Errorf(
+		"invalid signer address %q (must be unix:///path/to/socket or tcp://host:port)", s,
+	)
+}
+
+// maxMessageSize is the largest message either side will read.
+// Sign bytes include the proposal annotations, which are small,
+// so this is a generous bound.
+const maxMessageSize = 1 << 20
@@ -0,0 +1,100 @@
+package gprivval
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gordian-engine/gordian/gcrypto/gblsminsig"
+)
+
+// blsIKMSize is the amount of random key material in a new BLS key file.
+const blsIKMSize = 32
+
+// blsKeyFile is the JSON representation of a BLS validator key file.
+// Byte slices are encoded as base64.
+type blsKeyFile struct {
+	// The input key material from which the secret key is derived.
+	IKM []byte `json:"ikm"`
+
+	// The compressed public key.
+	// It is redundant with IKM, but it lets operators read the public key
+	// without the tooling to derive it,
+	// and it is checked against the derived key on load.
+	PubKey []byte `json:"pub_key"`
+}
+
+// GenerateBLSKeyFile creates a new BLS minimized-signature validator key
+// and writes it to path.
+// It fails if a file already exists at path.
+func GenerateBLSKeyFile(path string) (gblsminsig.Signer, error) {
+	ikm := make([]byte, blsIKMSize)
+	if _, err := rand.Read(ikm); err != nil {
+		return gblsminsig.Signer{}, fmt.Errorf("failed to generate BLS key material: %w", err)
+	}
+
+	s, err := gblsminsig.NewSigner(ikm)
+	if err != nil {
+		return gblsminsig.Signer{}, fmt.Errorf("failed to derive BLS key: %w", err)
+	}
+
+	j, err := json.Marshal(blsKeyFile{
+		IKM:    ikm,
+		PubKey: s.PubKey().PubKeyBytes(),
+	})
+	if err != nil {
+		return gblsminsig.Signer{}, fmt.Errorf("failed to marshal BLS key file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return gblsminsig.Signer{}, fmt.Errorf("failed to create directory for BLS key: %w", err)
+	}
+
+	// O_EXCL so that we never clobber an existing validator key.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return gblsminsig.Signer{}, fmt.Errorf("failed to create BLS key file: %w", err)
+	}
+	if _, err := f.Write(j); err != nil {
+		_ = f.Close()
+		return gblsminsig.Signer{}, fmt.Errorf("failed to write BLS key file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return gblsminsig.Signer{}, fmt.Errorf("failed to close BLS key file: %w", err)
+	}
+
+	return s, nil
+}
+
+// LoadBLSKeyFile reads the BLS minimized-signature validator key
+// previously written by [GenerateBLSKeyFile].
+//
+// It returns an error if the file is missing, cannot be parsed,
+// or holds a public key that does not match its key material.
+func LoadBLSKeyFile(path string) (gblsminsig.Signer, error) {
+	j, err := os.ReadFile(path)
+	if err != nil {
+		return gblsminsig.Signer{}, fmt.Errorf("failed to read BLS key file: %w", err)
+	}
+
+	var kf blsKeyFile
+	if err := json.Unmarshal(j, &kf); err != nil {
+		return gblsminsig.Signer{}, fmt.Errorf("failed to parse BLS key file %q: %w", path, err)
+	}
+
+	s, err := gblsminsig.NewSigner(kf.IKM)
+	if err != nil {
+		return gblsminsig.Signer{}, fmt.Errorf("invalid key material in BLS key file %q: %w", path, err)
+	}
+
+	if !bytes.Equal(s.PubKey().PubKeyBytes(), kf.PubKey) {
+		return gblsminsig.Signer{}, fmt.Errorf(
+			"BLS key file %q has a public key that does not match its key material", path,
+		)
+	}
+
+	return s, nil
+}
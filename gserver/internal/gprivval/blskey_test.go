@@ -0,0 +1,59 @@
+package gprivval_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLSKeyFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config", "bls_key.json")
+
+	s, err := gprivval.GenerateBLSKeyFile(path)
+	require.NoError(t, err)
+
+	loaded, err := gprivval.LoadBLSKeyFile(path)
+	require.NoError(t, err)
+	require.True(t, s.PubKey().Equal(loaded.PubKey()))
+
+	msg := []byte("hello")
+	sig, err := loaded.Sign(context.Background(), msg)
+	require.NoError(t, err)
+	require.True(t, s.PubKey().Verify(msg, sig))
+
+	// Never overwrite an existing key.
+	_, err = gprivval.GenerateBLSKeyFile(path)
+	require.Error(t, err)
+}
+
+func TestLoadBLSKeyFile_mismatchedPubKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path1 := filepath.Join(dir, "bls_key_1.json")
+	path2 := filepath.Join(dir, "bls_key_2.json")
+
+	_, err := gprivval.GenerateBLSKeyFile(path1)
+	require.NoError(t, err)
+	_, err = gprivval.GenerateBLSKeyFile(path2)
+	require.NoError(t, err)
+
+	// Splice the second file's public key into the first file.
+	j1, err := os.ReadFile(path1)
+	require.NoError(t, err)
+	j2, err := os.ReadFile(path2)
+	require.NoError(t, err)
+	pub2 := string(j2[strings.Index(string(j2), `"pub_key"`):])
+	spliced := string(j1[:strings.Index(string(j1), `"pub_key"`)]) + pub2
+	require.NoError(t, os.WriteFile(path1, []byte(spliced), 0o600))
+
+	_, err = gprivval.LoadBLSKeyFile(path1)
+	require.ErrorContains(t, err, "does not match")
+}
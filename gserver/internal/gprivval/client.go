@@ -0,0 +1,341 @@
+package gprivval
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval/gprivvalpb"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+// ErrDoubleSignRefused is wrapped in the error returned from [*Client] methods
+// when the remote signer refuses a request because it conflicts with a previous signature.
+var ErrDoubleSignRefused = errors.New("remote signer refused to double sign")
+
+// DefaultRequestTimeout is the default for [ClientConfig.RequestTimeout].
+const DefaultRequestTimeout = 5 * time.Second
+
+// DefaultSignTimeout is the default for [ClientConfig.SignTimeout].
+const DefaultSignTimeout = 30 * time.Second
+
+// Bounds of the delay between retries of a failed sign request.
+const (
+	minRetryDelay = 50 * time.Millisecond
+	maxRetryDelay = time.Second
+)
+
+var _ tmconsensus.Signer = (*Client)(nil)
+
+// Client is a [tmconsensus.Signer] that requests signatures from a remote [Server].
+//
+// Requests are sent one at a time over a single connection,
+// which is redialed on the next request if it fails.
+// Sign requests that fail to reach the signer are retried
+// for up to [ClientConfig.SignTimeout].
+type Client struct {
+	log *slog.Logger
+
+	network, address string
+	tlsConfig        *tls.Config
+
+	scheme      tmconsensus.SignatureScheme
+	pubKey      gcrypto.PubKey
+	timeout     time.Duration
+	signTimeout time.Duration
+
+	// Guards the connection, so that each response is read
+	// by the goroutine that sent the corresponding request.
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// ClientConfig is the configuration for [NewClient].
+type ClientConfig struct {
+	// The address of the remote signer, as understood by [ParseAddr].
+	Addr string
+
+	// The TLS configuration to authenticate the signer, and to authenticate to it,
+	// typically from [ClientTLSConfig].
+	// Required for a TCP address, and ignored for a Unix socket.
+	TLSConfig *tls.Config
+
+	// The registry to decode the signer's public key.
+	Registry *gcrypto.Registry
+
+	// The scheme to produce the bytes to sign.
+	SignatureScheme tmconsensus.SignatureScheme
+
+	// How long to wait for the signer to respond to a single request,
+	// if the request context has no earlier deadline.
+	// Defaults to [DefaultRequestTimeout] if zero.
+	RequestTimeout time.Duration
+
+	// How long to keep retrying a sign request
+	// that fails because the signer could not be reached or did not respond,
+	// if the request context has no earlier deadline.
+	// The engine treats a signing failure as fatal,
+	// so a brief outage or restart of the signer must not surface as an error.
+	// Defaults to [DefaultSignTimeout] if zero.
+	SignTimeout time.Duration
+}
+
+// NewClient connects to the remote signer and retrieves its public key.
+// It returns an error if the signer cannot be reached,
+// so that a validator does not start without the ability to sign.
+func NewClient(ctx context.Context, log *slog.Logger, cfg ClientConfig) (*Client, error) {
+	network, address, err := ParseAddr(cfg.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if network != "unix" && cfg.TLSConfig == nil {
+		return nil, fmt.Errorf("signer address %q requires a TLS configuration for mutual authentication", cfg.Addr)
+	}
+
+	c := &Client{
+		log: log,
+
+		network: network,
+		address: address,
+
+		tlsConfig: cfg.TLSConfig,
+
+		scheme:      cfg.SignatureScheme,
+		timeout:     cfg.RequestTimeout,
+		signTimeout: cfg.SignTimeout,
+	}
+	if c.timeout <= 0 {
+		c.timeout = DefaultRequestTimeout
+	}
+	if c.signTimeout <= 0 {
+		c.signTimeout = DefaultSignTimeout
+	}
+
+	resp, err := c.roundTrip(ctx, &gprivvalpb.Request{
+		Request: &gprivvalpb.Request_PubKey{PubKey: &gprivvalpb.PubKeyRequest{}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key from remote signer: %w", err)
+	}
+	pkr := resp.GetPubKey()
+	if pkr == nil {
+		return nil, fmt.Errorf("remote signer sent unexpected response %T to public key request", resp.Response)
+	}
+	c.pubKey, err = cfg.Registry.Unmarshal(pkr.EncodedPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key from remote signer: %w", err)
+	}
+
+	return c, nil
+}
+
+// Close closes the connection to the remote signer, if one is open.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	c.r = nil
+	return err
+}
+
+func (c *Client) PubKey() gcrypto.PubKey {
+	return c.pubKey
+}
+
+func (c *Client) Prevote(ctx context.Context, vt tmconsensus.VoteTarget) (
+	signContent, signature []byte, err error,
+) {
+	signContent, err = tmconsensus.PrevoteSignBytes(vt, c.scheme)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate prevote sign bytes: %w", err)
+	}
+
+	signature, err = c.sign(ctx, vt.Height, vt.Round, PrevoteStep, signContent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign prevote: %w", err)
+	}
+	return signContent, signature, nil
+}
+
+func (c *Client) Precommit(ctx context.Context, vt tmconsensus.VoteTarget) (
+	signContent, signature []byte, err error,
+) {
+	signContent, err = tmconsensus.PrecommitSignBytes(vt, c.scheme)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate precommit sign bytes: %w", err)
+	}
+
+	signature, err = c.sign(ctx, vt.Height, vt.Round, PrecommitStep, signContent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign precommit: %w", err)
+	}
+	return signContent, signature, nil
+}
+
+func (c *Client) SignProposedHeader(ctx context.Context, ph *tmconsensus.ProposedHeader) error {
+	signContent, err := tmconsensus.ProposalSignBytes(ph.Header, ph.Round, ph.Annotations, c.scheme)
+	if err != nil {
+		return fmt.Errorf("failed to generate proposal sign bytes: %w", err)
+	}
+
+	sig, err := c.sign(ctx, ph.Header.Height, ph.Round, ProposalStep, signContent)
+	if err != nil {
+		return fmt.Errorf("failed to sign proposal: %w", err)
+	}
+
+	ph.Signature = sig
+	return nil
+}
+
+func (c *Client) sign(
+	ctx context.Context, height uint64, round uint32, step Step, signBytes []byte,
+) ([]byte, error) {
+	req := &gprivvalpb.Request{
+		Request: &gprivvalpb.Request_Sign{
+			Sign: &gprivvalpb.SignRequest{
+				Height:    height,
+				Round:     round,
+				Step:      step.pb(),
+				SignBytes: signBytes,
+			},
+		},
+	}
+
+	resp, err := c.roundTripWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := resp.Response.(type) {
+	case *gprivvalpb.Response_Sign:
+		// Don't trust the signer blindly;
+		// an invalid signature would only be rejected later by our peers.
+		if !c.pubKey.Verify(signBytes, r.Sign.Signature) {
+			return nil, errors.New("remote signer returned an invalid signature")
+		}
+		return r.Sign.Signature, nil
+
+	case *gprivvalpb.Response_Error:
+		if r.Error.DoubleSign {
+			return nil, fmt.Errorf("%w: %s", ErrDoubleSignRefused, r.Error.Description)
+		}
+		return nil, fmt.Errorf("remote signer error: %s", r.Error.Description)
+
+	default:
+		return nil, fmt.Errorf("remote signer sent unexpected response %T to sign request", resp.Response)
+	}
+}
+
+// roundTripWithRetry calls roundTrip until it succeeds,
+// waiting a little longer between each attempt,
+// and gives up once the sign timeout elapses or ctx is canceled.
+//
+// Retrying a sign request is safe, as the signer returns its previous signature
+// for the same content at the same height, round, and step.
+func (c *Client) roundTripWithRetry(ctx context.Context, req *gprivvalpb.Request) (*gprivvalpb.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.signTimeout)
+	defer cancel()
+
+	delay := minRetryDelay
+	for {
+		resp, err := c.roundTrip(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		c.log.Warn("Remote signer request failed; retrying", "err", err, "delay", delay)
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, fmt.Errorf("giving up on remote signer (%w); last error: %w", context.Cause(ctx), err)
+		case <-t.C:
+		}
+
+		delay = min(2*delay, maxRetryDelay)
+	}
+}
+
+// roundTrip sends req to the signer and returns its response,
+// dialing the signer first if there is no open connection.
+func (c *Client) roundTrip(ctx context.Context, req *gprivvalpb.Request) (*gprivvalpb.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if c.conn == nil {
+		conn, err := c.dial(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial remote signer: %w", err)
+		}
+		c.log.Info("Connected to remote signer", "network", c.network, "addr", c.address)
+		c.conn = conn
+		c.r = bufio.NewReader(conn)
+	}
+
+	resp, err := c.roundTripLocked(ctx, req)
+	if err != nil {
+		// We can't know the state of the stream after a failure,
+		// so start over with a new connection on the next request.
+		_ = c.conn.Close()
+		c.conn = nil
+		c.r = nil
+		return nil, err
+	}
+	return resp, nil
+}
+
+// dial connects to the signer,
+// completing the TLS handshake if the signer is not on a Unix socket.
+func (c *Client) dial(ctx context.Context) (net.Conn, error) {
+	if c.network == "unix" {
+		var d net.Dialer
+		return d.DialContext(ctx, c.network, c.address)
+	}
+
+	d := tls.Dialer{Config: c.tlsConfig}
+	return d.DialContext(ctx, c.network, c.address)
+}
+
+func (c *Client) roundTripLocked(ctx context.Context, req *gprivvalpb.Request) (*gprivvalpb.Response, error) {
+	// The connection does not respect the context directly,
+	// so apply its deadline and unblock on cancellation.
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := c.conn.SetDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("failed to set deadline on signer connection: %w", err)
+		}
+	}
+	conn := c.conn
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if _, err := protodelim.MarshalTo(c.conn, req); err != nil {
+		return nil, fmt.Errorf("failed to write request to remote signer: %w", err)
+	}
+
+	var resp gprivvalpb.Response
+	opts := protodelim.UnmarshalOptions{MaxSize: maxMessageSize}
+	if err := opts.UnmarshalFrom(c.r, &resp); err != nil {
+		return nil, fmt.Errorf("failed to read response from remote signer: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,406 @@
+package gprivval_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval/gprivvalpb"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gscheme"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+func TestClient_sign(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	addr, signer, _ := startServer(t, ctx, dir)
+
+	c := newClient(t, ctx, addr)
+	require.True(t, signer.PubKey().Equal(c.PubKey()))
+
+	vt := tmconsensus.VoteTarget{Height: 1, Round: 0, BlockHash: "block"}
+	content, sig, err := c.Prevote(ctx, vt)
+	require.NoError(t, err)
+	require.True(t, c.PubKey().Verify(content, sig))
+
+	// Voting for a different block at the same height and round is refused.
+	nilVT := tmconsensus.VoteTarget{Height: 1, Round: 0}
+	_, _, err = c.Prevote(ctx, nilVT)
+	require.ErrorIs(t, err, gprivval.ErrDoubleSignRefused)
+
+	content, sig, err = c.Precommit(ctx, vt)
+	require.NoError(t, err)
+	require.True(t, c.PubKey().Verify(content, sig))
+
+	ph := tmconsensustest.NewEd25519Fixture(1).NextProposedHeader([]byte("app_data"), 0)
+	ph.Header.Height = 2
+	require.NoError(t, c.SignProposedHeader(ctx, &ph))
+	content, err = tmconsensus.ProposalSignBytes(ph.Header, ph.Round, ph.Annotations, testScheme)
+	require.NoError(t, err)
+	require.True(t, c.PubKey().Verify(content, ph.Signature))
+}
+
+func TestClient_restartedSigner(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+
+	sCtx, sCancel := context.WithCancel(ctx)
+	addr, _, s := startServer(t, sCtx, dir)
+
+	c := newClient(t, ctx, addr)
+
+	_, _, err := c.Precommit(ctx, tmconsensus.VoteTarget{Height: 3, Round: 1, BlockHash: "block"})
+	require.NoError(t, err)
+
+	// Restart the signer with the same state.
+	sCancel()
+	s.Wait()
+	startServer(t, ctx, dir)
+
+	// The first request may fail on the stale connection,
+	// and then the client redials on the next request.
+	_, _, err = c.Prevote(ctx, tmconsensus.VoteTarget{Height: 3, Round: 1, BlockHash: "block"})
+	if !errors.Is(err, gprivval.ErrDoubleSignRefused) {
+		_, _, err = c.Prevote(ctx, tmconsensus.VoteTarget{Height: 3, Round: 1, BlockHash: "block"})
+	}
+	require.ErrorIs(t, err, gprivval.ErrDoubleSignRefused)
+
+	// Moving on to the next height works.
+	_, _, err = c.Prevote(ctx, tmconsensus.VoteTarget{Height: 4, Round: 0, BlockHash: "block"})
+	require.NoError(t, err)
+}
+
+func TestClient_signerUnavailable(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+
+	sCtx, sCancel := context.WithCancel(ctx)
+	addr, _, s := startServer(t, sCtx, dir)
+
+	c := newClient(t, ctx, addr)
+
+	// Stop the signer, and only bring it back while the client is waiting on it.
+	sCancel()
+	s.Wait()
+
+	go func() {
+		gtest.Sleep(gtest.ScaleMs(200))
+		startServer(t, ctx, dir)
+	}()
+
+	content, sig, err := c.Prevote(ctx, tmconsensus.VoteTarget{Height: 1, Round: 0, BlockHash: "block"})
+	require.NoError(t, err)
+	require.True(t, c.PubKey().Verify(content, sig))
+}
+
+func TestClient_signTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+
+	sCtx, sCancel := context.WithCancel(ctx)
+	addr, _, s := startServer(t, sCtx, dir)
+
+	var reg gcrypto.Registry
+	gcrypto.RegisterEd25519(&reg)
+	c, err := gprivval.NewClient(ctx, gtest.NewLogger(t), gprivval.ClientConfig{
+		Addr:            addr,
+		Registry:        &reg,
+		SignatureScheme: testScheme,
+		SignTimeout:     time.Duration(gtest.ScaleMs(200)),
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	sCancel()
+	s.Wait()
+
+	_, _, err = c.Prevote(ctx, tmconsensus.VoteTarget{Height: 1, Round: 0, BlockHash: "block"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestServer_mismatchedRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	addr, _, _ := startServer(t, ctx, dir)
+
+	conn, err := net.Dial("unix", strings.TrimPrefix(addr, "unix://"))
+	require.NoError(t, err)
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	sign := func(height uint64, round uint32, step gprivvalpb.SignStep, signBytes []byte) *gprivvalpb.Response {
+		t.Helper()
+
+		_, err := protodelim.MarshalTo(conn, &gprivvalpb.Request{
+			Request: &gprivvalpb.Request_Sign{
+				Sign: &gprivvalpb.SignRequest{
+					Height:    height,
+					Round:     round,
+					Step:      step,
+					SignBytes: signBytes,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var resp gprivvalpb.Response
+		require.NoError(t, protodelim.UnmarshalFrom(r, &resp))
+		return &resp
+	}
+
+	prevote, err := tmconsensus.PrevoteSignBytes(tmconsensus.VoteTarget{Height: 5, Round: 0, BlockHash: "block"}, testScheme)
+	require.NoError(t, err)
+
+	// Declaring a later height than the sign bytes must not get them signed.
+	resp := sign(6, 0, gprivvalpb.SignStep_SIGN_STEP_PREVOTE, prevote)
+	require.NotNil(t, resp.GetError())
+	require.False(t, resp.GetError().DoubleSign)
+
+	// Nor may the step disagree.
+	resp = sign(5, 0, gprivvalpb.SignStep_SIGN_STEP_PRECOMMIT, prevote)
+	require.NotNil(t, resp.GetError())
+
+	// Sign bytes for another chain are refused.
+	other, err := tmconsensus.PrevoteSignBytes(
+		tmconsensus.VoteTarget{Height: 5, Round: 0, BlockHash: "block"},
+		gscheme.SignatureSchemeV1{ChainID: "other-chain"},
+	)
+	require.NoError(t, err)
+	resp = sign(5, 0, gprivvalpb.SignStep_SIGN_STEP_PREVOTE, other)
+	require.NotNil(t, resp.GetError())
+
+	// Nothing was signed, so the correctly declared request succeeds.
+	resp = sign(5, 0, gprivvalpb.SignStep_SIGN_STEP_PREVOTE, prevote)
+	require.NotNil(t, resp.GetSign())
+}
+
+func TestClient_tcpMutualTLS(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, serverKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, clientKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	serverTLS, err := gprivval.ServerTLSConfig(serverKey, []ed25519.PublicKey{clientKey.Public().(ed25519.PublicKey)})
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := "tcp://" + ln.Addr().String()
+	signer, _ := startServerOn(t, ctx, t.TempDir(), ln, serverTLS)
+
+	var reg gcrypto.Registry
+	gcrypto.RegisterEd25519(&reg)
+	newTCPClient := func(tlsConfig *tls.Config) (*gprivval.Client, error) {
+		c, err := gprivval.NewClient(ctx, gtest.NewLogger(t), gprivval.ClientConfig{
+			Addr:            addr,
+			TLSConfig:       tlsConfig,
+			Registry:        &reg,
+			SignatureScheme: testScheme,
+			RequestTimeout:  time.Duration(gtest.ScaleMs(2000)),
+		})
+		if err == nil {
+			t.Cleanup(func() { _ = c.Close() })
+		}
+		return c, err
+	}
+
+	t.Run("allowed client", func(t *testing.T) {
+		clientTLS, err := gprivval.ClientTLSConfig(clientKey, serverKey.Public().(ed25519.PublicKey))
+		require.NoError(t, err)
+
+		c, err := newTCPClient(clientTLS)
+		require.NoError(t, err)
+		require.True(t, signer.PubKey().Equal(c.PubKey()))
+
+		content, sig, err := c.Prevote(ctx, tmconsensus.VoteTarget{Height: 1, Round: 0, BlockHash: "block"})
+		require.NoError(t, err)
+		require.True(t, c.PubKey().Verify(content, sig))
+	})
+
+	t.Run("client key not allowed", func(t *testing.T) {
+		clientTLS, err := gprivval.ClientTLSConfig(otherKey, serverKey.Public().(ed25519.PublicKey))
+		require.NoError(t, err)
+
+		_, err = newTCPClient(clientTLS)
+		require.Error(t, err)
+	})
+
+	t.Run("wrong server key", func(t *testing.T) {
+		clientTLS, err := gprivval.ClientTLSConfig(clientKey, otherKey.Public().(ed25519.PublicKey))
+		require.NoError(t, err)
+
+		_, err = newTCPClient(clientTLS)
+		require.Error(t, err)
+	})
+
+	t.Run("no TLS", func(t *testing.T) {
+		_, err := newTCPClient(nil)
+		require.Error(t, err)
+	})
+}
+
+func TestServer_tcpRefusesUnauthenticated(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, tc := range []struct {
+		name      string
+		tlsConfig func(t *testing.T) *tls.Config
+	}{
+		{name: "no TLS configuration", tlsConfig: func(*testing.T) *tls.Config { return nil }},
+		{name: "TLS configuration", tlsConfig: func(t *testing.T) *tls.Config {
+			_, serverKey, err := ed25519.GenerateKey(nil)
+			require.NoError(t, err)
+			_, clientKey, err := ed25519.GenerateKey(nil)
+			require.NoError(t, err)
+			cfg, err := gprivval.ServerTLSConfig(serverKey, []ed25519.PublicKey{clientKey.Public().(ed25519.PublicKey)})
+			require.NoError(t, err)
+			return cfg
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			startServerOn(t, ctx, dir, ln, tc.tlsConfig(t))
+
+			conn, err := net.Dial("tcp", ln.Addr().String())
+			require.NoError(t, err)
+			defer conn.Close()
+			require.NoError(t, conn.SetDeadline(time.Now().Add(time.Duration(gtest.ScaleMs(2000)))))
+
+			prevote, err := tmconsensus.PrevoteSignBytes(tmconsensus.VoteTarget{Height: 5, Round: 0, BlockHash: "block"}, testScheme)
+			require.NoError(t, err)
+			_, _ = protodelim.MarshalTo(conn, &gprivvalpb.Request{
+				Request: &gprivvalpb.Request_Sign{
+					Sign: &gprivvalpb.SignRequest{
+						Height:    5,
+						Round:     0,
+						Step:      gprivvalpb.SignStep_SIGN_STEP_PREVOTE,
+						SignBytes: prevote,
+					},
+				},
+			})
+
+			// The server closes the connection without a response.
+			var resp gprivvalpb.Response
+			require.Error(t, protodelim.UnmarshalFrom(bufio.NewReader(conn), &resp))
+
+			// And nothing was signed.
+			wm, err := gprivval.OpenWatermark(filepath.Join(dir, "sign_state.json"))
+			require.NoError(t, err)
+			_, _, step := wm.Last()
+			require.Equal(t, gprivval.InvalidStep, step)
+		})
+	}
+}
+
+// testScheme is the signature scheme shared by the test clients and servers.
+var testScheme = gscheme.SignatureSchemeV1{ChainID: "test-chain"}
+
+// startServer starts a signer on a Unix socket in dir,
+// with its sign state also in dir.
+// The same key is used for every server in the same dir.
+func startServer(
+	t *testing.T, ctx context.Context, dir string,
+) (addr string, signer gcrypto.Signer, s *gprivval.Server) {
+	t.Helper()
+
+	sockPath := filepath.Join(dir, "signer.sock")
+	ln, err := net.Listen("unix", sockPath)
+	require.NoError(t, err)
+
+	signer, s = startServerOn(t, ctx, dir, ln, nil)
+	return "unix://" + sockPath, signer, s
+}
+
+// startServerOn starts a signer accepting connections on ln,
+// with its sign state in dir.
+func startServerOn(
+	t *testing.T, ctx context.Context, dir string, ln net.Listener, tlsConfig *tls.Config,
+) (signer gcrypto.Signer, s *gprivval.Server) {
+	t.Helper()
+
+	// Deterministic key from the directory name.
+	seed := make([]byte, ed25519.SeedSize)
+	copy(seed, filepath.Base(dir))
+	signer = gcrypto.NewEd25519Signer(ed25519.NewKeyFromSeed(seed))
+
+	wm, err := gprivval.OpenWatermark(filepath.Join(dir, "sign_state.json"))
+	require.NoError(t, err)
+
+	var reg gcrypto.Registry
+	gcrypto.RegisterEd25519(&reg)
+
+	s = gprivval.NewServer(ctx, gtest.NewLogger(t), gprivval.ServerConfig{
+		Listener:  ln,
+		Signer:    signer,
+		Parser:    testScheme,
+		Registry:  &reg,
+		Watermark: wm,
+		TLSConfig: tlsConfig,
+	})
+	t.Cleanup(s.Wait)
+
+	return signer, s
+}
+
+func newClient(t *testing.T, ctx context.Context, addr string) *gprivval.Client {
+	t.Helper()
+
+	var reg gcrypto.Registry
+	gcrypto.RegisterEd25519(&reg)
+
+	c, err := gprivval.NewClient(ctx, gtest.NewLogger(t), gprivval.ClientConfig{
+		Addr:            addr,
+		Registry:        &reg,
+		SignatureScheme: testScheme,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
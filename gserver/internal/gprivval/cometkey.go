@@ -0,0 +1,61 @@
+package gprivval
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/privval"
+)
+
+// LoadCometKeyFile reads the ed25519 private key
+// from the Comet privval key file at path.
+//
+// Unlike [privval.LoadFilePV], which exits the process on any failure,
+// LoadCometKeyFile returns an error if the file is missing,
+// cannot be parsed, holds a key type other than ed25519,
+// or holds a public key that does not match its private key.
+func LoadCometKeyFile(path string) (ed25519.PrivateKey, error) {
+	j, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validator key file: %w", err)
+	}
+
+	var pvKey privval.FilePVKey
+	if err := cmtjson.Unmarshal(j, &pvKey); err != nil {
+		return nil, fmt.Errorf("failed to parse validator key file %q: %w", path, err)
+	}
+
+	if pvKey.PrivKey == nil {
+		return nil, fmt.Errorf("validator key file %q has no private key", path)
+	}
+	if t := pvKey.PrivKey.Type(); t != "ed25519" {
+		return nil, fmt.Errorf(
+			"gcosmos only understands ed25519 signing keys; got %q in %q", t, path,
+		)
+	}
+
+	b := pvKey.PrivKey.Bytes()
+	if len(b) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf(
+			"validator key in %q has wrong size: want %d, got %d",
+			path, ed25519.PrivateKeySize, len(b),
+		)
+	}
+	priv := ed25519.PrivateKey(b)
+
+	// The file's public key is redundant, but if it disagrees with the private key,
+	// the file has been edited or corrupted, and we should not sign with it.
+	if pvKey.PubKey != nil {
+		pub := priv.Public().(ed25519.PublicKey)
+		if !bytes.Equal(pub, pvKey.PubKey.Bytes()) {
+			return nil, fmt.Errorf(
+				"validator key file %q has a public key that does not match its private key", path,
+			)
+		}
+	}
+
+	return priv, nil
+}
@@ -0,0 +1,27 @@
+// Package gprivval keeps a validator's consensus key out of the validator process.
+//
+// A [Server] holds the private key in a separate signing process,
+// and a [Client] is the [tmconsensus.Signer] that the validator uses
+// to request signatures from that process over a Unix or TCP socket.
+// The wire messages are defined in the gprivvalpb package.
+// Over TCP, the validator and the signer authenticate each other with mutual TLS,
+// each pinning the other's ed25519 key,
+// and the server does not read any request from a connection until it is authenticated.
+//
+// The server refuses to sign anything at a lower height, round, and step
+// than the last message it signed, or anything different at the same height, round, and step,
+// as recorded in a [Watermark] that persists across restarts.
+// That way, a validator that restarts with a wiped consensus store,
+// or a second validator process misconfigured with the same signer,
+// cannot cause the key to double sign.
+//
+// A validator signing with a local key uses the same protection:
+// a [GuardedSigner] checks every request against a [Watermark] of its own.
+// The guarded signer may also wait for a [DoubleSignCheck]
+// to watch the network for a few heights at startup,
+// so that it does not start signing while another process is already using the key;
+// meanwhile the engine follows consensus without participating, as a full node would.
+//
+// The key itself is either an ed25519 key in a Comet validator key file (see [LoadCometKeyFile])
+// or, for chains using aggregated BLS signatures, a BLS key file (see [LoadBLSKeyFile]).
+package gprivval
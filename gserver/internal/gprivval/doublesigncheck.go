@@ -0,0 +1,261 @@
+package gprivval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gexchange"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+)
+
+// ErrDoubleSignCheckPending is returned from a [GuardedSigner]
+// while its [DoubleSignCheck] has not yet observed enough heights.
+var ErrDoubleSignCheckPending = errors.New("refusing to sign until double sign check completes")
+
+// DoubleSignDetectedError is returned from a [GuardedSigner]
+// after its [DoubleSignCheck] observed a message signed by our own key,
+// most likely by another validator process configured with the same key.
+type DoubleSignDetectedError struct {
+	Height uint64
+	Round  uint32
+	Step   Step
+}
+
+func (e DoubleSignDetectedError) Error() string {
+	return fmt.Sprintf(
+		"refusing to sign: observed a %s at %d/%d signed by our key from another process",
+		e.Step, e.Height, e.Round,
+	)
+}
+
+// DoubleSignCheck watches incoming consensus messages after startup,
+// looking for proposals or votes signed by our own key.
+// If another validator process is already signing with the key,
+// starting to sign would almost certainly produce a double sign.
+//
+// The check passes once it has observed messages for the configured number of heights
+// without seeing our key.
+// Until then, and permanently if our key is seen,
+// a [GuardedSigner] using the check keeps the engine from participating in consensus.
+//
+// Messages at or below the [Watermark] are ignored,
+// because those may be our own messages from before a restart
+// that peers are still gossiping.
+//
+// Only schemes with individual signatures per validator can be inspected;
+// the check cannot find our key inside an aggregated signature.
+type DoubleSignCheck struct {
+	log *slog.Logger
+
+	pubKey gcrypto.PubKey
+	scheme tmconsensus.SignatureScheme
+	wm     *Watermark
+
+	heights uint64
+
+	// Incoming messages are handled concurrently.
+	mu          sync.Mutex
+	startHeight uint64
+	passed      bool
+	detected    *DoubleSignDetectedError
+}
+
+// DoubleSignCheckConfig is the configuration for [NewDoubleSignCheck].
+type DoubleSignCheckConfig struct {
+	// Our validator public key.
+	PubKey gcrypto.PubKey
+
+	// The scheme to reconstruct the bytes signed in incoming votes.
+	SignatureScheme tmconsensus.SignatureScheme
+
+	// The watermark of our own signatures.
+	Watermark *Watermark
+
+	// The number of heights to observe before allowing signing.
+	// Must be positive.
+	Heights uint64
+}
+
+// NewDoubleSignCheck returns a new DoubleSignCheck.
+// Use [*DoubleSignCheck.Handler] to observe incoming messages.
+func NewDoubleSignCheck(log *slog.Logger, cfg DoubleSignCheckConfig) *DoubleSignCheck {
+	if cfg.Heights == 0 {
+		panic(errors.New("BUG: DoubleSignCheckConfig.Heights must be positive"))
+	}
+
+	return &DoubleSignCheck{
+		log: log,
+
+		pubKey: cfg.PubKey,
+		scheme: cfg.SignatureScheme,
+		wm:     cfg.Watermark,
+
+		heights: cfg.Heights,
+	}
+}
+
+// Err returns nil if the check has passed,
+// [ErrDoubleSignCheckPending] if the check is still in progress,
+// or a [DoubleSignDetectedError] if our key was observed.
+func (c *DoubleSignCheck) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.detected != nil {
+		return *c.detected
+	}
+	if !c.passed {
+		return ErrDoubleSignCheckPending
+	}
+	return nil
+}
+
+// Handler returns a [tmconsensus.ConsensusHandler] that inspects every message
+// before passing it to h.
+// Heights only count towards the check once h accepts a message at that height,
+// so that invalid messages cannot end the check early.
+func (c *DoubleSignCheck) Handler(h tmconsensus.ConsensusHandler) tmconsensus.ConsensusHandler {
+	return doubleSignCheckHandler{c: c, h: h}
+}
+
+type doubleSignCheckHandler struct {
+	c *DoubleSignCheck
+	h tmconsensus.ConsensusHandler
+}
+
+func (h doubleSignCheckHandler) HandleProposedHeader(
+	ctx context.Context, ph tmconsensus.ProposedHeader,
+) gexchange.Feedback {
+	if h.c.watching() && ph.ProposerPubKey != nil && ph.ProposerPubKey.Equal(h.c.pubKey) {
+		h.c.observeOwn(ph.Header.Height, ph.Round, ProposalStep)
+	}
+
+	f := h.h.HandleProposedHeader(ctx, ph)
+	if f == gexchange.FeedbackAccepted {
+		h.c.observeHeight(ph.Header.Height)
+	}
+	return f
+}
+
+func (h doubleSignCheckHandler) HandlePrevoteProofs(
+	ctx context.Context, p tmconsensus.PrevoteSparseProof,
+) gexchange.Feedback {
+	if h.c.watching() {
+		h.c.inspectVotes(p.Height, p.Round, PrevoteStep, p.Proofs)
+	}
+
+	f := h.h.HandlePrevoteProofs(ctx, p)
+	if f == gexchange.FeedbackAccepted {
+		h.c.observeHeight(p.Height)
+	}
+	return f
+}
+
+func (h doubleSignCheckHandler) HandlePrecommitProofs(
+	ctx context.Context, p tmconsensus.PrecommitSparseProof,
+) gexchange.Feedback {
+	if h.c.watching() {
+		h.c.inspectVotes(p.Height, p.Round, PrecommitStep, p.Proofs)
+	}
+
+	f := h.h.HandlePrecommitProofs(ctx, p)
+	if f == gexchange.FeedbackAccepted {
+		h.c.observeHeight(p.Height)
+	}
+	return f
+}
+
+// watching reports whether the check is still in progress.
+func (c *DoubleSignCheck) watching() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.passed && c.detected == nil
+}
+
+// inspectVotes checks whether any signature in proofs verifies against our key.
+func (c *DoubleSignCheck) inspectVotes(
+	height uint64, round uint32, step Step, proofs map[string][]gcrypto.SparseSignature,
+) {
+	if !c.aboveWatermark(height, round, step) {
+		return
+	}
+
+	for blockHash, sigs := range proofs {
+		vt := tmconsensus.VoteTarget{Height: height, Round: round, BlockHash: blockHash}
+
+		var signBytes []byte
+		var err error
+		if step == PrevoteStep {
+			signBytes, err = tmconsensus.PrevoteSignBytes(vt, c.scheme)
+		} else {
+			signBytes, err = tmconsensus.PrecommitSignBytes(vt, c.scheme)
+		}
+		if err != nil {
+			// The engine will reject the message anyway.
+			continue
+		}
+
+		for _, sig := range sigs {
+			if c.pubKey.Verify(signBytes, sig.Sig) {
+				c.observeOwn(height, round, step)
+				return
+			}
+		}
+	}
+}
+
+func (c *DoubleSignCheck) aboveWatermark(height uint64, round uint32, step Step) bool {
+	lh, lr, ls := c.wm.Last()
+	return cmpHRS(height, round, step, lh, lr, ls) > 0
+}
+
+// observeOwn records that our key signed a message we did not sign in this process.
+func (c *DoubleSignCheck) observeOwn(height uint64, round uint32, step Step) {
+	if !c.aboveWatermark(height, round, step) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.passed || c.detected != nil {
+		return
+	}
+
+	c.detected = &DoubleSignDetectedError{Height: height, Round: round, Step: step}
+	c.log.Error(
+		"Observed a message signed by our validator key from another process; will not sign",
+		"height", height, "round", round, "step", step,
+	)
+}
+
+// observeHeight advances the check after a message at height was accepted.
+func (c *DoubleSignCheck) observeHeight(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.passed || c.detected != nil {
+		return
+	}
+
+	if c.startHeight == 0 {
+		c.startHeight = height
+		c.log.Info(
+			"Starting double sign check; will not sign until complete",
+			"start_height", height, "heights", c.heights,
+		)
+		return
+	}
+
+	if height >= c.startHeight+c.heights {
+		c.passed = true
+		c.log.Info(
+			"Double sign check passed; participating in consensus from the next round",
+			"start_height", c.startHeight, "height", height,
+		)
+	}
+}
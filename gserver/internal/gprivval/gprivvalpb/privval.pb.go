@@ -0,0 +1,635 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        v5.28.3
+// source: proto/gordian/privval/v1/privval.proto
+
+package gprivvalpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SignStep orders the messages a validator signs within a single round.
+// The signer never signs for a height, round, and step
+// lower than the last one it signed.
+type SignStep int32
+
+const (
+	SignStep_SIGN_STEP_UNSPECIFIED SignStep = 0
+	SignStep_SIGN_STEP_PROPOSAL    SignStep = 1
+	SignStep_SIGN_STEP_PREVOTE     SignStep = 2
+	SignStep_SIGN_STEP_PRECOMMIT   SignStep = 3
+)
+
+// Enum value maps for SignStep.
+var (
+	SignStep_name = map[int32]string{
+		0: "SIGN_STEP_UNSPECIFIED",
+		1: "SIGN_STEP_PROPOSAL",
+		2: "SIGN_STEP_PREVOTE",
+		3: "SIGN_STEP_PRECOMMIT",
+	}
+	SignStep_value = map[string]int32{
+		"SIGN_STEP_UNSPECIFIED": 0,
+		"SIGN_STEP_PROPOSAL":    1,
+		"SIGN_STEP_PREVOTE":     2,
+		"SIGN_STEP_PRECOMMIT":   3,
+	}
+)
+
+func (x SignStep) Enum() *SignStep {
+	p := new(SignStep)
+	*p = x
+	return p
+}
+
+func (x SignStep) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SignStep) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_gordian_privval_v1_privval_proto_enumTypes[0].Descriptor()
+}
+
+func (SignStep) Type() protoreflect.EnumType {
+	return &file_proto_gordian_privval_v1_privval_proto_enumTypes[0]
+}
+
+func (x SignStep) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SignStep.Descriptor instead.
+func (SignStep) EnumDescriptor() ([]byte, []int) {
+	return file_proto_gordian_privval_v1_privval_proto_rawDescGZIP(), []int{0}
+}
+
+type PubKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PubKeyRequest) Reset() {
+	*x = PubKeyRequest{}
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubKeyRequest) ProtoMessage() {}
+
+func (x *PubKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubKeyRequest.ProtoReflect.Descriptor instead.
+func (*PubKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_privval_v1_privval_proto_rawDescGZIP(), []int{0}
+}
+
+type SignRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  uint32   `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Step   SignStep `protobuf:"varint,3,opt,name=step,proto3,enum=gordian.privval.v1.SignStep" json:"step,omitempty"`
+	// The exact bytes to sign.
+	SignBytes []byte `protobuf:"bytes,4,opt,name=sign_bytes,json=signBytes,proto3" json:"sign_bytes,omitempty"`
+}
+
+func (x *SignRequest) Reset() {
+	*x = SignRequest{}
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRequest) ProtoMessage() {}
+
+func (x *SignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRequest.ProtoReflect.Descriptor instead.
+func (*SignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_privval_v1_privval_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *SignRequest) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *SignRequest) GetStep() SignStep {
+	if x != nil {
+		return x.Step
+	}
+	return SignStep_SIGN_STEP_UNSPECIFIED
+}
+
+func (x *SignRequest) GetSignBytes() []byte {
+	if x != nil {
+		return x.SignBytes
+	}
+	return nil
+}
+
+type Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*Request_PubKey
+	//	*Request_Sign
+	Request isRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *Request) Reset() {
+	*x = Request{}
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request) ProtoMessage() {}
+
+func (x *Request) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request.ProtoReflect.Descriptor instead.
+func (*Request) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_privval_v1_privval_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *Request) GetRequest() isRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *Request) GetPubKey() *PubKeyRequest {
+	if x, ok := x.GetRequest().(*Request_PubKey); ok {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *Request) GetSign() *SignRequest {
+	if x, ok := x.GetRequest().(*Request_Sign); ok {
+		return x.Sign
+	}
+	return nil
+}
+
+type isRequest_Request interface {
+	isRequest_Request()
+}
+
+type Request_PubKey struct {
+	PubKey *PubKeyRequest `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3,oneof"`
+}
+
+type Request_Sign struct {
+	Sign *SignRequest `protobuf:"bytes,2,opt,name=sign,proto3,oneof"`
+}
+
+func (*Request_PubKey) isRequest_Request() {}
+
+func (*Request_Sign) isRequest_Request() {}
+
+type PubKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Encoded through a gcrypto.Registry.
+	EncodedPubKey []byte `protobuf:"bytes,1,opt,name=encoded_pub_key,json=encodedPubKey,proto3" json:"encoded_pub_key,omitempty"`
+}
+
+func (x *PubKeyResponse) Reset() {
+	*x = PubKeyResponse{}
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubKeyResponse) ProtoMessage() {}
+
+func (x *PubKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubKeyResponse.ProtoReflect.Descriptor instead.
+func (*PubKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_privval_v1_privval_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PubKeyResponse) GetEncodedPubKey() []byte {
+	if x != nil {
+		return x.EncodedPubKey
+	}
+	return nil
+}
+
+type SignResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SignResponse) Reset() {
+	*x = SignResponse{}
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignResponse) ProtoMessage() {}
+
+func (x *SignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignResponse.ProtoReflect.Descriptor instead.
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_privval_v1_privval_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type ErrorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Description string `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	// Set when the signer refused the request
+	// because it conflicts with a previous signature.
+	DoubleSign bool `protobuf:"varint,2,opt,name=double_sign,json=doubleSign,proto3" json:"double_sign,omitempty"`
+}
+
+func (x *ErrorResponse) Reset() {
+	*x = ErrorResponse{}
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorResponse) ProtoMessage() {}
+
+func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
+func (*ErrorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_privval_v1_privval_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ErrorResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ErrorResponse) GetDoubleSign() bool {
+	if x != nil {
+		return x.DoubleSign
+	}
+	return false
+}
+
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Response:
+	//	*Response_PubKey
+	//	*Response_Sign
+	//	*Response_Error
+	Response isResponse_Response `protobuf_oneof:"response"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_gordian_privval_v1_privval_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_proto_gordian_privval_v1_privval_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *Response) GetResponse() isResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (x *Response) GetPubKey() *PubKeyResponse {
+	if x, ok := x.GetResponse().(*Response_PubKey); ok {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *Response) GetSign() *SignResponse {
+	if x, ok := x.GetResponse().(*Response_Sign); ok {
+		return x.Sign
+	}
+	return nil
+}
+
+func (x *Response) GetError() *ErrorResponse {
+	if x, ok := x.GetResponse().(*Response_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isResponse_Response interface {
+	isResponse_Response()
+}
+
+type Response_PubKey struct {
+	PubKey *PubKeyResponse `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3,oneof"`
+}
+
+type Response_Sign struct {
+	Sign *SignResponse `protobuf:"bytes,2,opt,name=sign,proto3,oneof"`
+}
+
+type Response_Error struct {
+	Error *ErrorResponse `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*Response_PubKey) isResponse_Response() {}
+
+func (*Response_Sign) isResponse_Response() {}
+
+func (*Response_Error) isResponse_Response() {}
+
+var File_proto_gordian_privval_v1_privval_proto protoreflect.FileDescriptor
+
+var file_proto_gordian_privval_v1_privval_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2f,
+	0x70, 0x72, 0x69, 0x76, 0x76, 0x61, 0x6c, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x69, 0x76, 0x76,
+	0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61,
+	0x6e, 0x2e, 0x70, 0x72, 0x69, 0x76, 0x76, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x22, 0x0f, 0x0a, 0x0d,
+	0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x8c, 0x01,
+	0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x30, 0x0a, 0x04, 0x73,
+	0x74, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x72, 0x64,
+	0x69, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x69, 0x76, 0x76, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x69, 0x67, 0x6e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x69, 0x67, 0x6e, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x89, 0x01, 0x0a,
+	0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x6f, 0x72, 0x64,
+	0x69, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x69, 0x76, 0x76, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x75, 0x62, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x06,
+	0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x35, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e, 0x70,
+	0x72, 0x69, 0x76, 0x76, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x42, 0x09, 0x0a,
+	0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x38, 0x0a, 0x0e, 0x50, 0x75, 0x62, 0x4b,
+	0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x65, 0x6e,
+	0x63, 0x6f, 0x64, 0x65, 0x64, 0x5f, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x50, 0x75, 0x62, 0x4b,
+	0x65, 0x79, 0x22, 0x2c, 0x0a, 0x0c, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x22, 0x52, 0x0a, 0x0d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x69,
+	0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65,
+	0x53, 0x69, 0x67, 0x6e, 0x22, 0xc8, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3d, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x69,
+	0x76, 0x76, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79,
+	0x12, 0x36, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x69, 0x76, 0x76, 0x61, 0x6c,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x39, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x6f, 0x72, 0x64, 0x69, 0x61,
+	0x6e, 0x2e, 0x70, 0x72, 0x69, 0x76, 0x76, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a,
+	0x6d, 0x0a, 0x08, 0x53, 0x69, 0x67, 0x6e, 0x53, 0x74, 0x65, 0x70, 0x12, 0x19, 0x0a, 0x15, 0x53,
+	0x49, 0x47, 0x4e, 0x5f, 0x53, 0x54, 0x45, 0x50, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x53,
+	0x54, 0x45, 0x50, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x4f, 0x53, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x15,
+	0x0a, 0x11, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x53, 0x54, 0x45, 0x50, 0x5f, 0x50, 0x52, 0x45, 0x56,
+	0x4f, 0x54, 0x45, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x49, 0x47, 0x4e, 0x5f, 0x53, 0x54,
+	0x45, 0x50, 0x5f, 0x50, 0x52, 0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x10, 0x03, 0x42, 0x48,
+	0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x72,
+	0x64, 0x69, 0x61, 0x6e, 0x2d, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x67, 0x63, 0x6f, 0x73,
+	0x6d, 0x6f, 0x73, 0x2f, 0x67, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x70, 0x72, 0x69, 0x76, 0x76, 0x61, 0x6c, 0x2f, 0x67, 0x70,
+	0x72, 0x69, 0x76, 0x76, 0x61, 0x6c, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_gordian_privval_v1_privval_proto_rawDescOnce sync.Once
+	file_proto_gordian_privval_v1_privval_proto_rawDescData = file_proto_gordian_privval_v1_privval_proto_rawDesc
+)
+
+func file_proto_gordian_privval_v1_privval_proto_rawDescGZIP() []byte {
+	file_proto_gordian_privval_v1_privval_proto_rawDescOnce.Do(func() {
+		file_proto_gordian_privval_v1_privval_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_gordian_privval_v1_privval_proto_rawDescData)
+	})
+	return file_proto_gordian_privval_v1_privval_proto_rawDescData
+}
+
+var file_proto_gordian_privval_v1_privval_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_gordian_privval_v1_privval_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_gordian_privval_v1_privval_proto_goTypes = []any{
+	(SignStep)(0),          // 0: gordian.privval.v1.SignStep
+	(*PubKeyRequest)(nil),  // 1: gordian.privval.v1.PubKeyRequest
+	(*SignRequest)(nil),    // 2: gordian.privval.v1.SignRequest
+	(*Request)(nil),        // 3: gordian.privval.v1.Request
+	(*PubKeyResponse)(nil), // 4: gordian.privval.v1.PubKeyResponse
+	(*SignResponse)(nil),   // 5: gordian.privval.v1.SignResponse
+	(*ErrorResponse)(nil),  // 6: gordian.privval.v1.ErrorResponse
+	(*Response)(nil),       // 7: gordian.privval.v1.Response
+}
+var file_proto_gordian_privval_v1_privval_proto_depIdxs = []int32{
+	0, // 0: gordian.privval.v1.SignRequest.step:type_name -> gordian.privval.v1.SignStep
+	1, // 1: gordian.privval.v1.Request.pub_key:type_name -> gordian.privval.v1.PubKeyRequest
+	2, // 2: gordian.privval.v1.Request.sign:type_name -> gordian.privval.v1.SignRequest
+	4, // 3: gordian.privval.v1.Response.pub_key:type_name -> gordian.privval.v1.PubKeyResponse
+	5, // 4: gordian.privval.v1.Response.sign:type_name -> gordian.privval.v1.SignResponse
+	6, // 5: gordian.privval.v1.Response.error:type_name -> gordian.privval.v1.ErrorResponse
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_gordian_privval_v1_privval_proto_init() }
+func file_proto_gordian_privval_v1_privval_proto_init() {
+	if File_proto_gordian_privval_v1_privval_proto != nil {
+		return
+	}
+	file_proto_gordian_privval_v1_privval_proto_msgTypes[2].OneofWrappers = []any{
+		(*Request_PubKey)(nil),
+		(*Request_Sign)(nil),
+	}
+	file_proto_gordian_privval_v1_privval_proto_msgTypes[6].OneofWrappers = []any{
+		(*Response_PubKey)(nil),
+		(*Response_Sign)(nil),
+		(*Response_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_gordian_privval_v1_privval_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_gordian_privval_v1_privval_proto_goTypes,
+		DependencyIndexes: file_proto_gordian_privval_v1_privval_proto_depIdxs,
+		EnumInfos:         file_proto_gordian_privval_v1_privval_proto_enumTypes,
+		MessageInfos:      file_proto_gordian_privval_v1_privval_proto_msgTypes,
+	}.Build()
+	File_proto_gordian_privval_v1_privval_proto = out.File
+	file_proto_gordian_privval_v1_privval_proto_rawDesc = nil
+	file_proto_gordian_privval_v1_privval_proto_goTypes = nil
+	file_proto_gordian_privval_v1_privval_proto_depIdxs = nil
+}
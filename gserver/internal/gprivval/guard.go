@@ -0,0 +1,216 @@
+package gprivval
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmstore"
+)
+
+var _ tmconsensus.Signer = (*GuardedSigner)(nil)
+
+// GuardedSigner is a [tmconsensus.Signer] that checks every request
+// against a [Watermark] before passing it to another signer.
+//
+// The engine's action store already prevents conflicting votes,
+// but only as long as the action store survives;
+// the in-memory action store is empty after every restart.
+// The watermark is a small file of its own,
+// so it is cheap to keep even when the rest of the consensus state is in memory.
+//
+// With a [DoubleSignCheck], the guarded signer keeps the engine from participating in consensus
+// until the check passes.
+// The engine treats a signing failure as fatal,
+// so instead of refusing requests, [*GuardedSigner.PubKey] reports a stand-in key
+// that is in no validator set, and the engine behaves as a full node.
+// The real key is only reported from the first round the engine enters
+// after the check passes (see [*GuardedSigner.StateMachineStore]),
+// so that the engine never changes its mind about participating partway through a round.
+type GuardedSigner struct {
+	signer tmconsensus.Signer
+	scheme tmconsensus.SignatureScheme
+	wm     *Watermark
+
+	check *DoubleSignCheck
+
+	// Reported by PubKey until live is set.
+	standIn gcrypto.PubKey
+	live    atomic.Bool
+}
+
+// GuardedSignerConfig is the configuration for [NewGuardedSigner].
+type GuardedSignerConfig struct {
+	// The signer that produces the signatures.
+	Signer tmconsensus.Signer
+
+	// The scheme to produce the bytes to sign,
+	// which must match the scheme used by Signer.
+	SignatureScheme tmconsensus.SignatureScheme
+
+	// The persistent record of the last signed message.
+	Watermark *Watermark
+
+	// If set, the engine does not participate in consensus until the check passes,
+	// and every request is refused until then.
+	Check *DoubleSignCheck
+}
+
+// NewGuardedSigner returns a new GuardedSigner.
+func NewGuardedSigner(cfg GuardedSignerConfig) *GuardedSigner {
+	g := &GuardedSigner{
+		signer: cfg.Signer,
+		scheme: cfg.SignatureScheme,
+		wm:     cfg.Watermark,
+
+		check: cfg.Check,
+	}
+
+	if g.check == nil {
+		g.live.Store(true)
+	} else {
+		// A fresh key, so that it cannot be in any validator set.
+		pub, _, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			panic(fmt.Errorf("failed to generate stand-in key: %w", err))
+		}
+		g.standIn = gcrypto.Ed25519PubKey(pub)
+	}
+
+	return g
+}
+
+// PubKey returns the public key of the inner signer,
+// or, while waiting for the double sign check, a stand-in key in no validator set.
+func (g *GuardedSigner) PubKey() gcrypto.PubKey {
+	if !g.live.Load() {
+		return g.standIn
+	}
+	return g.signer.PubKey()
+}
+
+// StateMachineStore wraps the engine's state machine store,
+// to start reporting the real key from the first round entered after the check passes.
+//
+// The engine records each new height and round in its state machine store
+// before it looks up the signer's key to decide whether it participates in that round,
+// and that decision stands until the next round.
+func (g *GuardedSigner) StateMachineStore(s tmstore.StateMachineStore) tmstore.StateMachineStore {
+	return guardedStateMachineStore{StateMachineStore: s, g: g}
+}
+
+type guardedStateMachineStore struct {
+	tmstore.StateMachineStore
+	g *GuardedSigner
+}
+
+func (s guardedStateMachineStore) SetStateMachineHeightRound(
+	ctx context.Context, height uint64, round uint32,
+) error {
+	if err := s.StateMachineStore.SetStateMachineHeightRound(ctx, height, round); err != nil {
+		return err
+	}
+
+	if !s.g.live.Load() && s.g.check.Err() == nil {
+		s.g.live.Store(true)
+	}
+	return nil
+}
+
+func (g *GuardedSigner) Prevote(ctx context.Context, vt tmconsensus.VoteTarget) (
+	signContent, signature []byte, err error,
+) {
+	signContent, err = tmconsensus.PrevoteSignBytes(vt, g.scheme)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate prevote sign bytes: %w", err)
+	}
+
+	signature, err = g.sign(vt.Height, vt.Round, PrevoteStep, signContent, func() ([]byte, []byte, error) {
+		return g.signer.Prevote(ctx, vt)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign prevote: %w", err)
+	}
+	return signContent, signature, nil
+}
+
+func (g *GuardedSigner) Precommit(ctx context.Context, vt tmconsensus.VoteTarget) (
+	signContent, signature []byte, err error,
+) {
+	signContent, err = tmconsensus.PrecommitSignBytes(vt, g.scheme)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate precommit sign bytes: %w", err)
+	}
+
+	signature, err = g.sign(vt.Height, vt.Round, PrecommitStep, signContent, func() ([]byte, []byte, error) {
+		return g.signer.Precommit(ctx, vt)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign precommit: %w", err)
+	}
+	return signContent, signature, nil
+}
+
+func (g *GuardedSigner) SignProposedHeader(ctx context.Context, ph *tmconsensus.ProposedHeader) error {
+	signContent, err := tmconsensus.ProposalSignBytes(ph.Header, ph.Round, ph.Annotations, g.scheme)
+	if err != nil {
+		return fmt.Errorf("failed to generate proposal sign bytes: %w", err)
+	}
+
+	sig, err := g.sign(ph.Header.Height, ph.Round, ProposalStep, signContent, func() ([]byte, []byte, error) {
+		// Sign a copy, so that ph is untouched if the signature is not released.
+		cp := *ph
+		if err := g.signer.SignProposedHeader(ctx, &cp); err != nil {
+			return nil, nil, err
+		}
+
+		// The proposal signer does not report what it signed,
+		// so the best we can do is confirm the signature covers our sign bytes.
+		if !g.signer.PubKey().Verify(signContent, cp.Signature) {
+			return nil, nil, errors.New("inner signer produced a proposal signature that does not match the expected sign bytes")
+		}
+		return signContent, cp.Signature, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign proposal: %w", err)
+	}
+
+	ph.Signature = sig
+	return nil
+}
+
+// sign records the request in the watermark and calls signFn if the request is allowed.
+// signFn returns the content signed by the inner signer,
+// which must match signContent.
+func (g *GuardedSigner) sign(
+	height uint64, round uint32, step Step, signContent []byte,
+	signFn func() (signContent, signature []byte, err error),
+) ([]byte, error) {
+	if g.check != nil {
+		if err := g.check.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if !g.live.Load() {
+		// The check passed, but the engine has not entered a round since.
+		return nil, ErrDoubleSignCheckPending
+	}
+
+	return g.wm.Sign(height, round, step, signContent, func(b []byte) ([]byte, error) {
+		innerContent, sig, err := signFn()
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(innerContent, b) {
+			// Recording a signature over content other than what the signer signed
+			// would make the watermark useless, so refuse to continue.
+			return nil, errors.New("inner signer signed different content than expected (signature scheme mismatch?)")
+		}
+		return sig, nil
+	})
+}
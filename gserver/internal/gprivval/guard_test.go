@@ -0,0 +1,456 @@
+package gprivval_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/bits-and-blooms/bitset"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gexchange"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
+	"github.com/gordian-engine/gordian/tm/tmdriver"
+	"github.com/gordian-engine/gordian/tm/tmengine"
+	"github.com/gordian-engine/gordian/tm/tmengine/tmenginetest"
+	"github.com/gordian-engine/gordian/tm/tmstore/tmmemstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardedSigner_restart(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "sign_state.json")
+	inner := newLocalSigner(1)
+
+	g := newGuardedSigner(t, inner, path, nil)
+
+	vt := tmconsensus.VoteTarget{Height: 2, Round: 0, BlockHash: "block"}
+	content, sig, err := g.Prevote(ctx, vt)
+	require.NoError(t, err)
+	require.True(t, g.PubKey().Verify(content, sig))
+
+	// Simulate a restart with the consensus store wiped,
+	// where the engine tries to vote nil at the same height and round.
+	g = newGuardedSigner(t, inner, path, nil)
+
+	_, _, err = g.Prevote(ctx, tmconsensus.VoteTarget{Height: 2, Round: 0})
+	var dse gprivval.DoubleSignError
+	require.ErrorAs(t, err, &dse)
+
+	// The original vote can be repeated.
+	_, sig2, err := g.Prevote(ctx, vt)
+	require.NoError(t, err)
+	require.Equal(t, sig, sig2)
+
+	// And later messages are still allowed.
+	ph := tmconsensustest.NewEd25519Fixture(1).NextProposedHeader([]byte("app_data"), 0)
+	ph.Header.Height = 2
+	ph.Round = 1
+	require.NoError(t, g.SignProposedHeader(ctx, &ph))
+	content, err = tmconsensus.ProposalSignBytes(
+		ph.Header, ph.Round, ph.Annotations, tmconsensustest.SimpleSignatureScheme{},
+	)
+	require.NoError(t, err)
+	require.True(t, g.PubKey().Verify(content, ph.Signature))
+}
+
+func TestGuardedSigner_doubleSignCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	inner := newLocalSigner(1)
+	wm, err := gprivval.OpenWatermark("")
+	require.NoError(t, err)
+
+	check := gprivval.NewDoubleSignCheck(gtest.NewLogger(t), gprivval.DoubleSignCheckConfig{
+		PubKey:          inner.PubKey(),
+		SignatureScheme: tmconsensustest.SimpleSignatureScheme{},
+		Watermark:       wm,
+		Heights:         2,
+	})
+	g := gprivval.NewGuardedSigner(gprivval.GuardedSignerConfig{
+		Signer:          inner,
+		SignatureScheme: tmconsensustest.SimpleSignatureScheme{},
+		Watermark:       wm,
+		Check:           check,
+	})
+	h := check.Handler(acceptAllHandler{})
+
+	vt := tmconsensus.VoteTarget{Height: 5, Round: 0, BlockHash: "block"}
+	_, _, err = g.Prevote(ctx, vt)
+	require.ErrorIs(t, err, gprivval.ErrDoubleSignCheckPending)
+
+	// Votes from other validators advance the check.
+	other := newLocalSigner(2)
+	require.Equal(t, gexchange.FeedbackAccepted, h.HandlePrevoteProofs(ctx, prevoteProof(t, other, vt)))
+	_, _, err = g.Prevote(ctx, vt)
+	require.ErrorIs(t, err, gprivval.ErrDoubleSignCheckPending)
+
+	// Until the check passes, the engine sees a key outside the validator set.
+	require.False(t, g.PubKey().Equal(inner.PubKey()))
+
+	vt.Height = 7
+	h.HandlePrevoteProofs(ctx, prevoteProof(t, other, vt))
+	require.NoError(t, check.Err())
+
+	// The engine has not entered a round since the check passed,
+	// so it is still not participating.
+	require.False(t, g.PubKey().Equal(inner.PubKey()))
+	_, _, err = g.Prevote(ctx, vt)
+	require.ErrorIs(t, err, gprivval.ErrDoubleSignCheckPending)
+
+	sms := g.StateMachineStore(tmmemstore.NewStateMachineStore())
+	require.NoError(t, sms.SetStateMachineHeightRound(ctx, 7, 0))
+
+	require.True(t, g.PubKey().Equal(inner.PubKey()))
+	_, _, err = g.Prevote(ctx, vt)
+	require.NoError(t, err)
+}
+
+func TestGuardedSigner_engineDuringDoubleSignCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// We are validator 0; the other three have enough power to commit without us.
+	efx := tmenginetest.NewFixture(ctx, t, 4)
+	fx := efx.Fx
+
+	wm, err := gprivval.OpenWatermark("")
+	require.NoError(t, err)
+	inner := tmconsensus.PassthroughSigner{
+		Signer:          fx.PrivVals[0].Signer,
+		SignatureScheme: fx.SignatureScheme,
+	}
+	check := gprivval.NewDoubleSignCheck(gtest.NewLogger(t), gprivval.DoubleSignCheckConfig{
+		PubKey:          inner.PubKey(),
+		SignatureScheme: fx.SignatureScheme,
+		Watermark:       wm,
+		Heights:         1,
+	})
+	g := gprivval.NewGuardedSigner(gprivval.GuardedSignerConfig{
+		Signer:          inner,
+		SignatureScheme: fx.SignatureScheme,
+		Watermark:       wm,
+		Check:           check,
+	})
+
+	opts := efx.SigningOptionMap()
+	opts["WithSigner"] = tmengine.WithSigner(g)
+	opts["WithStateMachineStore"] = tmengine.WithStateMachineStore(g.StateMachineStore(efx.StateMachineStore))
+
+	var engine *tmengine.Engine
+	eReady := make(chan struct{})
+	go func() {
+		defer close(eReady)
+		engine = efx.MustNewEngine(opts.ToSlice()...)
+	}()
+
+	defer func() {
+		cancel()
+		<-eReady
+		engine.Wait()
+	}()
+
+	ercCh := efx.ConsensusStrategy.ExpectEnterRound(1, 0, nil)
+
+	icReq := gtest.ReceiveSoon(t, efx.InitChainCh)
+	const initAppStateHash = "app_state_0"
+	gtest.SendSoon(t, icReq.Resp, tmdriver.InitChainResponse{
+		AppStateHash: []byte(initAppStateHash),
+	})
+	_ = gtest.ReceiveSoon(t, eReady)
+	_ = gtest.ReceiveSoon(t, ercCh)
+
+	h := check.Handler(tmconsensus.AcceptAllValidFeedbackMapper{Handler: engine})
+
+	// Our consensus strategy always goes along with the proposed block.
+	go func() {
+		cs := efx.ConsensusStrategy
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case req := <-cs.ConsiderProposedBlocksRequests:
+				req.ChoiceHash <- string(req.PHs[0].Header.Hash)
+			case req := <-cs.ChooseProposedBlockRequests:
+				var hash string
+				if len(req.Input) > 0 {
+					hash = string(req.Input[0].Header.Hash)
+				}
+				req.ChoiceHash <- hash
+			case req := <-cs.DecidePrecommitRequests:
+				req.ChoiceHash <- req.Input.MostVotedPrevoteHash
+			}
+		}
+	}()
+
+	// propose has validator 1 propose a block at height.
+	propose := func(height uint64) tmconsensus.ProposedHeader {
+		t.Helper()
+
+		ph := fx.NextProposedHeader([]byte(fmt.Sprintf("app_data_%d", height)), 1)
+		if height == 1 {
+			gen := tmconsensus.Genesis{
+				ChainID:             "my-chain", // Hard-coded in the engine fixture.
+				InitialHeight:       1,
+				CurrentAppStateHash: []byte(initAppStateHash),
+				ValidatorSet:        fx.ValSet(),
+			}
+			genHeader, err := gen.Header(fx.HashScheme)
+			require.NoError(t, err)
+			ph.Header.PrevBlockHash = genHeader.Hash
+			ph.Header.PrevAppStateHash = []byte(initAppStateHash)
+			fx.RecalculateHash(&ph.Header)
+		}
+		fx.SignProposal(ctx, &ph, 1)
+		require.Equal(t, gexchange.FeedbackAccepted, h.HandleProposedHeader(ctx, ph))
+
+		return ph
+	}
+
+	// prevote delivers the other validators' prevotes for ph.
+	prevote := func(ph tmconsensus.ProposedHeader) {
+		t.Helper()
+
+		keyHash, _ := fx.ValidatorHashes()
+		require.Equal(t, gexchange.FeedbackAccepted, h.HandlePrevoteProofs(ctx, tmconsensus.PrevoteSparseProof{
+			Height: ph.Header.Height, Round: 0,
+			PubKeyHash: keyHash,
+			Proofs: fx.SparsePrevoteProofMap(ctx, ph.Header.Height, 0, map[string][]int{
+				string(ph.Header.Hash): {1, 2, 3},
+			}),
+		}))
+	}
+
+	// awaitOwnVote waits for the gossip strategy to see our vote for ph.
+	awaitOwnVote := func(ph tmconsensus.ProposedHeader, precommit bool) {
+		t.Helper()
+
+		for {
+			vrv := gtest.ReceiveSoon(t, efx.GossipStrategy.Updates).Voting
+			if vrv == nil || vrv.Height != ph.Header.Height {
+				continue
+			}
+			proofs := vrv.PrevoteProofs
+			if precommit {
+				proofs = vrv.PrecommitProofs
+			}
+			proof, ok := proofs[string(ph.Header.Hash)]
+			if !ok {
+				continue
+			}
+			var bs bitset.BitSet
+			proof.SignatureBitSet(&bs)
+			if bs.Test(0) {
+				return
+			}
+		}
+	}
+
+	// precommitAndCommit delivers the other validators' precommits for ph,
+	// finalizes it, and waits for the engine to enter the next height.
+	precommitAndCommit := func(ph tmconsensus.ProposedHeader) {
+		t.Helper()
+
+		height := ph.Header.Height
+		hash := string(ph.Header.Hash)
+		voteMap := map[string][]int{hash: {1, 2, 3}}
+
+		keyHash, _ := fx.ValidatorHashes()
+		require.Equal(t, gexchange.FeedbackAccepted, h.HandlePrecommitProofs(ctx, tmconsensus.PrecommitSparseProof{
+			Height: height, Round: 0,
+			PubKeyHash: keyHash,
+			Proofs:     fx.SparsePrecommitProofMap(ctx, height, 0, voteMap),
+		}))
+
+		finReq := gtest.ReceiveSoon(t, efx.FinalizeBlockRequests)
+		appStateHash := []byte(fmt.Sprintf("app_state_%d", height))
+		gtest.SendSoon(t, finReq.Resp, tmdriver.FinalizeBlockResponse{
+			Height:    height,
+			Round:     0,
+			BlockHash: ph.Header.Hash,
+
+			Validators: fx.Vals(),
+
+			AppStateHash: appStateHash,
+		})
+		fx.CommitBlock(ph.Header, appStateHash, 0, fx.PrecommitProofMap(ctx, height, 0, voteMap))
+
+		ercCh := efx.ConsensusStrategy.ExpectEnterRound(height+1, 0, nil)
+		for efx.RoundTimer.ElapseCommitWaitTimer(height, 0) != nil {
+			// The commit wait timer starts once the finalization is handled.
+			gtest.Sleep(gtest.ScaleMs(10))
+		}
+		_ = gtest.ReceiveSoon(t, ercCh)
+	}
+
+	// Heights 1 and 2 complete the check.
+	// Before the fix, the engine stopped as soon as it tried to prevote.
+	ph := propose(1)
+	prevote(ph)
+	precommitAndCommit(ph)
+	ph = propose(2)
+	prevote(ph)
+	require.NoError(t, check.Err())
+	precommitAndCommit(ph)
+
+	_, _, step := wm.Last()
+	require.Equal(t, gprivval.InvalidStep, step, "signed during the double sign check")
+
+	// At height 3, the engine participates again.
+	ph = propose(3)
+	awaitOwnVote(ph, false)
+	prevote(ph)
+	awaitOwnVote(ph, true)
+
+	lh, lr, step := wm.Last()
+	require.Equal(t, uint64(3), lh)
+	require.Zero(t, lr)
+	require.Equal(t, gprivval.PrecommitStep, step)
+}
+
+func TestGuardedSigner_doubleSignCheck_detected(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	inner := newLocalSigner(1)
+	wm, err := gprivval.OpenWatermark("")
+	require.NoError(t, err)
+
+	check := gprivval.NewDoubleSignCheck(gtest.NewLogger(t), gprivval.DoubleSignCheckConfig{
+		PubKey:          inner.PubKey(),
+		SignatureScheme: tmconsensustest.SimpleSignatureScheme{},
+		Watermark:       wm,
+		Heights:         2,
+	})
+	g := gprivval.NewGuardedSigner(gprivval.GuardedSignerConfig{
+		Signer:          inner,
+		SignatureScheme: tmconsensustest.SimpleSignatureScheme{},
+		Watermark:       wm,
+		Check:           check,
+	})
+	h := check.Handler(acceptAllHandler{})
+
+	// Another process is precommitting with our key.
+	vt := tmconsensus.VoteTarget{Height: 5, Round: 0, BlockHash: "block"}
+	_, sig, err := inner.Precommit(ctx, vt)
+	require.NoError(t, err)
+	h.HandlePrecommitProofs(ctx, tmconsensus.PrecommitSparseProof{
+		Height: vt.Height,
+		Round:  vt.Round,
+		Proofs: map[string][]gcrypto.SparseSignature{
+			vt.BlockHash: {{KeyID: []byte{0}, Sig: sig}},
+		},
+	})
+
+	var dde gprivval.DoubleSignDetectedError
+	require.ErrorAs(t, check.Err(), &dde)
+	require.Equal(t, uint64(5), dde.Height)
+	require.Equal(t, gprivval.PrecommitStep, dde.Step)
+
+	// Later heights no longer end the check.
+	vt.Height = 10
+	h.HandlePrevoteProofs(ctx, prevoteProof(t, newLocalSigner(2), vt))
+
+	_, _, err = g.Prevote(ctx, vt)
+	require.ErrorAs(t, err, &dde)
+}
+
+func TestGuardedSigner_doubleSignCheck_ignoresOwnPastMessages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "sign_state.json")
+	inner := newLocalSigner(1)
+
+	// Sign a prevote before the restart.
+	vt := tmconsensus.VoteTarget{Height: 5, Round: 0, BlockHash: "block"}
+	_, _, err := newGuardedSigner(t, inner, path, nil).Prevote(ctx, vt)
+	require.NoError(t, err)
+
+	wm, err := gprivval.OpenWatermark(path)
+	require.NoError(t, err)
+	check := gprivval.NewDoubleSignCheck(gtest.NewLogger(t), gprivval.DoubleSignCheckConfig{
+		PubKey:          inner.PubKey(),
+		SignatureScheme: tmconsensustest.SimpleSignatureScheme{},
+		Watermark:       wm,
+		Heights:         1,
+	})
+	h := check.Handler(acceptAllHandler{})
+
+	// Peers may still be gossiping our own prevote from before the restart.
+	h.HandlePrevoteProofs(ctx, prevoteProof(t, inner, vt))
+	require.ErrorIs(t, check.Err(), gprivval.ErrDoubleSignCheckPending)
+
+	vt.Height = 6
+	h.HandlePrevoteProofs(ctx, prevoteProof(t, newLocalSigner(2), vt))
+	require.NoError(t, check.Err())
+}
+
+func newLocalSigner(seed byte) tmconsensus.Signer {
+	return tmconsensus.PassthroughSigner{
+		Signer: gcrypto.NewEd25519Signer(
+			ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize)),
+		),
+		SignatureScheme: tmconsensustest.SimpleSignatureScheme{},
+	}
+}
+
+func newGuardedSigner(
+	t *testing.T, inner tmconsensus.Signer, path string, check *gprivval.DoubleSignCheck,
+) *gprivval.GuardedSigner {
+	t.Helper()
+
+	wm, err := gprivval.OpenWatermark(path)
+	require.NoError(t, err)
+
+	return gprivval.NewGuardedSigner(gprivval.GuardedSignerConfig{
+		Signer:          inner,
+		SignatureScheme: tmconsensustest.SimpleSignatureScheme{},
+		Watermark:       wm,
+		Check:           check,
+	})
+}
+
+func prevoteProof(t *testing.T, s tmconsensus.Signer, vt tmconsensus.VoteTarget) tmconsensus.PrevoteSparseProof {
+	t.Helper()
+
+	_, sig, err := s.Prevote(context.Background(), vt)
+	require.NoError(t, err)
+
+	return tmconsensus.PrevoteSparseProof{
+		Height: vt.Height,
+		Round:  vt.Round,
+		Proofs: map[string][]gcrypto.SparseSignature{
+			vt.BlockHash: {{KeyID: []byte{0}, Sig: sig}},
+		},
+	}
+}
+
+// acceptAllHandler stands in for the engine, accepting every message.
+type acceptAllHandler struct{}
+
+func (acceptAllHandler) HandleProposedHeader(context.Context, tmconsensus.ProposedHeader) gexchange.Feedback {
+	return gexchange.FeedbackAccepted
+}
+
+func (acceptAllHandler) HandlePrevoteProofs(context.Context, tmconsensus.PrevoteSparseProof) gexchange.Feedback {
+	return gexchange.FeedbackAccepted
+}
+
+func (acceptAllHandler) HandlePrecommitProofs(context.Context, tmconsensus.PrecommitSparseProof) gexchange.Feedback {
+	return gexchange.FeedbackAccepted
+}
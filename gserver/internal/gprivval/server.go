@@ -0,0 +1,277 @@
+package gprivval
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval/gprivvalpb"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gscheme"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+// Server is the signing side of the remote signer protocol.
+// It holds the private key and the [Watermark],
+// and it serves sign requests from connected validators.
+type Server struct {
+	log *slog.Logger
+
+	signer gcrypto.Signer
+	parser gscheme.SigningContentParser
+	wm     *Watermark
+
+	tlsConfig *tls.Config
+
+	encodedPubKey []byte
+
+	wg sync.WaitGroup
+}
+
+// ServerConfig is the configuration for [NewServer].
+type ServerConfig struct {
+	// The listener on which to accept validator connections.
+	// The server closes the listener when its context is canceled.
+	Listener net.Listener
+
+	// The signer holding the private key.
+	Signer gcrypto.Signer
+
+	// The parser for the sign bytes of the chain's signature scheme.
+	// The server reads the height, round, and step to check against the watermark
+	// from the sign bytes themselves,
+	// so that a validator cannot get conflicting content signed by misdeclaring them.
+	Parser gscheme.SigningContentParser
+
+	// The registry to encode the signer's public key for validators.
+	Registry *gcrypto.Registry
+
+	// The persistent record of the last signed message.
+	Watermark *Watermark
+
+	// The TLS configuration to authenticate validators connecting over TCP,
+	// typically from [ServerTLSConfig].
+	// Connections on a Unix socket are authenticated by the socket's file permissions instead,
+	// so the server refuses any connection that is neither on a Unix socket
+	// nor authenticated by a client certificate through this configuration.
+	TLSConfig *tls.Config
+}
+
+// NewServer starts serving validator connections in the background.
+// Cancel ctx to stop; use [*Server.Wait] to block until the background work finishes.
+func NewServer(ctx context.Context, log *slog.Logger, cfg ServerConfig) *Server {
+	s := &Server{
+		log: log,
+
+		signer: cfg.Signer,
+		parser: cfg.Parser,
+		wm:     cfg.Watermark,
+
+		tlsConfig: cfg.TLSConfig,
+
+		encodedPubKey: cfg.Registry.Marshal(cfg.Signer.PubKey()),
+	}
+
+	s.wg.Add(1)
+	go s.acceptLoop(ctx, cfg.Listener)
+
+	return s
+}
+
+// Wait blocks until all of s's background work is completed.
+func (s *Server) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Server) acceptLoop(ctx context.Context, ln net.Listener) {
+	defer s.wg.Done()
+
+	// Accept does not respect a context, so unblock it by closing the listener.
+	stop := context.AfterFunc(ctx, func() {
+		_ = ln.Close()
+	})
+	defer stop()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				s.log.Info("Stopping due to context cancellation", "cause", context.Cause(ctx))
+			} else {
+				s.log.Warn("Failed to accept connection; stopping", "err", err)
+			}
+			return
+		}
+
+		s.log.Info("Accepted validator connection", "remote_addr", conn.RemoteAddr())
+
+		s.wg.Add(1)
+		go s.serveConn(ctx, conn)
+	}
+}
+
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	log := s.log.With("remote_addr", conn.RemoteAddr())
+
+	// Closing conn on cancellation also unblocks the authenticated connection wrapping it.
+	ac, err := s.authenticate(ctx, conn)
+	if err != nil {
+		log.Warn("Refusing unauthenticated validator connection", "err", err)
+		return
+	}
+
+	r := bufio.NewReader(ac)
+	opts := protodelim.UnmarshalOptions{MaxSize: maxMessageSize}
+	for {
+		var req gprivvalpb.Request
+		if err := opts.UnmarshalFrom(r, &req); err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				log.Info("Validator connection closed")
+			} else {
+				log.Warn("Failed to read request; closing connection", "err", err)
+			}
+			return
+		}
+
+		resp := s.handleRequest(ctx, log, &req)
+		if _, err := protodelim.MarshalTo(ac, resp); err != nil {
+			log.Warn("Failed to write response; closing connection", "err", err)
+			return
+		}
+	}
+}
+
+// authenticate returns the connection to serve requests on,
+// or an error if the peer on conn could not be authenticated.
+// Nothing is read from conn until it is authenticated,
+// so an unauthenticated peer can neither get a signature nor advance the watermark.
+func (s *Server) authenticate(ctx context.Context, conn net.Conn) (net.Conn, error) {
+	if conn.LocalAddr().Network() == "unix" {
+		return conn, nil
+	}
+
+	if s.tlsConfig == nil {
+		return nil, errors.New("TCP connections require a TLS configuration with client authentication")
+	}
+
+	tc := tls.Server(conn, s.tlsConfig)
+
+	hsCtx, cancel := context.WithTimeout(ctx, handshakeTimeout)
+	defer cancel()
+	if err := tc.HandshakeContext(hsCtx); err != nil {
+		return nil, fmt.Errorf("TLS handshake failed: %w", err)
+	}
+
+	// The configuration may not have required a client certificate.
+	if len(tc.ConnectionState().PeerCertificates) == 0 {
+		return nil, errors.New("validator presented no client certificate")
+	}
+
+	return tc, nil
+}
+
+func (s *Server) handleRequest(
+	ctx context.Context, log *slog.Logger, req *gprivvalpb.Request,
+) *gprivvalpb.Response {
+	switch r := req.Request.(type) {
+	case *gprivvalpb.Request_PubKey:
+		return &gprivvalpb.Response{
+			Response: &gprivvalpb.Response_PubKey{
+				PubKey: &gprivvalpb.PubKeyResponse{EncodedPubKey: s.encodedPubKey},
+			},
+		}
+
+	case *gprivvalpb.Request_Sign:
+		sr := r.Sign
+		height, round, step, err := s.signedHRS(sr)
+		if err != nil {
+			log.Warn("Refused malformed sign request", "err", err)
+			return errorResponse(err.Error(), false)
+		}
+
+		sig, err := s.wm.Sign(height, round, step, sr.SignBytes, func(b []byte) ([]byte, error) {
+			return s.signer.Sign(ctx, b)
+		})
+		if err != nil {
+			var dse DoubleSignError
+			isDoubleSign := errors.As(err, &dse)
+			if isDoubleSign {
+				log.Error(
+					"Refused conflicting sign request",
+					"height", height, "round", round, "step", step,
+					"err", err,
+				)
+			} else {
+				log.Warn("Failed to sign", "err", err)
+			}
+			return errorResponse(err.Error(), isDoubleSign)
+		}
+
+		log.Debug("Signed", "height", height, "round", round, "step", step)
+		return &gprivvalpb.Response{
+			Response: &gprivvalpb.Response_Sign{
+				Sign: &gprivvalpb.SignResponse{Signature: sig},
+			},
+		}
+
+	default:
+		return errorResponse("unknown request type", false)
+	}
+}
+
+// signedHRS returns the height, round, and step of the message in sr's sign bytes,
+// or an error if the sign bytes cannot be parsed
+// or if they disagree with the height, round, and step declared in sr.
+func (s *Server) signedHRS(sr *gprivvalpb.SignRequest) (uint64, uint32, Step, error) {
+	m, err := s.parser.ParseSigningContent(sr.SignBytes)
+	if err != nil {
+		return 0, 0, InvalidStep, fmt.Errorf("failed to parse sign bytes: %w", err)
+	}
+
+	var step Step
+	switch m.Kind {
+	case gscheme.ProposalMessage:
+		step = ProposalStep
+	case gscheme.PrevoteMessage:
+		step = PrevoteStep
+	case gscheme.PrecommitMessage:
+		step = PrecommitStep
+	default:
+		panic(fmt.Errorf("BUG: unhandled signed message kind %d", m.Kind))
+	}
+
+	declStep := stepFromPB(sr.Step)
+	if m.Height != sr.Height || m.Round != sr.Round || step != declStep {
+		return 0, 0, InvalidStep, fmt.Errorf(
+			"sign bytes are for %d/%d/%s but the request declared %d/%d/%s",
+			m.Height, m.Round, step, sr.Height, sr.Round, declStep,
+		)
+	}
+
+	return m.Height, m.Round, step, nil
+}
+
+func errorResponse(desc string, doubleSign bool) *gprivvalpb.Response {
+	return &gprivvalpb.Response{
+		Response: &gprivvalpb.Response_Error{
+			Error: &gprivvalpb.ErrorResponse{
+				Description: desc,
+				DoubleSign:  doubleSign,
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package gprivval
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// handshakeTimeout bounds how long the server waits
+// for a TCP client to complete the TLS handshake.
+const handshakeTimeout = 10 * time.Second
+
+// ServerTLSConfig returns the TLS configuration for a [Server] listening on TCP.
+//
+// The server presents a self-signed certificate for key,
+// and it only completes the handshake with clients
+// presenting a certificate for one of the allowed public keys,
+// so an unauthenticated peer never gets to send a request.
+// Clients pin the server's public key in turn, with [ClientTLSConfig].
+func ServerTLSConfig(key ed25519.PrivateKey, allowedClients []ed25519.PublicKey) (*tls.Config, error) {
+	if len(allowedClients) == 0 {
+		return nil, errors.New("at least one allowed client key is required")
+	}
+
+	cert, err := selfSignedCert(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+
+		// There is no CA; the client certificate is checked against the pinned keys instead.
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: pinnedKeyVerifier(allowedClients),
+	}, nil
+}
+
+// ClientTLSConfig returns the TLS configuration for a [Client]
+// connecting to a signer over TCP.
+//
+// The client presents a self-signed certificate for key,
+// which must be one of the signer's allowed keys,
+// and it only completes the handshake with a signer presenting
+// a certificate for serverKey.
+func ClientTLSConfig(key ed25519.PrivateKey, serverKey ed25519.PublicKey) (*tls.Config, error) {
+	cert, err := selfSignedCert(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+
+		// There is no CA or meaningful server name to verify;
+		// the server certificate is checked against the pinned key instead.
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: pinnedKeyVerifier([]ed25519.PublicKey{serverKey}),
+	}, nil
+}
+
+// selfSignedCert returns a certificate for key, signed by key itself.
+// The certificate only carries the public key;
+// the TLS 1.3 handshake proves that the peer holds the corresponding private key.
+func selfSignedCert(key ed25519.PrivateKey) (tls.Certificate, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return tls.Certificate{}, fmt.Errorf("invalid ed25519 private key length %d", len(key))
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(100 * 365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create TLS certificate: %w", err)
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
+
+// pinnedKeyVerifier returns a [tls.Config.VerifyPeerCertificate] function
+// accepting only a peer certificate for one of keys.
+func pinnedKeyVerifier(keys []ed25519.PublicKey) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("peer presented no certificate")
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return fmt.Errorf("failed to parse peer certificate: %w", err)
+		}
+		pub, ok := cert.PublicKey.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("peer certificate has unsupported key type %T", cert.PublicKey)
+		}
+		for _, k := range keys {
+			if bytes.Equal(pub, k) {
+				return nil
+			}
+		}
+		return fmt.Errorf("peer key %x is not allowed", []byte(pub))
+	}
+}
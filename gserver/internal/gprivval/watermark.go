@@ -0,0 +1,254 @@
+package gprivval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gfile"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval/gprivvalpb"
+)
+
+// Step orders the messages a validator signs within a single round.
+type Step uint8
+
+const (
+	InvalidStep Step = iota
+
+	ProposalStep
+	PrevoteStep
+	PrecommitStep
+)
+
+func (s Step) String() string {
+	switch s {
+	case ProposalStep:
+		return "proposal"
+	case PrevoteStep:
+		return "prevote"
+	case PrecommitStep:
+		return "precommit"
+	default:
+		return fmt.Sprintf("Step(%d)", uint8(s))
+	}
+}
+
+func stepFromPB(s gprivvalpb.SignStep) Step {
+	switch s {
+	case gprivvalpb.SignStep_SIGN_STEP_PROPOSAL:
+		return ProposalStep
+	case gprivvalpb.SignStep_SIGN_STEP_PREVOTE:
+		return PrevoteStep
+	case gprivvalpb.SignStep_SIGN_STEP_PRECOMMIT:
+		return PrecommitStep
+	default:
+		return InvalidStep
+	}
+}
+
+func (s Step) pb() gprivvalpb.SignStep {
+	switch s {
+	case ProposalStep:
+		return gprivvalpb.SignStep_SIGN_STEP_PROPOSAL
+	case PrevoteStep:
+		return gprivvalpb.SignStep_SIGN_STEP_PREVOTE
+	case PrecommitStep:
+		return gprivvalpb.SignStep_SIGN_STEP_PRECOMMIT
+	default:
+		panic(fmt.Errorf("BUG: no protobuf value for %s", s))
+	}
+}
+
+// DoubleSignError is returned when a sign request conflicts
+// with the last message recorded in a [Watermark].
+type DoubleSignError struct {
+	// The height, round, and step of the last signed message.
+	LastHeight uint64
+	LastRound  uint32
+	LastStep   Step
+
+	// The height, round, and step of the refused request.
+	Height uint64
+	Round  uint32
+	Step   Step
+}
+
+func (e DoubleSignError) Error() string {
+	return fmt.Sprintf(
+		"refusing to sign %d/%d/%s: already signed %s at %d/%d/%s",
+		e.Height, e.Round, e.Step,
+		describeConflict(e), e.LastHeight, e.LastRound, e.LastStep,
+	)
+}
+
+func describeConflict(e DoubleSignError) string {
+	if e.Height == e.LastHeight && e.Round == e.LastRound && e.Step == e.LastStep {
+		return "different content"
+	}
+	return "a later message"
+}
+
+// signState is the persisted form of a [Watermark].
+type signState struct {
+	Height uint64
+	Round  uint32
+	Step   Step
+
+	SignBytes []byte
+	Signature []byte
+}
+
+// Watermark is the high-water mark of a signing key:
+// the height, round, and step of the last message signed,
+// along with the signed content and the signature.
+//
+// Watermark is safe for concurrent use.
+type Watermark struct {
+	path string
+
+	mu   sync.Mutex
+	last signState
+}
+
+// OpenWatermark loads the watermark persisted at path.
+// If no file exists at path, the watermark starts empty,
+// and the file is created upon the first signature.
+//
+// If path is blank, the watermark is only kept in memory,
+// which is only appropriate for tests.
+func OpenWatermark(path string) (*Watermark, error) {
+	w := &Watermark{path: path}
+	if path == "" {
+		return w, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return w, nil
+		}
+		return nil, fmt.Errorf("failed to read sign state file: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &w.last); err != nil {
+		return nil, fmt.Errorf("failed to parse sign state file %q: %w", path, err)
+	}
+	return w, nil
+}
+
+// Sign calls signFn to sign signBytes at the given height, round, and step,
+// if that does not conflict with the last signed message.
+// The new watermark is persisted before Sign returns the signature,
+// so that a crash immediately after signing cannot lose the record.
+//
+// Signing the same bytes at the same height, round, and step
+// returns the previous signature without calling signFn,
+// so that a validator may safely retry a request whose response it never received.
+//
+// If the request conflicts with the last signed message,
+// Sign returns a [DoubleSignError].
+func (w *Watermark) Sign(
+	height uint64, round uint32, step Step,
+	signBytes []byte,
+	signFn func([]byte) ([]byte, error),
+) ([]byte, error) {
+	if step == InvalidStep {
+		return nil, fmt.Errorf("invalid sign step %s", step)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch cmpHRS(height, round, step, w.last.Height, w.last.Round, w.last.Step) {
+	case -1:
+		return nil, w.doubleSignErrorLocked(height, round, step)
+	case 0:
+		if !bytes.Equal(signBytes, w.last.SignBytes) {
+			return nil, w.doubleSignErrorLocked(height, round, step)
+		}
+		return bytes.Clone(w.last.Signature), nil
+	}
+
+	sig, err := signFn(signBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	next := signState{
+		Height: height,
+		Round:  round,
+		Step:   step,
+
+		SignBytes: bytes.Clone(signBytes),
+		Signature: bytes.Clone(sig),
+	}
+	if err := w.saveLocked(next); err != nil {
+		// Without a durable record, we must not release the signature.
+		return nil, err
+	}
+	w.last = next
+
+	return sig, nil
+}
+
+// Last returns the height, round, and step of the last signed message.
+// The step is [InvalidStep] if nothing has been signed yet.
+func (w *Watermark) Last() (height uint64, round uint32, step Step) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.last.Height, w.last.Round, w.last.Step
+}
+
+func (w *Watermark) doubleSignErrorLocked(height uint64, round uint32, step Step) DoubleSignError {
+	return DoubleSignError{
+		LastHeight: w.last.Height,
+		LastRound:  w.last.Round,
+		LastStep:   w.last.Step,
+
+		Height: height,
+		Round:  round,
+		Step:   step,
+	}
+}
+
+func (w *Watermark) saveLocked(s signState) error {
+	if w.path == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sign state: %w", err)
+	}
+
+	// Losing this file could lead to a double sign,
+	// so the write must be durable, not only atomic.
+	if err := gfile.WriteAtomic(w.path, b); err != nil {
+		return fmt.Errorf("failed to save sign state: %w", err)
+	}
+	return nil
+}
+
+// cmpHRS compares two height, round, and step triples,
+// returning -1, 0, or 1 like [cmp.Compare].
+func cmpHRS(h1 uint64, r1 uint32, s1 Step, h2 uint64, r2 uint32, s2 Step) int {
+	switch {
+	case h1 < h2:
+		return -1
+	case h1 > h2:
+		return 1
+	case r1 < r2:
+		return -1
+	case r1 > r2:
+		return 1
+	case s1 < s2:
+		return -1
+	case s1 > s2:
+		return 1
+	default:
+		return 0
+	}
+}
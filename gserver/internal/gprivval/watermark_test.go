@@ -0,0 +1,96 @@
+package gprivval_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gprivval"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatermark_Sign(t *testing.T) {
+	t.Parallel()
+
+	w, err := gprivval.OpenWatermark("")
+	require.NoError(t, err)
+
+	var calls int
+	signFn := func(b []byte) ([]byte, error) {
+		calls++
+		return append([]byte("sig:"), b...), nil
+	}
+
+	sig, err := w.Sign(1, 0, gprivval.PrevoteStep, []byte("a"), signFn)
+	require.NoError(t, err)
+	require.Equal(t, []byte("sig:a"), sig)
+	require.Equal(t, 1, calls)
+
+	// Identical request returns the previous signature without signing again.
+	sig, err = w.Sign(1, 0, gprivval.PrevoteStep, []byte("a"), signFn)
+	require.NoError(t, err)
+	require.Equal(t, []byte("sig:a"), sig)
+	require.Equal(t, 1, calls)
+
+	// Different content at the same height, round, and step is refused.
+	_, err = w.Sign(1, 0, gprivval.PrevoteStep, []byte("b"), signFn)
+	var dse gprivval.DoubleSignError
+	require.ErrorAs(t, err, &dse)
+	require.Equal(t, 1, calls)
+
+	// Later steps are fine.
+	_, err = w.Sign(1, 0, gprivval.PrecommitStep, []byte("c"), signFn)
+	require.NoError(t, err)
+
+	// But earlier steps are refused, even with the earlier content.
+	_, err = w.Sign(1, 0, gprivval.PrevoteStep, []byte("a"), signFn)
+	require.ErrorAs(t, err, &dse)
+
+	// A proposal in the next round is after a precommit in this round.
+	_, err = w.Sign(1, 1, gprivval.ProposalStep, []byte("d"), signFn)
+	require.NoError(t, err)
+
+	h, r, s := w.Last()
+	require.Equal(t, uint64(1), h)
+	require.Equal(t, uint32(1), r)
+	require.Equal(t, gprivval.ProposalStep, s)
+}
+
+func TestWatermark_signError(t *testing.T) {
+	t.Parallel()
+
+	w, err := gprivval.OpenWatermark("")
+	require.NoError(t, err)
+
+	signErr := errors.New("sign failed")
+	_, err = w.Sign(1, 0, gprivval.PrevoteStep, []byte("a"), func([]byte) ([]byte, error) {
+		return nil, signErr
+	})
+	require.ErrorIs(t, err, signErr)
+
+	// The failed signature did not advance the watermark.
+	_, _, s := w.Last()
+	require.Equal(t, gprivval.InvalidStep, s)
+}
+
+func TestWatermark_persisted(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sign_state.json")
+	signFn := func(b []byte) ([]byte, error) { return b, nil }
+
+	w, err := gprivval.OpenWatermark(path)
+	require.NoError(t, err)
+	_, err = w.Sign(5, 2, gprivval.PrecommitStep, []byte("a"), signFn)
+	require.NoError(t, err)
+
+	// A new watermark on the same file remembers the last signature.
+	w, err = gprivval.OpenWatermark(path)
+	require.NoError(t, err)
+
+	_, err = w.Sign(5, 2, gprivval.PrevoteStep, []byte("b"), signFn)
+	require.ErrorAs(t, err, new(gprivval.DoubleSignError))
+
+	_, err = w.Sign(5, 2, gprivval.PrecommitStep, []byte("a"), signFn)
+	require.NoError(t, err)
+}
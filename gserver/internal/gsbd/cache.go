@@ -22,12 +22,16 @@ import (
 //  3. The mirror is performing catchup,
 //     and the block data may or may not be immediately available.
 //
-// The first downside of the RequestCache is that entries must be manually purged.
-// If multiple proposed blocks are received, the driver is designed to only purge
-// the block data for the block it finalizes.
-// This means the consensus strategy should purge the other blocks;
-// otherwise the other block data will never be garbage collected.
-// (Perhaps this implies this cache is a good candidate for a weak map in Go.)
+// Entries are not removed automatically.
+// Once the driver has finalized a height and saved its block data
+// to the [gcstore.BlockDataStore], it calls [*RequestCache.PurgeThroughHeight],
+// releasing the finalized block's data along with the data
+// for every other block proposed at that height or earlier,
+// such as the proposals from rounds that did not finalize.
+// Before that, when the consensus strategy enters a later round of a height,
+// it calls [*RequestCache.PurgeBelowRound] to release the data
+// for the proposals from the earlier rounds of the height,
+// as proposals in the new round have data IDs for the new round.
 //
 // There are some possible race conditions if a block data request
 // may be created from multiple originators, or if the same block data is present in different requests.
@@ -113,6 +117,57 @@ func (c *RequestCache) Purge(dataID string) {
 	delete(c.rs, dataID)
 }
 
+// PurgeThroughHeight removes every entry whose data ID
+// is for a block at or below the given height,
+// and returns the number of entries removed.
+//
+// Entries whose data ID cannot be parsed are removed too,
+// as no block can ever reference them.
+//
+// A request that is still in flight when it is purged
+// continues to completion, but its result is no longer reachable through the cache.
+func (c *RequestCache) PurgeThroughHeight(height uint64) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for dataID := range c.rs {
+		h, _, _, _, _, err := ParseDataID(dataID)
+		if err == nil && h > height {
+			continue
+		}
+
+		delete(c.rs, dataID)
+		n++
+	}
+	return n
+}
+
+// PurgeBelowRound removes every entry whose data ID
+// is for a block at the given height and at a round below the given round,
+// and returns the number of entries removed.
+// Entries for other heights, and entries whose data ID cannot be parsed,
+// are left for [*RequestCache.PurgeThroughHeight].
+//
+// If a block from an earlier round is finalized after its entry was purged,
+// the driver recovers its data the same way as for a block it never requested.
+func (c *RequestCache) PurgeBelowRound(height uint64, round uint32) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for dataID := range c.rs {
+		h, r, _, _, _, err := ParseDataID(dataID)
+		if err != nil || h != height || r >= round {
+			continue
+		}
+
+		delete(c.rs, dataID)
+		n++
+	}
+	return n
+}
+
 // Get returns the BlockDataRequest corresponding to dataID.
 // The ok return value follows the idiomatic "comma, ok" pattern in Go.
 func (c *RequestCache) Get(dataID string) (r *BlockDataRequest, ok bool) {
@@ -3,6 +3,7 @@ package gsbd_test
 import (
 	"testing"
 
+	"cosmossdk.io/core/transaction"
 	"github.com/gordian-engine/gcosmos/gserver/gservertest"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
@@ -35,3 +36,67 @@ func TestRequestCache_SetInFlight(t *testing.T) {
 	require.Equal(t, bdr.Transactions, got.Transactions)
 	require.Equal(t, bdr.EncodedTransactions, got.EncodedTransactions)
 }
+
+func TestRequestCache_PurgeThroughHeight(t *testing.T) {
+	t.Parallel()
+
+	c := gsbd.NewRequestCache()
+
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+
+	// Two rounds at height 1, one at height 2, and one at height 3.
+	id1r0 := gsbd.DataID(1, 0, 1, txs)
+	id1r1 := gsbd.DataID(1, 1, 1, txs)
+	id2 := gsbd.DataID(2, 0, 1, txs)
+	id3 := gsbd.DataID(3, 0, 1, txs)
+	for _, id := range []string{id1r0, id1r1, id2, id3} {
+		c.SetImmediatelyAvailable(id, txs, txs[0].Bytes())
+	}
+
+	require.Equal(t, 3, c.PurgeThroughHeight(2))
+
+	for _, id := range []string{id1r0, id1r1, id2} {
+		_, ok := c.Get(id)
+		require.False(t, ok, id)
+	}
+	_, ok := c.Get(id3)
+	require.True(t, ok)
+
+	// A purged data ID may be set again.
+	c.SetImmediatelyAvailable(id2, txs, txs[0].Bytes())
+
+	// Nothing left at or below height 1.
+	require.Zero(t, c.PurgeThroughHeight(1))
+}
+
+func TestRequestCache_PurgeBelowRound(t *testing.T) {
+	t.Parallel()
+
+	c := gsbd.NewRequestCache()
+
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+
+	// Three rounds at height 2, and the first round of heights 1 and 3.
+	id1 := gsbd.DataID(1, 0, 1, txs)
+	id2r0 := gsbd.DataID(2, 0, 1, txs)
+	id2r1 := gsbd.DataID(2, 1, 1, txs)
+	id2r2 := gsbd.DataID(2, 2, 1, txs)
+	id3 := gsbd.DataID(3, 0, 1, txs)
+	for _, id := range []string{id1, id2r0, id2r1, id2r2, id3} {
+		c.SetImmediatelyAvailable(id, txs, txs[0].Bytes())
+	}
+
+	require.Equal(t, 2, c.PurgeBelowRound(2, 2))
+
+	for _, id := range []string{id2r0, id2r1} {
+		_, ok := c.Get(id)
+		require.False(t, ok, id)
+	}
+	for _, id := range []string{id1, id2r2, id3} {
+		_, ok := c.Get(id)
+		require.True(t, ok, id)
+	}
+
+	// Nothing left below round 2 at height 2.
+	require.Zero(t, c.PurgeBelowRound(2, 2))
+}
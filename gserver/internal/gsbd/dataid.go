@@ -7,36 +7,116 @@ import (
 	"strings"
 
 	"cosmossdk.io/core/transaction"
+	"github.com/cometbft/cometbft/crypto/merkle"
 	"golang.org/x/crypto/blake2b"
 )
 
 const txsHashSize = 32
 
+// TxsHashScheme is the construction of the transactions hash in data IDs.
+//
+// The data ID identifies its scheme, so a [BlockDataDecoder] accepts data IDs of every scheme.
+// Like the [Format], the scheme is still chosen per chain, in genesis,
+// so that every validator produces identical data IDs for identical transactions.
+type TxsHashScheme uint8
+
+const (
+	// Blake2bTxsHash is the original scheme:
+	// the blake2b-256 hash of the concatenated transaction hashes.
+	Blake2bTxsHash TxsHashScheme = iota
+
+	// MerkleTxsHash is the root of the Merkle tree whose leaves are the transaction hashes.
+	//
+	// The tree is the RFC 6962 style SHA-256 tree used by Comet,
+	// so the root matches the data hash Comet would compute for the same transactions,
+	// and a [TxProof] can be verified as a Comet TxProof.
+	MerkleTxsHash
+)
+
+// Transactions hash scheme names, for selecting a scheme in configuration.
+const (
+	Blake2bTxsHashName = "blake2b"
+	MerkleTxsHashName  = "merkle-sha256"
+)
+
+// merkleTxsHashPrefix precedes the transactions hash in data IDs with the [MerkleTxsHash] scheme.
+// It is not a hex digit, so it cannot be mistaken for part of a [Blake2bTxsHash] hash.
+const merkleTxsHashPrefix = "m"
+
+// TxsHashSchemeByName returns the TxsHashScheme with the given name.
+func TxsHashSchemeByName(name string) (TxsHashScheme, error) {
+	switch name {
+	case Blake2bTxsHashName:
+		return Blake2bTxsHash, nil
+	case MerkleTxsHashName:
+		return MerkleTxsHash, nil
+	default:
+		return 0, fmt.Errorf(
+			"unknown txs hash scheme %q (known schemes: %q, %q)",
+			name, Blake2bTxsHashName, MerkleTxsHashName,
+		)
+	}
+}
+
+// String returns the name of s.
+func (s TxsHashScheme) String() string {
+	switch s {
+	case Blake2bTxsHash:
+		return Blake2bTxsHashName
+	case MerkleTxsHash:
+		return MerkleTxsHashName
+	default:
+		return fmt.Sprintf("TxsHashScheme(%d)", uint8(s))
+	}
+}
+
 // We need the zero hash for the special, but probably common,
 // case of zero transactions.
 //go:generate go run ./dataid_generate.go
 
-// TxsHash returns the accumulated hash resulting from
-// the hash of the concatenated transaction hashes.
+// TxsHash returns the transactions hash of txs with the scheme s.
 // This is the last segment of the data ID.
-func TxsHash(txs []transaction.Tx) [txsHashSize]byte {
-	hasher, err := blake2b.New(txsHashSize, nil)
-	if err != nil {
-		panic(fmt.Errorf("impossible: blake2b.New failed: %w", err))
-	}
+func (s TxsHashScheme) TxsHash(txs []transaction.Tx) [txsHashSize]byte {
+	out := [txsHashSize]byte{}
 
-	for _, tx := range txs {
-		hash := tx.Hash()
-		_, _ = hasher.Write(hash[:])
+	switch s {
+	case Blake2bTxsHash:
+		hasher, err := blake2b.New(txsHashSize, nil)
+		if err != nil {
+			panic(fmt.Errorf("impossible: blake2b.New failed: %w", err))
+		}
+
+		for _, tx := range txs {
+			hash := tx.Hash()
+			_, _ = hasher.Write(hash[:])
+		}
+
+		_ = hasher.Sum(out[:0])
+	case MerkleTxsHash:
+		_ = copy(out[:], merkle.HashFromByteSlices(txHashes(txs)))
+	default:
+		panic(fmt.Errorf("BUG: unknown txs hash scheme %d", uint8(s)))
 	}
 
-	txsHash := make([]byte, 0, txsHashSize)
-	txsHash = hasher.Sum(txsHash)
-	out := [txsHashSize]byte{}
-	_ = copy(out[:], txsHash)
 	return out
 }
 
+// TxsHash returns the transactions hash of txs with the default [Blake2bTxsHash] scheme.
+func TxsHash(txs []transaction.Tx) [txsHashSize]byte {
+	return Blake2bTxsHash.TxsHash(txs)
+}
+
+// txHashes returns the hash of every transaction in txs,
+// which are the leaves of the Merkle tree of the [MerkleTxsHash] scheme.
+func txHashes(txs []transaction.Tx) [][]byte {
+	hashes := make([][]byte, len(txs))
+	for i, tx := range txs {
+		hash := tx.Hash()
+		hashes[i] = hash[:]
+	}
+	return hashes
+}
+
 // DataID returns a string formatted as:
 //
 //	HEIGHT:ROUND:NUM_TXs:DATA_LEN:HASH(TXs)
@@ -52,9 +132,10 @@ func TxsHash(txs []transaction.Tx) [txsHashSize]byte {
 // The DATA_LEN is an indicator to the receiver of the buffer size required
 // to hold the data to be decoded into transactions.
 //
-// HASH(TXs) is the blake2b hash of the concatenation
-// of every transaction' hash in txs, formatted as lowercase hex-encoded bytes.
-func DataID(
+// HASH(TXs) is the transactions hash of txs with the scheme s, as returned by [TxsHashScheme.TxsHash],
+// formatted as lowercase hex-encoded bytes.
+// With the [MerkleTxsHash] scheme, the hash is preceded by the letter m.
+func (s TxsHashScheme) DataID(
 	height uint64,
 	round uint32,
 	dataLen uint32,
@@ -65,12 +146,32 @@ func DataID(
 			panic(fmt.Errorf("BUG: got dataLen=%d with 0 transactions", dataLen))
 		}
 
+		if s == MerkleTxsHash {
+			return fmt.Sprintf("%d:%d%s", height, round, merkleZeroHashSuffix)
+		}
 		return fmt.Sprintf("%d:%d%s", height, round, zeroHashSuffix)
 	}
 
-	return fmt.Sprintf("%d:%d:%d:%x:%x", height, round, len(txs), dataLen, TxsHash(txs))
+	if s == MerkleTxsHash {
+		return fmt.Sprintf(
+			"%d:%d:%d:%x:%s%x", height, round, len(txs), dataLen, merkleTxsHashPrefix, s.TxsHash(txs),
+		)
+	}
+	return fmt.Sprintf("%d:%d:%d:%x:%x", height, round, len(txs), dataLen, s.TxsHash(txs))
 }
 
+// DataID returns the data ID of txs with the default [Blake2bTxsHash] scheme,
+// as described in [TxsHashScheme.DataID].
+func DataID(
+	height uint64,
+	round uint32,
+	dataLen uint32,
+	txs []transaction.Tx,
+) string {
+	return Blake2bTxsHash.DataID(height, round, dataLen, txs)
+}
+
+// ParseDataID parses the fields of a data ID of any [TxsHashScheme].
 func ParseDataID(id string) (
 	height uint64, round uint32,
 	nTxs int,
@@ -78,7 +179,33 @@ func ParseDataID(id string) (
 	txsHash [txsHashSize]byte,
 	err error,
 ) {
-	if hr, ok := strings.CutSuffix(id, zeroHashSuffix); ok {
+	height, round, nTxs, dataLen, txsHash, _, err = parseDataID(id)
+	return
+}
+
+// DataIDTxsHashScheme returns the scheme of the transactions hash in the data ID.
+func DataIDTxsHashScheme(id string) (TxsHashScheme, error) {
+	_, _, _, _, _, s, err := parseDataID(id)
+	return s, err
+}
+
+// parseDataID is [ParseDataID], also returning the scheme of the transactions hash.
+func parseDataID(id string) (
+	height uint64, round uint32,
+	nTxs int,
+	dataLen uint32,
+	txsHash [txsHashSize]byte,
+	scheme TxsHashScheme,
+	err error,
+) {
+	hr, ok := strings.CutSuffix(id, zeroHashSuffix)
+	if !ok {
+		hr, ok = strings.CutSuffix(id, merkleZeroHashSuffix)
+		if ok {
+			scheme = MerkleTxsHash
+		}
+	}
+	if ok {
 		parts := strings.SplitN(hr, ":", 3) // Yes, 3, not 2.
 		if len(parts) != 2 {
 			// Should be left with only height and round.
@@ -104,7 +231,11 @@ func ParseDataID(id string) (
 
 		// nTxs and dataLen are already initialized to zero, don't need to reassign.
 
-		copy(txsHash[:], zeroHash)
+		if scheme == MerkleTxsHash {
+			copy(txsHash[:], merkleZeroHash)
+		} else {
+			copy(txsHash[:], zeroHash)
+		}
 
 		return
 	}
@@ -145,12 +276,18 @@ func ParseDataID(id string) (
 	}
 	dataLen = uint32(u64)
 
-	if wantLen := hex.EncodedLen(txsHashSize); len(parts[4]) != wantLen {
+	hashPart := parts[4]
+	if h, ok := strings.CutPrefix(hashPart, merkleTxsHashPrefix); ok {
+		hashPart = h
+		scheme = MerkleTxsHash
+	}
+
+	if wantLen := hex.EncodedLen(txsHashSize); len(hashPart) != wantLen {
 		// We could check this earlier to avoid work in parsing the numbers.
-		err = fmt.Errorf("wrong length for txs hash; want %d, got %d", wantLen, len(parts[4]))
+		err = fmt.Errorf("wrong length for txs hash; want %d, got %d", wantLen, len(hashPart))
 		return
 	}
-	_, err = hex.AppendDecode(txsHash[:0], []byte(parts[4]))
+	_, err = hex.AppendDecode(txsHash[:0], []byte(hashPart))
 	if err != nil {
 		err = fmt.Errorf("failed to decode txs hash: %w", err)
 		return
@@ -161,5 +298,5 @@ func ParseDataID(id string) (
 }
 
 func IsZeroTxDataID(id string) bool {
-	return strings.HasSuffix(id, zeroHashSuffix)
+	return strings.HasSuffix(id, zeroHashSuffix) || strings.HasSuffix(id, merkleZeroHashSuffix)
 }
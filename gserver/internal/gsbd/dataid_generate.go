@@ -1,6 +1,6 @@
 //go:build ignore
 
-// Generate a constant for the zero hash.
+// Generate constants for the zero hashes.
 // We could have copied these values as literal declarations,
 // but I would argue this is a bit more provable and typo-proof.
 // Furthermore, doing this in a go:generate step gives us the provability
@@ -10,6 +10,7 @@
 package main
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"os"
 
@@ -29,16 +30,26 @@ func main() {
 	zeroHash := make([]byte, 0, txsHashSize)
 	zeroHash = hasher.Sum(zeroHash)
 
+	// The root of a Merkle tree with no leaves is the SHA-256 hash of no input.
+	merkleZeroHash := sha256.Sum256(nil)
+
 	program := `package gsbd
 
 // Code generated by 'go run dataid_generate.go'; DO NOT EDIT.
 
-// The hash of no input, indicating that no transactions were included.
+// The blake2b hash of no input, indicating that no transactions were included.
 const zeroHash = "` + hex.EncodeToString(zeroHash) + `"
 
 // The suffix of the app data ID including nTxs=0,
 // data_len=0, and the corresponding hash.
 const zeroHashSuffix = ":0:0:" + zeroHash
+
+// The Merkle root of no transactions, indicating that no transactions were included.
+const merkleZeroHash = "` + hex.EncodeToString(merkleZeroHash[:]) + `"
+
+// The suffix of the app data ID including nTxs=0,
+// data_len=0, and the corresponding Merkle root.
+const merkleZeroHashSuffix = ":0:0:m" + merkleZeroHash
 `
 
 	if err := os.WriteFile("dataid_zero.go", []byte(program), 0o644); err != nil {
@@ -0,0 +1,98 @@
+package gsbd_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxsHashScheme_DataID(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []gsbd.TxsHashScheme{gsbd.Blake2bTxsHash, gsbd.MerkleTxsHash} {
+		t.Run(s.String(), func(t *testing.T) {
+			t.Parallel()
+
+			byName, err := gsbd.TxsHashSchemeByName(s.String())
+			require.NoError(t, err)
+			require.Equal(t, s, byName)
+
+			txs := []transaction.Tx{
+				gservertest.NewHashOnlyTransaction(1),
+				gservertest.NewHashOnlyTransaction(2),
+			}
+
+			var buf bytes.Buffer
+			sz, err := gsbd.EncodeBlockData(&buf, txs)
+			require.NoError(t, err)
+
+			dataID := s.DataID(5, 1, uint32(sz), txs)
+			require.False(t, gsbd.IsZeroTxDataID(dataID))
+
+			h, r, nTxs, dataLen, txsHash, err := gsbd.ParseDataID(dataID)
+			require.NoError(t, err)
+			require.Equal(t, uint64(5), h)
+			require.Equal(t, uint32(1), r)
+			require.Equal(t, 2, nTxs)
+			require.Equal(t, uint32(sz), dataLen)
+			require.Equal(t, s.TxsHash(txs), txsHash)
+
+			gotScheme, err := gsbd.DataIDTxsHashScheme(dataID)
+			require.NoError(t, err)
+			require.Equal(t, s, gotScheme)
+
+			// Decoders accept either scheme.
+			dec, err := gsbd.NewBlockDataDecoder(dataID, gservertest.HashOnlyTransactionDecoder{})
+			require.NoError(t, err)
+			gotTxs, err := dec.Decode(&buf)
+			require.NoError(t, err)
+			require.Equal(t, txs, gotTxs)
+
+			zeroID := s.DataID(5, 1, 0, nil)
+			require.True(t, gsbd.IsZeroTxDataID(zeroID))
+			gotScheme, err = gsbd.DataIDTxsHashScheme(zeroID)
+			require.NoError(t, err)
+			require.Equal(t, s, gotScheme)
+		})
+	}
+}
+
+func TestTxsHashScheme_defaultBlake2b(t *testing.T) {
+	t.Parallel()
+
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+	require.Equal(t, gsbd.Blake2bTxsHash.DataID(1, 0, 10, txs), gsbd.DataID(1, 0, 10, txs))
+	require.NotEqual(t, gsbd.Blake2bTxsHash.TxsHash(txs), gsbd.MerkleTxsHash.TxsHash(txs))
+
+	var zero gsbd.TxsHashScheme
+	require.Equal(t, gsbd.Blake2bTxsHash, zero)
+}
+
+func TestBlockDataDecoder_wrongTxsHashScheme(t *testing.T) {
+	t.Parallel()
+
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+
+	// A Merkle data ID whose hash is actually the blake2b hash must not decode.
+	blake2bHash := gsbd.Blake2bTxsHash.TxsHash(txs)
+	merkleID := gsbd.MerkleTxsHash.DataID(1, 0, uint32(sz), txs)
+	_, _, _, _, merkleHash, err := gsbd.ParseDataID(merkleID)
+	require.NoError(t, err)
+	forged := strings.Replace(merkleID, fmt.Sprintf("%x", merkleHash), fmt.Sprintf("%x", blake2bHash), 1)
+	require.NotEqual(t, merkleID, forged)
+
+	dec, err := gsbd.NewBlockDataDecoder(forged, gservertest.HashOnlyTransactionDecoder{})
+	require.NoError(t, err)
+	_, err = dec.Decode(&buf)
+	require.Error(t, err)
+}
@@ -2,9 +2,16 @@ package gsbd
 
 // Code generated by 'go run dataid_generate.go'; DO NOT EDIT.
 
-// The hash of no input, indicating that no transactions were included.
+// The blake2b hash of no input, indicating that no transactions were included.
 const zeroHash = "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"
 
 // The suffix of the app data ID including nTxs=0,
 // data_len=0, and the corresponding hash.
 const zeroHashSuffix = ":0:0:" + zeroHash
+
+// The Merkle root of no transactions, indicating that no transactions were included.
+const merkleZeroHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// The suffix of the app data ID including nTxs=0,
+// data_len=0, and the corresponding Merkle root.
+const merkleZeroHashSuffix = ":0:0:m" + merkleZeroHash
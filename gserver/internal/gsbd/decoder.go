@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -12,15 +13,26 @@ import (
 	"github.com/golang/snappy"
 )
 
+// ErrTxsHashMismatch is returned from [*BlockDataDecoder.Decode]
+// when the block data was read in full,
+// but its transactions do not match the hash in the data ID.
+// Unlike other decoding errors, such as a truncated read,
+// this proves that whoever served the data served the wrong data.
+var ErrTxsHashMismatch = errors.New("decoded transactions do not match data ID")
+
 // BlockDataDecoder parses a "framed" set of block data.
-// The framing format is a one-byte compression-type header,
+// The framing format is a one-byte header indicating the transaction [Format] and the compression type,
 // followed by possibly more header bytes depending on the compression format.
+// Block data in any format is accepted.
 type BlockDataDecoder struct {
-	nTxs    int
-	dataLen int
-	txsHash [txsHashSize]byte
+	nTxs          int
+	dataLen       int
+	txsHash       [txsHashSize]byte
+	txsHashScheme TxsHashScheme
 
 	txDecoder transaction.Codec[transaction.Tx]
+
+	zstd *ZstdCodec
 }
 
 // NewBlockDataDecoder returns a new BlockDataDecoder.
@@ -37,20 +49,27 @@ func NewBlockDataDecoder(
 	// We don't need the height or round,
 	// so we could potentially justify a lighter weight parser...
 	// but this isn't really in a hot path, so it's probably fine.
-	_, _, nTxs, dataLen, txsHash, err := ParseDataID(dataID)
+	_, _, nTxs, dataLen, txsHash, txsHashScheme, err := parseDataID(dataID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse data ID: %w", err)
 	}
 
 	return &BlockDataDecoder{
-		nTxs:    nTxs,
-		dataLen: int(dataLen),
-		txsHash: txsHash,
+		nTxs:          nTxs,
+		dataLen:       int(dataLen),
+		txsHash:       txsHash,
+		txsHashScheme: txsHashScheme,
 
 		txDecoder: txDecoder,
 	}, nil
 }
 
+// SetZstdCodec sets the codec for decoding zstd-compressed block data.
+// Without a codec, zstd-compressed block data is rejected.
+func (d *BlockDataDecoder) SetZstdCodec(zc *ZstdCodec) {
+	d.zstd = zc
+}
+
 // Decode parses the encoded block data in r
 // and returns the resulting transaction slice.
 func (d *BlockDataDecoder) Decode(r io.Reader) ([]transaction.Tx, error) {
@@ -64,23 +83,42 @@ func (d *BlockDataDecoder) Decode(r io.Reader) ([]transaction.Tx, error) {
 	// First apply any decompression as necessary,
 	// storing in the "encoded" slice.
 	var encoded []byte
+	var format Format
 	switch firstByte[0] {
 	case uncompressedHeader:
 		encoded, err = d.decodeUncompressed(r)
-		if err != nil {
-			return nil, err
-		}
 	case snappyHeader:
 		encoded, err = d.decodeSnappy(r)
-		if err != nil {
-			return nil, err
-		}
+	case uncompressedBinaryHeader:
+		format = BinaryFormat
+		encoded, err = d.decodeUncompressed(r)
+	case snappyBinaryHeader:
+		format = BinaryFormat
+		encoded, err = d.decodeSnappy(r)
+	case zstdHeader:
+		encoded, err = d.decodeZstd(r)
+	case zstdBinaryHeader:
+		format = BinaryFormat
+		encoded, err = d.decodeZstd(r)
 	default:
 		return nil, fmt.Errorf("unrecognized header byte %x", firstByte[0])
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	// Now we can decode the raw bytes.
-	return d.decodeRaw(encoded)
+	var items [][]byte
+	switch format {
+	case JSONFormat:
+		items, err = d.splitJSON(encoded)
+	case BinaryFormat:
+		items, err = d.splitBinary(encoded)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d.decodeTxs(items)
 }
 
 func (d *BlockDataDecoder) decodeUncompressed(r io.Reader) ([]byte, error) {
@@ -123,8 +161,63 @@ func (d *BlockDataDecoder) decodeUncompressed(r io.Reader) ([]byte, error) {
 }
 
 func (d *BlockDataDecoder) decodeSnappy(r io.Reader) ([]byte, error) {
-	// In our snappy encoded block data,
-	// the first value is the compressed size as a uvarint.
+	cBuf, err := d.readCompressed(r, snappy.MaxEncodedLen(d.dataLen), "snappy")
+	if err != nil {
+		return nil, err
+	}
+
+	// Now, we finally have the full compressed data.
+	// Before allocating the slice for the uncompressed version,
+	// double check that the uncompressed size will match.
+	uSize, err := snappy.DecodedLen(cBuf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read decoded length from compressed data: %w", err)
+	}
+	if uSize != d.dataLen {
+		return nil, fmt.Errorf(
+			"compressed data's decoded length %d differed from expected size %d ||| %x",
+			uSize, d.dataLen, cBuf,
+		)
+	}
+
+	// Decoding into nil will right-size the output buffer anyway.
+	// This could potentially use a sync.Pool to reuse allocations,
+	// but right now we don't have sufficient information to justify that.
+	u, err := snappy.Decode(nil, cBuf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode snappy data: %w", err)
+	}
+
+	if len(u) != d.dataLen {
+		return nil, fmt.Errorf(
+			"impossible: decoded length %d differed from expected length %d",
+			len(u), d.dataLen,
+		)
+	}
+
+	return u, nil
+}
+
+func (d *BlockDataDecoder) decodeZstd(r io.Reader) ([]byte, error) {
+	if d.zstd == nil {
+		return nil, errors.New("got zstd-compressed block data without a zstd codec")
+	}
+
+	// We only write zstd data when it is smaller than the uncompressed data.
+	cBuf, err := d.readCompressed(r, d.dataLen-1, "zstd")
+	if err != nil {
+		return nil, err
+	}
+
+	return d.zstd.decompress(cBuf, d.dataLen)
+}
+
+// readCompressed reads the size-prefixed compressed data from r,
+// rejecting sizes larger than maxCSize.
+// The algo argument is only used in error messages.
+func (d *BlockDataDecoder) readCompressed(r io.Reader, maxCSize int, algo string) ([]byte, error) {
+	// In our compressed block data,
+	// the first value is the compressed size as a varint.
 	//
 	// To read that varint, we need an io.ByteReader.
 	// The Stream type does not directly implement ByteReader,
@@ -145,11 +238,10 @@ func (d *BlockDataDecoder) decodeSnappy(r io.Reader) ([]byte, error) {
 		)
 	}
 
-	maxCSize := snappy.MaxEncodedLen(d.dataLen)
 	if cSize > maxCSize {
 		return nil, fmt.Errorf(
-			"invalid compressed size: %d larger than max snappy-encoded size %d of %d",
-			cSize, maxCSize, d.dataLen,
+			"invalid compressed size: %d larger than max %s-encoded size %d of %d",
+			cSize, algo, maxCSize, d.dataLen,
 		)
 	}
 
@@ -168,40 +260,12 @@ func (d *BlockDataDecoder) decodeSnappy(r io.Reader) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read full compressed data: %w", err)
 	}
 
-	// Now, we finally have the full compressed data.
-	// Before allocating the slice for the uncompressed version,
-	// double check that the uncompressed size will match.
-	uSize, err := snappy.DecodedLen(cBuf)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read decoded length from compressed data: %w", err)
-	}
-	if uSize != d.dataLen {
-		return nil, fmt.Errorf(
-			"compressed data's decoded length %d differed from expected size %d ||| %x",
-			uSize, d.dataLen, cBuf,
-		)
-	}
-
-	// Decoding into nil will right-size the output buffer anyway.
-	// This could potentially use a sync.Pool to reuse allocations,
-	// but right now we don't have sufficient information to justify that.
-	u, err := snappy.Decode(nil, cBuf)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode snappy data: %w", err)
-	}
-
-	if len(u) != d.dataLen {
-		return nil, fmt.Errorf(
-			"impossible: decoded length %d differed from expected length %d",
-			len(u), d.dataLen,
-		)
-	}
-
-	return u, nil
+	return cBuf, nil
 }
 
-func (d *BlockDataDecoder) decodeRaw(encoded []byte) ([]transaction.Tx, error) {
-	// The encoded data is currently a JSON array of byte slices.
+// splitJSON parses the individual transaction bytes from data in the [JSONFormat].
+func (d *BlockDataDecoder) splitJSON(encoded []byte) ([][]byte, error) {
+	// The encoded data is a JSON array of byte slices.
 	// Not efficient, but simple to use.
 	var items [][]byte
 	if err := json.Unmarshal(encoded, &items); err != nil {
@@ -213,7 +277,52 @@ func (d *BlockDataDecoder) decodeRaw(encoded []byte) ([]transaction.Tx, error) {
 			d.nTxs, len(items),
 		)
 	}
+	return items, nil
+}
+
+// splitBinary parses the individual transaction bytes from data in the [BinaryFormat].
+// The returned slices alias encoded.
+func (d *BlockDataDecoder) splitBinary(encoded []byte) ([][]byte, error) {
+	n, sz := binary.Uvarint(encoded)
+	if sz <= 0 {
+		return nil, fmt.Errorf("failed to read transaction count")
+	}
+	if n != uint64(d.nTxs) {
+		return nil, fmt.Errorf(
+			"incorrect number of encoded transactions: want %d, got %d",
+			d.nTxs, n,
+		)
+	}
+	encoded = encoded[sz:]
+
+	items := make([][]byte, d.nTxs)
+	for i := range items {
+		txLen, sz := binary.Uvarint(encoded)
+		if sz <= 0 {
+			return nil, fmt.Errorf("failed to read length of transaction at index %d", i)
+		}
+		encoded = encoded[sz:]
+
+		if txLen > uint64(len(encoded)) {
+			return nil, fmt.Errorf(
+				"length %d of transaction at index %d exceeds remaining data size %d",
+				txLen, i, len(encoded),
+			)
+		}
+		items[i] = encoded[:txLen:txLen]
+		encoded = encoded[txLen:]
+	}
+
+	if len(encoded) != 0 {
+		return nil, fmt.Errorf("%d unexpected bytes after last transaction", len(encoded))
+	}
+
+	return items, nil
+}
 
+// decodeTxs decodes each of the transaction bytes in items,
+// and confirms that the transactions match the data ID.
+func (d *BlockDataDecoder) decodeTxs(items [][]byte) ([]transaction.Tx, error) {
 	txs := make([]transaction.Tx, len(items))
 	for i := range items {
 		tx, err := d.txDecoder.Decode(items[i])
@@ -233,11 +342,11 @@ func (d *BlockDataDecoder) decodeRaw(encoded []byte) ([]transaction.Tx, error) {
 	// we could potentially accumulate the individual transaction hashes
 	// while decoding the transactions.
 	// But, doing it late is probably the better defensive choice.
-	gotTxsHash := TxsHash(txs)
+	gotTxsHash := d.txsHashScheme.TxsHash(txs)
 	if gotTxsHash != d.txsHash {
 		return nil, fmt.Errorf(
-			"decoded transactions hash %x differed from input %x",
-			gotTxsHash, d.txsHash,
+			"%w: decoded transactions hash %x differed from input %x",
+			ErrTxsHashMismatch, gotTxsHash, d.txsHash,
 		)
 	}
 
@@ -2,6 +2,7 @@ package gsbd_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"testing"
 
 	"cosmossdk.io/core/transaction"
@@ -62,3 +63,92 @@ func TestBlockDataDecoder_compressed(t *testing.T) {
 	require.Len(t, gotTxs, 10)
 	require.Equal(t, txs, gotTxs)
 }
+
+func TestBlockDataDecoder_binary(t *testing.T) {
+	t.Parallel()
+
+	for _, nTxs := range []int{1, 10} {
+		txs := make([]transaction.Tx, nTxs)
+		for i := range txs {
+			txs[i] = gservertest.NewHashOnlyTransaction(uint64(i))
+		}
+
+		var buf bytes.Buffer
+		sz, err := gsbd.EncodeBlockDataWithFormat(&buf, gsbd.BinaryFormat, txs)
+		require.NoError(t, err)
+
+		dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+		dec, err := gsbd.NewBlockDataDecoder(dataID, gservertest.HashOnlyTransactionDecoder{})
+		require.NoError(t, err)
+
+		gotTxs, err := dec.Decode(&buf)
+		require.NoError(t, err)
+		require.Equal(t, txs, gotTxs)
+	}
+}
+
+func TestBlockDataDecoder_binary_malformed(t *testing.T) {
+	t.Parallel()
+
+	tx := gservertest.NewHashOnlyTransaction(1)
+	txs := []transaction.Tx{tx}
+	txBytes := tx.Bytes()
+
+	for name, raw := range map[string][]byte{
+		"wrong count":     append([]byte{2, byte(len(txBytes))}, txBytes...),
+		"length too long": append([]byte{1, byte(len(txBytes) + 1)}, txBytes...),
+		"trailing bytes":  append(append([]byte{1, byte(len(txBytes))}, txBytes...), 0),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Uncompressed binary header, then the size, then the raw data.
+			b := append([]byte{2}, binary.AppendVarint(nil, int64(len(raw)))...)
+			b = append(b, raw...)
+
+			dataID := gsbd.DataID(1, 0, uint32(len(raw)), txs)
+			dec, err := gsbd.NewBlockDataDecoder(dataID, gservertest.HashOnlyTransactionDecoder{})
+			require.NoError(t, err)
+
+			_, err = dec.Decode(bytes.NewReader(b))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestBlockDataDecoder_txsHashMismatch(t *testing.T) {
+	t.Parallel()
+
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+	otherTxs := []transaction.Tx{gservertest.NewHashOnlyTransaction(2)}
+
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+	b := buf.Bytes()
+
+	t.Run("different transactions", func(t *testing.T) {
+		t.Parallel()
+
+		// Same size, but the data ID is for other transactions.
+		dataID := gsbd.DataID(1, 0, uint32(sz), otherTxs)
+		dec, err := gsbd.NewBlockDataDecoder(dataID, gservertest.HashOnlyTransactionDecoder{})
+		require.NoError(t, err)
+
+		_, err = dec.Decode(bytes.NewReader(b))
+		require.ErrorIs(t, err, gsbd.ErrTxsHashMismatch)
+	})
+
+	t.Run("truncated data", func(t *testing.T) {
+		t.Parallel()
+
+		// A truncated read is not evidence of wrong data.
+		dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+		dec, err := gsbd.NewBlockDataDecoder(dataID, gservertest.HashOnlyTransactionDecoder{})
+		require.NoError(t, err)
+
+		_, err = dec.Decode(bytes.NewReader(b[:len(b)-1]))
+		require.Error(t, err)
+		require.NotErrorIs(t, err, gsbd.ErrTxsHashMismatch)
+	})
+}
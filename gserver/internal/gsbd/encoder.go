@@ -11,54 +11,116 @@ import (
 	"github.com/golang/snappy"
 )
 
-// EncodeBlockData encodes a set of transactions as block data.
+// EncodeBlockData encodes a set of transactions as block data in the [JSONFormat].
+// See [EncodeBlockDataWithFormat] for details.
+func EncodeBlockData(w io.Writer, txs []transaction.Tx) (
+	decompressedDataSize int, err error,
+) {
+	return EncodeBlockDataWithFormat(w, JSONFormat, txs)
+}
+
+// EncodeBlockDataWithFormat encodes a set of transactions as block data.
 // The encoding format is as follows:
 //
-//  1. A header byte indicating the compression format,
+//  1. A header byte indicating the transaction format and the compression format,
 //     possibly indicating uncompressed.
 //  2. A varint indicating the length of the maybe-compressed data
 //     (see [binary.AppendVarint]).
-//  3. The maybe compressed data, which is currently inefficiently coded as
-//     a JSON array of base64 data (in Go, it is a [][]byte that is JSON-marshalled).
+//  3. The maybe compressed data, which is the transactions serialized in format f.
+//     The [JSONFormat] is an inefficiently coded JSON array of base64 data
+//     (in Go, it is a [][]byte that is JSON-marshalled);
+//     the [BinaryFormat] is a uvarint count of transactions,
+//     followed by each transaction's bytes prefixed with a uvarint length.
 //
 // The returned decodedDataSize is the size of the uncompressed data,
 // to be provided to the [DataID] function.
 //
-// EncodeBlockData panics when len(txs) == 0.
+// EncodeBlockDataWithFormat panics when len(txs) == 0.
 // Use [DataID] with arguments (height, round, 0, nil) directly
 // to get the data ID in that case.
-func EncodeBlockData(w io.Writer, txs []transaction.Tx) (
+func EncodeBlockDataWithFormat(w io.Writer, f Format, txs []transaction.Tx) (
+	decompressedDataSize int, err error,
+) {
+	return encodeBlockData(w, f, nil, txs)
+}
+
+// EncodeBlockDataWithZstd is like [EncodeBlockDataWithFormat],
+// but the data is compressed with zc instead of snappy.
+func EncodeBlockDataWithZstd(w io.Writer, f Format, zc *ZstdCodec, txs []transaction.Tx) (
+	decompressedDataSize int, err error,
+) {
+	if zc == nil {
+		panic("BUG: EncodeBlockDataWithZstd requires a non-nil ZstdCodec")
+	}
+	return encodeBlockData(w, f, zc, txs)
+}
+
+// encodeBlockData encodes txs in format f,
+// compressed with zc if it is set, or snappy otherwise.
+func encodeBlockData(w io.Writer, f Format, zc *ZstdCodec, txs []transaction.Tx) (
 	decompressedDataSize int, err error,
 ) {
 	if len(txs) == 0 {
 		panic("BUG: do not call EncodeBlockData with an empty set of transactions")
 	}
 
-	items := make([][]byte, len(txs))
-	for i, tx := range txs {
-		items[i] = tx.Bytes()
-	}
+	switch f {
+	case JSONFormat:
+		items := make([][]byte, len(txs))
+		for i, tx := range txs {
+			items[i] = tx.Bytes()
+		}
 
-	j, err := json.Marshal(items)
-	if err != nil {
-		return 0, fmt.Errorf("failed to marshal encoded transactions")
+		j, err := json.Marshal(items)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal encoded transactions")
+		}
+
+		if zc != nil {
+			return compressEncodedBlockData(w, j, uncompressedHeader, zstdHeader, zc.compress)
+		}
+		return compressEncodedBlockData(w, j, uncompressedHeader, snappyHeader, snappyCompress)
+
+	case BinaryFormat:
+		b := binary.AppendUvarint(nil, uint64(len(txs)))
+		for _, tx := range txs {
+			txBytes := tx.Bytes()
+			b = binary.AppendUvarint(b, uint64(len(txBytes)))
+			b = append(b, txBytes...)
+		}
+
+		if zc != nil {
+			return compressEncodedBlockData(w, b, uncompressedBinaryHeader, zstdBinaryHeader, zc.compress)
+		}
+		return compressEncodedBlockData(w, b, uncompressedBinaryHeader, snappyBinaryHeader, snappyCompress)
+
+	default:
+		panic(fmt.Errorf("BUG: unknown block data format %d", f))
 	}
+}
 
-	return compressEncodedBlockData(w, j)
+// snappyCompress returns the snappy-compressed form of b.
+func snappyCompress(b []byte) []byte {
+	return snappy.Encode(nil, b)
 }
 
-func compressEncodedBlockData(w io.Writer, j []byte) (int, error) {
+// compressEncodedBlockData writes the serialized transactions j to w,
+// compressed with compress if that saves any space,
+// prefixed with uHeader or cHeader to indicate the format and compression.
+func compressEncodedBlockData(
+	w io.Writer, j []byte, uHeader, cHeader byte, compress func([]byte) []byte,
+) (int, error) {
 	var header byte
 	var szBuf []byte
 	var data *bytes.Reader
 
-	if c := snappy.Encode(nil, j); len(c) < len(j) {
-		// We've saved some bytes, so use the snappy stream.
-		header = snappyHeader
+	if c := compress(j); len(c) < len(j) {
+		// We've saved some bytes, so use the compressed stream.
+		header = cHeader
 		szBuf = binary.AppendVarint(nil, int64(len(c)))
 		data = bytes.NewReader(c)
 	} else {
-		header = uncompressedHeader
+		header = uHeader
 		szBuf = binary.AppendVarint(nil, int64(len(j)))
 		data = bytes.NewReader(j)
 	}
@@ -66,3 +66,80 @@ func TestEncodeBlockData_panicsOnEmptyTxs(t *testing.T) {
 		_, _ = gsbd.EncodeBlockData(new(bytes.Buffer), nil)
 	})
 }
+
+func TestEncodeBlockDataWithFormat_binary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uncompressed", func(t *testing.T) {
+		t.Parallel()
+
+		var h [gservertest.HashSize]byte
+		for i := range h {
+			h[i] = byte(i) + 3
+		}
+		tx := gservertest.NewRawHashOnlyTransaction(h)
+
+		var buf bytes.Buffer
+		sz, err := gsbd.EncodeBlockDataWithFormat(&buf, gsbd.BinaryFormat, []transaction.Tx{tx})
+		require.NoError(t, err)
+
+		b := buf.Bytes()
+		require.Equal(t, byte(2), b[0])
+
+		rest, szLen := binary.Varint(b[1:])
+		require.Equal(t, 1+szLen+int(rest), len(b))
+
+		// One transaction, prefixed with its length.
+		want := append([]byte{1, byte(len(tx.Bytes()))}, tx.Bytes()...)
+		require.Equal(t, want, b[1+szLen:])
+		require.Equal(t, len(want), sz)
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		t.Parallel()
+
+		txs := make([]transaction.Tx, 10)
+		for i := range txs {
+			txs[i] = gservertest.NewHashOnlyTransaction(uint64(i))
+		}
+
+		var buf bytes.Buffer
+		_, err := gsbd.EncodeBlockDataWithFormat(&buf, gsbd.BinaryFormat, txs)
+		require.NoError(t, err)
+
+		b := buf.Bytes()
+		require.Equal(t, byte(3), b[0])
+
+		rest, szLen := binary.Varint(b[1:])
+		require.Equal(t, 1+szLen+int(rest), len(b))
+	})
+
+	t.Run("smaller than JSON", func(t *testing.T) {
+		t.Parallel()
+
+		txs := make([]transaction.Tx, 10)
+		for i := range txs {
+			txs[i] = gservertest.NewHashOnlyTransaction(uint64(i))
+		}
+
+		jsonSz, err := gsbd.EncodeBlockDataWithFormat(new(bytes.Buffer), gsbd.JSONFormat, txs)
+		require.NoError(t, err)
+		binarySz, err := gsbd.EncodeBlockDataWithFormat(new(bytes.Buffer), gsbd.BinaryFormat, txs)
+		require.NoError(t, err)
+
+		require.Less(t, binarySz, jsonSz)
+	})
+}
+
+func TestFormatByName(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range []gsbd.Format{gsbd.JSONFormat, gsbd.BinaryFormat} {
+		got, err := gsbd.FormatByName(f.String())
+		require.NoError(t, err)
+		require.Equal(t, f, got)
+	}
+
+	_, err := gsbd.FormatByName("xml")
+	require.Error(t, err)
+}
@@ -0,0 +1,734 @@
+package gsbd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gordian/gerasure"
+	"github.com/gordian-engine/gordian/gerasure/gereedsolomon"
+	"github.com/klauspost/reedsolomon"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	libp2pprotocol "github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// Holders of a block data shard serve it on this prefix followed by the data ID.
+const ProposedBlockDataShardV1Prefix = "/gordian/proposed_blockdata_shard/v1/"
+
+// The protocol a proposer uses to push a block data shard to the peer that will hold it.
+const PushBlockDataShardV1 = "/gordian/push_blockdata_shard/v1"
+
+// MaxShardSize is the largest block data shard that an [ErasureHost]
+// produces, holds, or retrieves.
+const MaxShardSize = 8 * 1024 * 1024
+
+// maxShards is the limit on the total number of data and parity shards
+// imposed by the Reed-Solomon implementation.
+const maxShards = 256
+
+// maxHeldShardBytes bounds the total size of the shards an [ErasureHost] holds for other proposers.
+// Only proposers push shards, but a proposer may still push many large shards.
+const maxHeldShardBytes = 64 * 1024 * 1024
+
+// shardHeightWindow is how many heights past the last finalized height
+// an [ErasureHost] accepts pushed shards for.
+// The engine votes on the height after the one being finalized,
+// so this tolerates a finalization that is still in progress.
+const shardHeightWindow = 2
+
+// ErasureLocation is the JSON-encoded Addr of a [Location] with the [ErasureScheme].
+//
+// The encoded block data is split into DataShards shards,
+// and ParityShards Reed-Solomon parity shards are computed from them;
+// any DataShards of the shards suffice to reconstruct the encoded data.
+type ErasureLocation struct {
+	DataShards   int
+	ParityShards int
+
+	// The size of every shard.
+	ShardSize int
+
+	// The size of the encoded block data.
+	// The final data shard may be padded,
+	// so this is required to join the reconstructed data shards.
+	EncodedSize int
+
+	// The SHA-256 hash of each shard, data shards first,
+	// committing the proposer to the shards it pushed.
+	// Every retrieved shard is checked against its hash before reconstruction,
+	// and the reconstructed data is still checked against the data ID.
+	ShardHashes [][]byte
+
+	// The peer ID of the holder of each shard,
+	// or the empty string if the shard could not be pushed to its holder.
+	Holders []string
+}
+
+// ParseErasureLocation decodes and validates the Addr of a Location with the [ErasureScheme].
+func ParseErasureLocation(addr string) (ErasureLocation, error) {
+	var el ErasureLocation
+	if err := json.Unmarshal([]byte(addr), &el); err != nil {
+		return ErasureLocation{}, fmt.Errorf("failed to unmarshal erasure location: %w", err)
+	}
+
+	n := el.DataShards + el.ParityShards
+	if el.DataShards <= 0 || el.ParityShards < 0 || n > maxShards {
+		return ErasureLocation{}, fmt.Errorf(
+			"invalid shard counts: %d data, %d parity", el.DataShards, el.ParityShards,
+		)
+	}
+	if el.ShardSize <= 0 || el.ShardSize > MaxShardSize {
+		return ErasureLocation{}, fmt.Errorf("invalid shard size %d", el.ShardSize)
+	}
+	if el.EncodedSize <= 0 || el.EncodedSize > el.DataShards*el.ShardSize {
+		return ErasureLocation{}, fmt.Errorf(
+			"encoded size %d does not fit in %d shards of size %d",
+			el.EncodedSize, el.DataShards, el.ShardSize,
+		)
+	}
+	if len(el.ShardHashes) != n || len(el.Holders) != n {
+		return ErasureLocation{}, fmt.Errorf(
+			"got %d shard hashes and %d holders for %d shards",
+			len(el.ShardHashes), len(el.Holders), n,
+		)
+	}
+	for i, h := range el.ShardHashes {
+		if len(h) != sha256.Size {
+			return ErasureLocation{}, fmt.Errorf("invalid length %d of hash for shard %d", len(h), i)
+		}
+	}
+
+	return el, nil
+}
+
+// ErasureHost is a [Provider] that erasure codes proposed block data
+// and pushes one shard to each validator,
+// so that the other validators can reconstruct the data from shards fetched from each other,
+// instead of every validator fetching the full data from the proposer.
+//
+// The data is still provided in full through the wrapped [*Libp2pHost],
+// as a fallback for peers that cannot gather enough shards.
+//
+// Every node, proposing or not, needs an ErasureHost
+// to hold and serve the shards pushed to it.
+// It only holds shards pushed by the proposer of the data,
+// as resolved through [*ErasureHost.SetProposerFunc].
+type ErasureHost struct {
+	log *slog.Logger
+
+	h *Libp2pHost
+
+	mu sync.Mutex
+
+	// The peers to push shards to, as set through SetShardPeers.
+	peers []libp2ppeer.ID
+
+	// Resolves the only peer that may push shards for a height and round,
+	// as set through SetProposerFunc.
+	proposerFn ProposerFunc
+
+	// Shards pushed to us, and their total size.
+	held      map[heldShardKey]heldShard
+	heldBytes int
+
+	// The latest height passed to PruneShards,
+	// bounding the heights of pushed shards.
+	finalizedHeight uint64
+
+	// Every shard at or below this height has been pruned.
+	prunedThrough uint64
+}
+
+type heldShardKey struct {
+	DataID string
+	Idx    int
+}
+
+type heldShard struct {
+	Height uint64
+	Data   []byte
+}
+
+// ProposerFunc returns the peer operating the validator
+// that proposes at the given height and round,
+// or false if that peer is not known.
+//
+// A ProposerFunc is called from stream handlers,
+// so it must be safe for concurrent use.
+type ProposerFunc func(height uint64, round uint32) (libp2ppeer.ID, bool)
+
+// NewErasureHost returns a new ErasureHost providing block data through h.
+// The stream handlers to hold and serve shards are set on h's libp2p host immediately.
+func NewErasureHost(log *slog.Logger, h *Libp2pHost) *ErasureHost {
+	e := &ErasureHost{
+		log: log,
+		h:   h,
+
+		held: make(map[heldShardKey]heldShard),
+	}
+
+	h.host.SetStreamHandler(PushBlockDataShardV1, e.handlePush)
+	h.host.SetStreamHandlerMatch(
+		libp2pprotocol.ID(ProposedBlockDataShardV1Prefix),
+		func(pID libp2pprotocol.ID) bool {
+			return strings.HasPrefix(string(pID), ProposedBlockDataShardV1Prefix)
+		},
+		e.handleServe,
+	)
+
+	return e
+}
+
+// SetShardPeers sets the peers, normally the current validators,
+// to push shards to in subsequent calls to [*ErasureHost.Provide].
+// The host's own peer ID is ignored.
+//
+// SetShardPeers is safe to call concurrently with Provide.
+func (e *ErasureHost) SetShardPeers(ids []libp2ppeer.ID) {
+	self := e.h.host.ID()
+	peers := make([]libp2ppeer.ID, 0, len(ids))
+	for _, id := range ids {
+		if id != self && !slices.Contains(peers, id) {
+			peers = append(peers, id)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.peers = peers
+}
+
+// SetProposerFunc sets how the host resolves the proposer of pushed shards.
+// A pushed shard is only held if it came from the proposer
+// of the height and round in its data ID;
+// until SetProposerFunc is called, every pushed shard is rejected.
+//
+// SetProposerFunc is safe to call concurrently with incoming pushes.
+func (e *ErasureHost) SetProposerFunc(f ProposerFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.proposerFn = f
+}
+
+// shardPushTimeout bounds how long Provide waits for shards to be pushed,
+// as the proposal cannot be sent until Provide returns.
+const shardPushTimeout = 2 * time.Second
+
+// Provide provides the block data through the wrapped [*Libp2pHost],
+// then pushes its shards to the shard peers
+// and adds a location with the [ErasureScheme] to the result.
+//
+// With fewer than two shard peers, or if too few shards could be pushed,
+// the result only includes the wrapped host's locations.
+func (e *ErasureHost) Provide(
+	ctx context.Context,
+	height uint64, round uint32,
+	pendingTxs []transaction.Tx,
+) (ProvideResult, error) {
+	res, err := e.h.Provide(ctx, height, round, pendingTxs)
+	if err != nil {
+		return ProvideResult{}, err
+	}
+
+	e.mu.Lock()
+	peers := e.peers
+	e.mu.Unlock()
+
+	if len(peers) < 2 {
+		return res, nil
+	}
+	if len(peers) > maxShards {
+		peers = peers[:maxShards]
+	}
+
+	loc, err := e.pushShards(ctx, res.DataID, res.Encoded, peers)
+	if err != nil {
+		e.log.Warn(
+			"Failed to push block data shards; proposal will only include full data locations",
+			"data_id", res.DataID,
+			"err", err,
+		)
+		return res, nil
+	}
+
+	res.Addrs = append(res.Addrs, loc)
+	return res, nil
+}
+
+// pushShards encodes one shard per peer and pushes them concurrently,
+// returning the Location describing the shards that were pushed.
+func (e *ErasureHost) pushShards(
+	ctx context.Context, dataID string, encoded []byte, peers []libp2ppeer.ID,
+) (Location, error) {
+	// Tolerate up to a third of the validators withholding their shards,
+	// mirroring the fault tolerance of consensus.
+	nParity := max(1, (len(peers)-1)/3)
+	nData := len(peers) - nParity
+
+	rs, err := reedsolomon.New(nData, nParity)
+	if err != nil {
+		return Location{}, fmt.Errorf("failed to create Reed-Solomon encoder: %w", err)
+	}
+
+	// The encoder takes ownership of its input,
+	// and the wrapped host is still serving the encoded data.
+	shards, err := gereedsolomon.NewEncoder(rs).Encode(ctx, bytes.Clone(encoded))
+	if err != nil {
+		return Location{}, fmt.Errorf("failed to encode shards: %w", err)
+	}
+	if len(shards[0]) > MaxShardSize {
+		return Location{}, fmt.Errorf(
+			"shard size %d exceeds maximum %d", len(shards[0]), MaxShardSize,
+		)
+	}
+
+	el := ErasureLocation{
+		DataShards:   nData,
+		ParityShards: nParity,
+
+		ShardSize:   len(shards[0]),
+		EncodedSize: len(encoded),
+
+		ShardHashes: make([][]byte, len(shards)),
+		Holders:     make([]string, len(shards)),
+	}
+	for i, s := range shards {
+		h := sha256.Sum256(s)
+		el.ShardHashes[i] = h[:]
+	}
+
+	pushCtx, cancel := context.WithTimeout(ctx, shardPushTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(len(peers))
+	for i, id := range peers {
+		go func() {
+			defer wg.Done()
+
+			if err := e.pushShard(pushCtx, id, dataID, i, shards[i]); err != nil {
+				e.log.Debug(
+					"Failed to push block data shard",
+					"peer_id", id,
+					"data_id", dataID,
+					"shard_idx", i,
+					"err", err,
+				)
+				return
+			}
+
+			// Each goroutine only writes its own index.
+			el.Holders[i] = id.String()
+		}()
+	}
+	wg.Wait()
+
+	nPushed := 0
+	for _, h := range el.Holders {
+		if h != "" {
+			nPushed++
+		}
+	}
+	if nPushed < nData {
+		return Location{}, fmt.Errorf(
+			"only pushed %d shards, fewer than the %d required to reconstruct", nPushed, nData,
+		)
+	}
+
+	b, err := json.Marshal(el)
+	if err != nil {
+		return Location{}, fmt.Errorf("failed to marshal erasure location: %w", err)
+	}
+
+	return Location{
+		Scheme: ErasureScheme,
+		Addr:   string(b),
+	}, nil
+}
+
+// pushShard sends the shard at index idx to the peer id,
+// and waits for the peer to acknowledge it.
+func (e *ErasureHost) pushShard(
+	ctx context.Context, id libp2ppeer.ID, dataID string, idx int, shard []byte,
+) error {
+	s, err := e.h.host.NewStream(ctx, id, PushBlockDataShardV1)
+	if err != nil {
+		return fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer s.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.SetDeadline(deadline)
+	}
+
+	// The data ID, the shard index, and the length-prefixed shard.
+	buf := make([]byte, 0, 3*binary.MaxVarintLen64+len(dataID)+len(shard))
+	buf = binary.AppendUvarint(buf, uint64(len(dataID)))
+	buf = append(buf, dataID...)
+	buf = binary.AppendUvarint(buf, uint64(idx))
+	buf = binary.AppendUvarint(buf, uint64(len(shard)))
+	buf = append(buf, shard...)
+
+	if _, err := s.Write(buf); err != nil {
+		return fmt.Errorf("failed to write shard: %w", err)
+	}
+	if err := s.CloseWrite(); err != nil {
+		return fmt.Errorf("failed to close stream for write: %w", err)
+	}
+
+	var ack [1]byte
+	if _, err := io.ReadFull(s, ack[:]); err != nil {
+		return fmt.Errorf("failed to read acknowledgement: %w", err)
+	}
+
+	return nil
+}
+
+// shardPushReadTimeout is how long a push handler waits to read a pushed shard.
+const shardPushReadTimeout = 5 * time.Second
+
+// maxDataIDLen bounds the data ID read from a pushed shard.
+// Data IDs are far shorter in practice.
+const maxDataIDLen = 256
+
+// handlePush reads a shard pushed by a proposer, holds it, and acknowledges it.
+func (e *ErasureHost) handlePush(s libp2pnetwork.Stream) {
+	defer s.Close()
+
+	_ = s.SetReadDeadline(time.Now().Add(shardPushReadTimeout))
+
+	dataID, idx, shard, err := readPushedShard(
+		bufio.NewReader(io.LimitReader(s, 3*binary.MaxVarintLen64+maxDataIDLen+MaxShardSize)),
+	)
+	if err != nil {
+		e.log.Debug("Failed to read pushed shard", "peer_id", s.Conn().RemotePeer(), "err", err)
+		_ = s.Reset()
+		return
+	}
+
+	height, round, _, _, _, err := ParseDataID(dataID)
+	if err != nil {
+		e.log.Debug("Rejecting pushed shard with unparseable data ID", "data_id", dataID, "err", err)
+		_ = s.Reset()
+		return
+	}
+
+	if reason := e.holdShard(s.Conn().RemotePeer(), dataID, height, round, idx, shard); reason != "" {
+		e.log.Debug(
+			"Rejecting pushed shard",
+			"peer_id", s.Conn().RemotePeer(),
+			"data_id", dataID,
+			"shard_idx", idx,
+			"reason", reason,
+		)
+		_ = s.Reset()
+		return
+	}
+
+	_, _ = s.Write([]byte{1})
+}
+
+// holdShard holds the shard pushed by peer p,
+// returning a non-empty reason if the shard is rejected instead.
+func (e *ErasureHost) holdShard(
+	p libp2ppeer.ID, dataID string, height uint64, round uint32, idx int, shard []byte,
+) (reason string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if height <= e.prunedThrough || height > e.finalizedHeight+shardHeightWindow {
+		return "height outside of window"
+	}
+
+	if e.proposerFn == nil {
+		return "proposers unknown"
+	}
+	if proposer, ok := e.proposerFn(height, round); !ok || proposer != p {
+		return "not pushed by proposer"
+	}
+
+	key := heldShardKey{DataID: dataID, Idx: idx}
+	heldBytes := e.heldBytes + len(shard)
+	if prev, ok := e.held[key]; ok {
+		// The proposer pushed the same shard again; replace it.
+		heldBytes -= len(prev.Data)
+	}
+	if heldBytes > maxHeldShardBytes {
+		return "too many held shard bytes"
+	}
+
+	e.held[key] = heldShard{Height: height, Data: shard}
+	e.heldBytes = heldBytes
+	return ""
+}
+
+// readPushedShard reads the fields written by [*ErasureHost.pushShard].
+func readPushedShard(r *bufio.Reader) (dataID string, idx int, shard []byte, err error) {
+	idLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("failed to read data ID length: %w", err)
+	}
+	if idLen == 0 || idLen > maxDataIDLen {
+		return "", 0, nil, fmt.Errorf("invalid data ID length %d", idLen)
+	}
+	id := make([]byte, idLen)
+	if _, err := io.ReadFull(r, id); err != nil {
+		return "", 0, nil, fmt.Errorf("failed to read data ID: %w", err)
+	}
+
+	uIdx, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("failed to read shard index: %w", err)
+	}
+	if uIdx >= maxShards {
+		return "", 0, nil, fmt.Errorf("invalid shard index %d", uIdx)
+	}
+
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("failed to read shard size: %w", err)
+	}
+	if size == 0 || size > MaxShardSize {
+		return "", 0, nil, fmt.Errorf("invalid shard size %d", size)
+	}
+	shard = make([]byte, size)
+	if _, err := io.ReadFull(r, shard); err != nil {
+		return "", 0, nil, fmt.Errorf("failed to read shard: %w", err)
+	}
+
+	return string(id), int(uIdx), shard, nil
+}
+
+// shardServeReadTimeout is how long a serve handler waits to read the requested shard index.
+const shardServeReadTimeout = 5 * time.Second
+
+// handleServe reads the index of the requested shard,
+// and writes the shard we hold at that index for the requested data ID:
+// the shard index followed by the shard.
+// The retriever knows the shard size from the [ErasureLocation].
+func (e *ErasureHost) handleServe(s libp2pnetwork.Stream) {
+	dataID := strings.TrimPrefix(string(s.Protocol()), ProposedBlockDataShardV1Prefix)
+
+	_ = s.SetReadDeadline(time.Now().Add(shardServeReadTimeout))
+	uIdx, err := binary.ReadUvarint(bufio.NewReader(io.LimitReader(s, binary.MaxVarintLen64)))
+	if err != nil || uIdx >= maxShards {
+		_ = s.Reset()
+		return
+	}
+	_ = s.CloseRead()
+
+	idx := int(uIdx)
+
+	e.mu.Lock()
+	hs, ok := e.held[heldShardKey{DataID: dataID, Idx: idx}]
+	e.mu.Unlock()
+
+	if !ok {
+		_ = s.Reset()
+		return
+	}
+
+	defer s.Close()
+
+	buf := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(hs.Data)), uint64(idx))
+	buf = append(buf, hs.Data...)
+	if _, err := s.Write(buf); err != nil {
+		e.log.Debug("Failed to write shard to stream", "data_id", dataID, "err", err)
+	}
+}
+
+// PruneShards releases the shards held for block data
+// more than [HandlerGraceHeights] heights below finalizedHeight,
+// and rejects any later pushes at or below that height.
+// Pushes are also only accepted up to a small window past finalizedHeight.
+func (e *ErasureHost) PruneShards(finalizedHeight uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if finalizedHeight > e.finalizedHeight {
+		e.finalizedHeight = finalizedHeight
+	}
+
+	if finalizedHeight <= HandlerGraceHeights {
+		return
+	}
+	through := finalizedHeight - HandlerGraceHeights
+
+	if through > e.prunedThrough {
+		e.prunedThrough = through
+	}
+	for key, hs := range e.held {
+		if hs.Height <= through {
+			e.heldBytes -= len(hs.Data)
+			delete(e.held, key)
+		}
+	}
+}
+
+// RetrieveShards reconstructs the block data for dataID
+// from the shards described by el,
+// fetching shards from their holders concurrently
+// until enough valid shards have been retrieved.
+// Like [*Libp2pClient.RetrieveEncoded],
+// it returns the decoded transactions and the encoded data.
+func (c *Libp2pClient) RetrieveShards(
+	ctx context.Context,
+	el ErasureLocation,
+	dataID string,
+) ([]transaction.Tx, []byte, error) {
+	_, _, _, dataLen, _, err := ParseDataID(dataID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse data ID: %w", err)
+	}
+	if el.EncodedSize > maxEncodedBlockDataSize(int(dataLen)) {
+		return nil, nil, fmt.Errorf(
+			"erasure location encoded size %d too large for data ID size %d",
+			el.EncodedSize, dataLen,
+		)
+	}
+
+	dec, err := NewBlockDataDecoder(dataID, c.decoder)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make block data decoder: %w", err)
+	}
+	dec.SetZstdCodec(c.zstd)
+
+	rs, err := reedsolomon.New(el.DataShards, el.ParityShards)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Reed-Solomon decoder: %w", err)
+	}
+	rec := gereedsolomon.NewReconstructor(rs, el.ShardSize)
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type fetchedShard struct {
+		Idx  int
+		Data []byte
+	}
+	// Buffered for every holder, so that no fetch blocks
+	// after we stop receiving.
+	fetched := make(chan fetchedShard, len(el.Holders))
+
+	var wg sync.WaitGroup
+	for i, holder := range el.Holders {
+		if holder == "" {
+			continue
+		}
+		id, err := libp2ppeer.Decode(holder)
+		if err != nil || id == c.h.ID() {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			shard, err := c.fetchShard(fetchCtx, id, dataID, i, el)
+			if err != nil {
+				if fetchCtx.Err() == nil {
+					c.log.Debug(
+						"Failed to fetch block data shard",
+						"peer_id", id,
+						"data_id", dataID,
+						"shard_idx", i,
+						"err", err,
+					)
+				}
+				return
+			}
+
+			fetched <- fetchedShard{Idx: i, Data: shard}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(fetched)
+	}()
+
+	for f := range fetched {
+		err := rec.ReconstructData(ctx, f.Idx, f.Data)
+		if errors.Is(err, gerasure.ErrIncompleteSet) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to reconstruct block data: %w", err)
+		}
+
+		// We have enough shards, so stop the remaining fetches.
+		cancel()
+
+		encoded, err := rec.Data(nil, el.EncodedSize)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to join reconstructed block data: %w", err)
+		}
+
+		txs, err := dec.Decode(bytes.NewReader(encoded))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode reconstructed block data: %w", err)
+		}
+
+		return txs, encoded, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return nil, nil, fmt.Errorf(
+		"retrieved too few valid shards to reconstruct block data (need %d)", el.DataShards,
+	)
+}
+
+// fetchShard retrieves the shard at index idx from its holder id,
+// and verifies it against the hash in el.
+func (c *Libp2pClient) fetchShard(
+	ctx context.Context, id libp2ppeer.ID, dataID string, idx int, el ErasureLocation,
+) ([]byte, error) {
+	s, err := c.h.NewStream(ctx, id, libp2pprotocol.ID(ProposedBlockDataShardV1Prefix+dataID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer s.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.SetDeadline(deadline)
+	}
+
+	if _, err := s.Write(binary.AppendUvarint(nil, uint64(idx))); err != nil {
+		return nil, fmt.Errorf("failed to write shard index: %w", err)
+	}
+	_ = s.CloseWrite()
+
+	r := bufio.NewReader(io.LimitReader(s, int64(binary.MaxVarintLen64+el.ShardSize)))
+	gotIdx, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shard index: %w", err)
+	}
+	if gotIdx != uint64(idx) {
+		return nil, fmt.Errorf("holder of shard %d served shard %d", idx, gotIdx)
+	}
+
+	shard := make([]byte, el.ShardSize)
+	if _, err := io.ReadFull(r, shard); err != nil {
+		return nil, fmt.Errorf("failed to read shard: %w", err)
+	}
+
+	if h := sha256.Sum256(shard); !bytes.Equal(h[:], el.ShardHashes[idx]) {
+		return nil, fmt.Errorf("shard %d does not match its hash", idx)
+	}
+
+	return shard, nil
+}
@@ -0,0 +1,205 @@
+package gsbd_test
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	"github.com/gordian-engine/gordian/tm/tmcodec/tmjson"
+	"github.com/gordian-engine/gordian/tm/tmp2p/tmlibp2p/tmlibp2ptest"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErasureHost_roundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := gtest.NewLogger(t)
+	net, err := tmlibp2ptest.NewNetwork(ctx, log.With("sys", "net"), tmjson.MarshalCodec{})
+	require.NoError(t, err)
+	defer net.Wait()
+	defer cancel()
+
+	// One proposer and four other validators.
+	const nHosts = 5
+	hosts := make([]libp2phost.Host, nHosts)
+	eHosts := make([]*gsbd.ErasureHost, nHosts)
+	ids := make([]libp2ppeer.ID, nHosts)
+	for i := range nHosts {
+		h, err := net.Connect(ctx)
+		require.NoError(t, err)
+
+		hosts[i] = h.Host().Libp2pHost()
+		ids[i] = hosts[i].ID()
+		eHosts[i] = gsbd.NewErasureHost(
+			log.With("sys", "erasure", "idx", i),
+			gsbd.NewLibp2pProviderHost(log.With("sys", "host", "idx", i), hosts[i]),
+		)
+	}
+	require.NoError(t, net.Stabilize(ctx))
+
+	// Every holder only accepts shards from the proposer.
+	for _, e := range eHosts[1:] {
+		e.SetProposerFunc(func(uint64, uint32) (libp2ppeer.ID, bool) {
+			return ids[0], true
+		})
+	}
+
+	// The proposer's own ID is ignored.
+	eHosts[0].SetShardPeers(ids)
+
+	txs := []transaction.Tx{
+		gservertest.NewHashOnlyTransaction(1),
+		gservertest.NewHashOnlyTransaction(2),
+		gservertest.NewHashOnlyTransaction(3),
+	}
+	res, err := eHosts[0].Provide(ctx, 1, 0, txs)
+	require.NoError(t, err)
+
+	// The full data location and the shard location.
+	require.Len(t, res.Addrs, 2)
+	require.Equal(t, gsbd.Libp2pScheme, res.Addrs[0].Scheme)
+	require.Equal(t, gsbd.ErasureScheme, res.Addrs[1].Scheme)
+
+	el, err := gsbd.ParseErasureLocation(res.Addrs[1].Addr)
+	require.NoError(t, err)
+	require.Equal(t, 3, el.DataShards)
+	require.Equal(t, 1, el.ParityShards)
+	require.Equal(t, len(res.Encoded), el.EncodedSize)
+	for i, h := range el.Holders {
+		require.Equal(t, ids[i+1].String(), h)
+	}
+
+	// The last validator holds one shard itself,
+	// so it must fetch all three of the other shards.
+	client := gsbd.NewLibp2pClient(
+		log.With("sys", "client"), hosts[4], gservertest.HashOnlyTransactionDecoder{},
+	)
+	gotTxs, encoded, err := client.RetrieveShards(ctx, el, res.DataID)
+	require.NoError(t, err)
+	require.Equal(t, txs, gotTxs)
+	require.Equal(t, res.Encoded, encoded)
+
+	// Once another holder prunes its shard, there are too few shards left.
+	eHosts[1].PruneShards(1 + gsbd.HandlerGraceHeights)
+	_, _, err = client.RetrieveShards(ctx, el, res.DataID)
+	require.ErrorContains(t, err, "too few valid shards")
+}
+
+func TestErasureHost_rejectedPushes(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := gtest.NewLogger(t)
+	net, err := tmlibp2ptest.NewNetwork(ctx, log.With("sys", "net"), tmjson.MarshalCodec{})
+	require.NoError(t, err)
+	defer net.Wait()
+	defer cancel()
+
+	const nHosts = 5
+	eHosts := make([]*gsbd.ErasureHost, nHosts)
+	ids := make([]libp2ppeer.ID, nHosts)
+	for i := range nHosts {
+		h, err := net.Connect(ctx)
+		require.NoError(t, err)
+
+		ids[i] = h.Host().Libp2pHost().ID()
+		eHosts[i] = gsbd.NewErasureHost(
+			log.With("sys", "erasure", "idx", i),
+			gsbd.NewLibp2pProviderHost(log.With("sys", "host", "idx", i), h.Host().Libp2pHost()),
+		)
+	}
+	require.NoError(t, net.Stabilize(ctx))
+
+	// The holders consider the validator at index 1 to be the proposer
+	// of round 0 at every height, and do not know the proposer of other rounds.
+	for _, e := range eHosts[1:] {
+		e.SetProposerFunc(func(_ uint64, r uint32) (libp2ppeer.ID, bool) {
+			if r != 0 {
+				return "", false
+			}
+			return ids[1], true
+		})
+	}
+
+	eHosts[0].SetShardPeers(ids)
+
+	txs := []transaction.Tx{gservertest.NextHashOnlyTransaction()}
+
+	requireNoShards := func(t *testing.T, res gsbd.ProvideResult) {
+		t.Helper()
+		for _, loc := range res.Addrs {
+			require.NotEqual(t, gsbd.ErasureScheme, loc.Scheme)
+		}
+	}
+
+	t.Run("not the proposer", func(t *testing.T) {
+		res, err := eHosts[0].Provide(ctx, 1, 0, txs)
+		require.NoError(t, err)
+		requireNoShards(t, res)
+	})
+
+	t.Run("unknown proposer", func(t *testing.T) {
+		res, err := eHosts[0].Provide(ctx, 1, 1, txs)
+		require.NoError(t, err)
+		requireNoShards(t, res)
+	})
+
+	// From here on, the host at index 0 is the proposer.
+	for _, e := range eHosts[1:] {
+		e.SetProposerFunc(func(uint64, uint32) (libp2ppeer.ID, bool) {
+			return ids[0], true
+		})
+	}
+
+	t.Run("height outside of window", func(t *testing.T) {
+		// Nothing has been finalized, so the height is too far ahead.
+		res, err := eHosts[0].Provide(ctx, 10, 0, txs)
+		require.NoError(t, err)
+		requireNoShards(t, res)
+
+		// Once the holders have finalized a nearby height, the same push is accepted.
+		for _, e := range eHosts[1:] {
+			e.PruneShards(9)
+		}
+		res, err = eHosts[0].Provide(ctx, 10, 0, txs)
+		require.NoError(t, err)
+		require.Equal(t, gsbd.ErasureScheme, res.Addrs[len(res.Addrs)-1].Scheme)
+
+		// And a height that has been pruned is rejected.
+		for _, e := range eHosts[1:] {
+			e.PruneShards(10 + gsbd.HandlerGraceHeights)
+		}
+		res, err = eHosts[0].Provide(ctx, 10, 1, txs)
+		require.NoError(t, err)
+		requireNoShards(t, res)
+	})
+}
+
+func TestParseErasureLocation_invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, addr := range map[string]string{
+		"not JSON":           "{",
+		"no data shards":     `{"DataShards":0,"ParityShards":1,"ShardSize":1,"EncodedSize":1}`,
+		"too many shards":    `{"DataShards":200,"ParityShards":100,"ShardSize":1,"EncodedSize":1}`,
+		"encoded size large": `{"DataShards":1,"ParityShards":1,"ShardSize":1,"EncodedSize":2}`,
+		"missing hashes":     `{"DataShards":1,"ParityShards":1,"ShardSize":1,"EncodedSize":1,"Holders":["",""]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := gsbd.ParseErasureLocation(addr)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package gsbd
+
+import "fmt"
+
+// Format is the serialization of the transactions in block data,
+// before any compression is applied.
+//
+// The header byte of encoded block data identifies both the format and the compression,
+// so a [BlockDataDecoder] accepts block data in every format.
+// The format is still chosen per chain, in genesis,
+// so that every validator produces identical block data for identical transactions.
+type Format uint8
+
+const (
+	// JSONFormat is the original format:
+	// a JSON array of the base64-encoded bytes of each transaction.
+	JSONFormat Format = iota
+
+	// BinaryFormat is a uvarint count of transactions,
+	// followed by the bytes of each transaction prefixed with their uvarint length.
+	BinaryFormat
+)
+
+// Format names, for selecting a format in configuration.
+const (
+	JSONFormatName   = "json"
+	BinaryFormatName = "binary"
+)
+
+// FormatByName returns the Format with the given name.
+func FormatByName(name string) (Format, error) {
+	switch name {
+	case JSONFormatName:
+		return JSONFormat, nil
+	case BinaryFormatName:
+		return BinaryFormat, nil
+	default:
+		return 0, fmt.Errorf(
+			"unknown block data format %q (known formats: %q, %q)",
+			name, JSONFormatName, BinaryFormatName,
+		)
+	}
+}
+
+// String returns the name of f.
+func (f Format) String() string {
+	switch f {
+	case JSONFormat:
+		return JSONFormatName
+	case BinaryFormat:
+		return BinaryFormatName
+	default:
+		return fmt.Sprintf("Format(%d)", uint8(f))
+	}
+}
@@ -0,0 +1,320 @@
+package gsbd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/golang/snappy"
+)
+
+// HTTPURL returns the URL of the block data for dataID,
+// under the base URL of a Location with the [HTTPScheme].
+func HTTPURL(baseURL, dataID string) string {
+	return strings.TrimSuffix(baseURL, "/") + "/" + url.PathEscape(dataID)
+}
+
+// HTTPPublisher uploads encoded block data to a web server with HTTP PUT,
+// so that other nodes can retrieve it from that server, or from a CDN in front of it,
+// instead of directly from the proposer.
+type HTTPPublisher struct {
+	client *http.Client
+
+	uploadURL, publicURL string
+}
+
+// NewHTTPPublisher returns a new HTTPPublisher.
+// Block data is uploaded under uploadURL,
+// and advertised in proposal annotations under publicURL,
+// which may be the same as uploadURL.
+// If client is nil, [http.DefaultClient] is used.
+func NewHTTPPublisher(client *http.Client, uploadURL, publicURL string) *HTTPPublisher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if publicURL == "" {
+		publicURL = uploadURL
+	}
+	return &HTTPPublisher{
+		client: client,
+
+		uploadURL: uploadURL,
+		publicURL: publicURL,
+	}
+}
+
+// Location returns the Location to include in the proposal annotation
+// for block data uploaded through p.
+func (p *HTTPPublisher) Location() Location {
+	return Location{
+		Scheme: HTTPScheme,
+		Addr:   p.publicURL,
+	}
+}
+
+// Publish uploads the encoded block data for dataID.
+func (p *HTTPPublisher) Publish(ctx context.Context, dataID string, encoded []byte) error {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, HTTPURL(p.uploadURL, dataID), bytes.NewReader(encoded),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload block data: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("upload of block data failed with status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// DefaultHTTPMaxBlockDataSize is the default limit on the uncompressed size
+// of block data that an [HTTPClient] retrieves.
+const DefaultHTTPMaxBlockDataSize = 32 * 1024 * 1024
+
+// HTTPClient retrieves block data from Locations with the [HTTPScheme].
+//
+// Unlike a libp2p peer, a web server is not accountable for the data it serves,
+// so the client bounds the response size by the length declared in the data ID
+// before reading anything,
+// and the decoded transactions are always verified against the data ID hash.
+//
+// The locations come from proposal annotations, which any proposer controls,
+// so by default the client only connects to public addresses over http or https,
+// and it never follows redirects;
+// otherwise a proposer could make every validator send requests
+// to services on their private networks.
+// Locations configured by the operator may be private,
+// through [*HTTPClient.AllowPrivateAddresses].
+type HTTPClient struct {
+	client *http.Client
+
+	decoder transaction.Codec[transaction.Tx]
+	zstd    *ZstdCodec
+
+	maxDataSize int
+
+	dialer       net.Dialer
+	allowPrivate bool
+}
+
+// NewHTTPClient returns a new HTTPClient.
+// If client is nil, a client with a short timeout is used.
+// If maxDataSize is not positive, [DefaultHTTPMaxBlockDataSize] is used.
+//
+// The HTTPClient uses a copy of client that refuses redirects,
+// and whose transport, if it is nil or an [*http.Transport],
+// is replaced with one that only dials the addresses allowed by the HTTPClient.
+func NewHTTPClient(
+	client *http.Client,
+	decoder transaction.Codec[transaction.Tx],
+	maxDataSize int,
+) *HTTPClient {
+	if client == nil {
+		// Arbitrarily chosen, but block data should be quick to fetch.
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	if maxDataSize <= 0 {
+		maxDataSize = DefaultHTTPMaxBlockDataSize
+	}
+
+	c := &HTTPClient{
+		decoder: decoder,
+
+		maxDataSize: maxDataSize,
+	}
+
+	hc := *client
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return errors.New("redirects are not followed for block data")
+	}
+	var t *http.Transport
+	switch rt := hc.Transport.(type) {
+	case nil:
+		t = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		t = rt.Clone()
+	}
+	if t != nil {
+		// A proxy would dial the target address on our behalf, unchecked.
+		t.Proxy = nil
+		t.DialContext = c.dialContext
+		hc.Transport = t
+	}
+	c.client = &hc
+
+	return c
+}
+
+// SetZstdCodec sets the codec for decoding zstd-compressed block data
+// in subsequent calls to [*HTTPClient.Retrieve].
+//
+// SetZstdCodec must not be called concurrently with Retrieve.
+func (c *HTTPClient) SetZstdCodec(zc *ZstdCodec) {
+	c.zstd = zc
+}
+
+// AllowPrivateAddresses allows subsequent calls to [*HTTPClient.Retrieve]
+// to connect to private, loopback, and link-local addresses.
+// It is only appropriate for a client whose base URLs are configured by the operator,
+// such as an archive of block data on the operator's own network.
+//
+// AllowPrivateAddresses must not be called concurrently with Retrieve.
+func (c *HTTPClient) AllowPrivateAddresses() {
+	c.allowPrivate = true
+}
+
+// dialContext resolves the host in addr,
+// and dials the first of its addresses that c allows.
+// Dialing the checked address, rather than resolving the host again,
+// ensures that a second DNS answer cannot bypass the check.
+func (c *HTTPClient) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %q: %w", host, err)
+	}
+
+	var errs []error
+	for _, ip := range ips {
+		ip = ip.Unmap()
+		if !c.allowPrivate && !isPublicAddr(ip) {
+			errs = append(errs, fmt.Errorf("refusing to connect to non-public address %s", ip))
+			continue
+		}
+
+		conn, err := c.dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return conn, nil
+	}
+
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no addresses for %q", host)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// isPublicAddr reports whether ip is a globally routable unicast address.
+func isPublicAddr(ip netip.Addr) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// Retrieve fetches the block data for dataID from under baseURL,
+// returning the decoded transactions and the encoded data.
+func (c *HTTPClient) Retrieve(
+	ctx context.Context,
+	baseURL, dataID string,
+) ([]transaction.Tx, []byte, error) {
+	_, _, _, dataLen, _, err := ParseDataID(dataID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse data ID: %w", err)
+	}
+	if int64(dataLen) > int64(c.maxDataSize) {
+		return nil, nil, fmt.Errorf(
+			"data ID declares size %d exceeding maximum %d", dataLen, c.maxDataSize,
+		)
+	}
+
+	dec, err := NewBlockDataDecoder(dataID, c.decoder)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make block data decoder: %w", err)
+	}
+	dec.SetZstdCodec(c.zstd)
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, nil, fmt.Errorf("unsupported URL scheme %q; only http and https are allowed", u.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, HTTPURL(baseURL, dataID), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to request block data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("block data request failed with status %s", resp.Status)
+	}
+
+	maxEncodedSize := maxEncodedBlockDataSize(int(dataLen))
+	if resp.ContentLength > int64(maxEncodedSize) {
+		return nil, nil, fmt.Errorf(
+			"response content length %d exceeds maximum encoded size %d",
+			resp.ContentLength, maxEncodedSize,
+		)
+	}
+
+	// Read one byte past the limit,
+	// so that we can tell an oversized body from one that is exactly the limit.
+	b, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxEncodedSize)+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(b) > maxEncodedSize {
+		return nil, nil, fmt.Errorf("response body exceeds maximum encoded size %d", maxEncodedSize)
+	}
+
+	// We save and serve the encoded data, so it must be exact:
+	// the header byte, the size, and exactly that many bytes.
+	if len(b) < 2 {
+		return nil, nil, errors.New("response body too short for block data")
+	}
+	size, n := binary.Varint(b[1:])
+	if n <= 0 || size <= 0 {
+		return nil, nil, errors.New("failed to read size from response body")
+	}
+	if want := 1 + int64(n) + size; int64(len(b)) != want {
+		return nil, nil, fmt.Errorf(
+			"response body length %d differed from encoded length %d; truncated or trailing data",
+			len(b), want,
+		)
+	}
+
+	txs, err := dec.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode block data: %w", err)
+	}
+
+	return txs, b, nil
+}
+
+// maxEncodedBlockDataSize is the largest possible encoding
+// of block data whose serialized transactions are dataLen bytes:
+// the header byte, the size varint, and the larger of the uncompressed and snappy sizes.
+// Zstd data is only written when it is smaller than the uncompressed data.
+func maxEncodedBlockDataSize(dataLen int) int {
+	return 1 + binary.MaxVarintLen64 + max(dataLen, snappy.MaxEncodedLen(dataLen))
+}
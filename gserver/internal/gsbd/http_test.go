@@ -0,0 +1,219 @@
+package gsbd_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/stretchr/testify/require"
+)
+
+// blobServer is a minimal web server storing PUT bodies by path.
+type blobServer struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+
+	gets atomic.Int32
+}
+
+func (s *blobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.blobs[r.URL.Path] = b
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		s.gets.Add(1)
+		b, ok := s.blobs[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(b)
+	default:
+		http.Error(w, "bad method", http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHTTP_publishAndRetrieve(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bs := &blobServer{blobs: map[string][]byte{}}
+	srv := httptest.NewServer(bs)
+	defer srv.Close()
+
+	txs := []transaction.Tx{
+		gservertest.NewHashOnlyTransaction(1),
+		gservertest.NewHashOnlyTransaction(2),
+	}
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+	dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+
+	// Upload to one path and advertise another, as if there were a CDN in front.
+	pub := gsbd.NewHTTPPublisher(srv.Client(), srv.URL+"/upload/", srv.URL+"/upload")
+	require.NoError(t, pub.Publish(ctx, dataID, buf.Bytes()))
+	loc := pub.Location()
+	require.Equal(t, gsbd.HTTPScheme, loc.Scheme)
+	require.Equal(t, srv.URL+"/upload", loc.Addr)
+
+	c := gsbd.NewHTTPClient(
+		srv.Client(), gservertest.HashOnlyTransactionDecoder{}, 0,
+	)
+	// The test server is on a loopback address.
+	c.AllowPrivateAddresses()
+	gotTxs, encoded, err := c.Retrieve(ctx, loc.Addr, dataID)
+	require.NoError(t, err)
+	require.Equal(t, txs, gotTxs)
+	require.Equal(t, buf.Bytes(), encoded)
+
+	// Missing data is an error.
+	otherTxs := []transaction.Tx{gservertest.NewHashOnlyTransaction(3)}
+	var otherBuf bytes.Buffer
+	otherSz, err := gsbd.EncodeBlockData(&otherBuf, otherTxs)
+	require.NoError(t, err)
+	otherDataID := gsbd.DataID(2, 0, uint32(otherSz), otherTxs)
+	_, _, err = c.Retrieve(ctx, loc.Addr, otherDataID)
+	require.ErrorContains(t, err, "404")
+}
+
+func TestHTTPClient_Retrieve_invalid(t *testing.T) {
+	t.Parallel()
+
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+	dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+
+	// Same size, different transaction.
+	wrongTxs := []transaction.Tx{gservertest.NewHashOnlyTransaction(2)}
+	var wrongBuf bytes.Buffer
+	_, err = gsbd.EncodeBlockData(&wrongBuf, wrongTxs)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		body    []byte
+		wantErr string
+	}{
+		"wrong transactions": {
+			body:    wrongBuf.Bytes(),
+			wantErr: "hash",
+		},
+		"trailing data": {
+			body:    append(bytes.Clone(buf.Bytes()), 0),
+			wantErr: "trailing",
+		},
+		"oversized body": {
+			body:    append(bytes.Clone(buf.Bytes()), bytes.Repeat([]byte{0}, 1024)...),
+			wantErr: "maximum encoded size",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(tc.body)
+			}))
+			defer srv.Close()
+
+			c := gsbd.NewHTTPClient(
+				srv.Client(), gservertest.HashOnlyTransactionDecoder{}, 0,
+			)
+			c.AllowPrivateAddresses()
+			_, _, err := c.Retrieve(context.Background(), srv.URL, dataID)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+
+	t.Run("data ID larger than limit", func(t *testing.T) {
+		t.Parallel()
+
+		bs := &blobServer{blobs: map[string][]byte{}}
+		srv := httptest.NewServer(bs)
+		defer srv.Close()
+
+		c := gsbd.NewHTTPClient(
+			srv.Client(), gservertest.HashOnlyTransactionDecoder{}, sz-1,
+		)
+		c.AllowPrivateAddresses()
+		_, _, err := c.Retrieve(context.Background(), srv.URL, dataID)
+		require.ErrorContains(t, err, "exceeding maximum")
+
+		// Rejected without making a request.
+		require.Zero(t, bs.gets.Load())
+	})
+}
+
+func TestHTTPClient_Retrieve_untrustedLocations(t *testing.T) {
+	t.Parallel()
+
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+	dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+
+	bs := &blobServer{blobs: map[string][]byte{
+		"/bd/" + dataID: buf.Bytes(),
+	}}
+	mux := http.NewServeMux()
+	mux.Handle("/bd/", bs)
+	mux.Handle("/redirect/", http.RedirectHandler("/bd/"+dataID, http.StatusFound))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	t.Run("private address", func(t *testing.T) {
+		c := gsbd.NewHTTPClient(nil, gservertest.HashOnlyTransactionDecoder{}, 0)
+		_, _, err := c.Retrieve(context.Background(), srv.URL+"/bd", dataID)
+		require.ErrorContains(t, err, "non-public address")
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		c := gsbd.NewHTTPClient(nil, gservertest.HashOnlyTransactionDecoder{}, 0)
+		c.AllowPrivateAddresses()
+		_, _, err := c.Retrieve(context.Background(), srv.URL+"/redirect", dataID)
+		require.ErrorContains(t, err, "redirect")
+
+		// The same client can still retrieve the data without the redirect.
+		gotTxs, _, err := c.Retrieve(context.Background(), srv.URL+"/bd", dataID)
+		require.NoError(t, err)
+		require.Equal(t, txs, gotTxs)
+	})
+
+	t.Run("scheme", func(t *testing.T) {
+		c := gsbd.NewHTTPClient(nil, gservertest.HashOnlyTransactionDecoder{}, 0)
+		c.AllowPrivateAddresses()
+		_, _, err := c.Retrieve(context.Background(), "file:///bd", dataID)
+		require.ErrorContains(t, err, "unsupported URL scheme")
+	})
+}
+
+func TestHTTPURL(t *testing.T) {
+	t.Parallel()
+
+	dataID := gsbd.DataID(1, 0, 0, nil)
+	require.Equal(t, "https://example.com/bd/"+dataID, gsbd.HTTPURL("https://example.com/bd", dataID))
+	require.Equal(t, "https://example.com/bd/"+dataID, gsbd.HTTPURL("https://example.com/bd/", dataID))
+	require.False(t, strings.Contains(gsbd.HTTPURL("https://example.com", "a/b"), "a/b"))
+}
@@ -8,8 +8,13 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gcstore"
 	libp2phost "github.com/libp2p/go-libp2p/core/host"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
@@ -24,6 +29,28 @@ type Libp2pHost struct {
 	log *slog.Logger
 
 	host libp2phost.Host
+
+	relayPeers []libp2ppeer.ID
+
+	httpPublisher *HTTPPublisher
+
+	format  Format
+	zstd    *ZstdCodec
+	txsHash TxsHashScheme
+
+	// Provide and Relay are called from the consensus strategy and its retriever,
+	// and PruneHandlers is called from the driver,
+	// so the handler bookkeeping is guarded by a mutex.
+	// It is only held to update the map,
+	// never while calling into the libp2p host.
+	mu sync.Mutex
+
+	// The per-proposal protocol IDs we have set handlers for, keyed by height.
+	handlers map[uint64][]libp2pprotocol.ID
+
+	// Every handler at or below this height has been removed.
+	// Atomic so that protocol matching never needs the mutex.
+	prunedThrough atomic.Uint64
 }
 
 func NewLibp2pProviderHost(
@@ -33,6 +60,8 @@ func NewLibp2pProviderHost(
 	return &Libp2pHost{
 		log:  log,
 		host: host,
+
+		handlers: make(map[uint64][]libp2pprotocol.ID),
 	}
 }
 
@@ -48,7 +77,7 @@ func (h *Libp2pHost) Provide(
 	}
 
 	var buf bytes.Buffer
-	sz, err := EncodeBlockData(&buf, pendingTxs)
+	sz, err := encodeBlockData(&buf, h.format, h.zstd, pendingTxs)
 	if err != nil {
 		return ProvideResult{}, fmt.Errorf(
 			"failed to encode block data: %w", err,
@@ -56,24 +85,39 @@ func (h *Libp2pHost) Provide(
 	}
 	encoded := buf.Bytes()
 
-	dataID := DataID(height, round, uint32(sz), pendingTxs)
+	dataID := h.txsHash.DataID(height, round, uint32(sz), pendingTxs)
 
-	pID := libp2pprotocol.ID(ProposedBlockDataV1Prefix + dataID)
-	h.host.SetStreamHandler(pID, h.makeBlockDataHandler(encoded))
-
-	// TODO: we need a way to prune old handlers.
-	// Currently we just leak a handler every time we propose a block.
+	h.setHandler(height, dataID, h.makeBlockDataHandler(encoded))
 
-	ai := libp2phost.InfoFromHost(h.host)
-	jai, err := json.Marshal(ai)
+	loc, err := libp2pLocation(*libp2phost.InfoFromHost(h.host))
 	if err != nil {
-		return ProvideResult{}, fmt.Errorf("failed to marshal AddrInfo %v: %w", ai, err)
+		return ProvideResult{}, err
 	}
-	locs := []Location{
-		{
-			Scheme: Libp2pScheme,
-			Addr:   string(jai),
-		},
+	locs := make([]Location, 1, 1+len(h.relayPeers))
+	locs[0] = loc
+
+	// Our relay peers will fetch the data from us as soon as they see the proposal,
+	// so also point other peers at the relays, in case they cannot reach us directly.
+	for _, id := range h.relayPeers {
+		ai := h.host.Peerstore().PeerInfo(id)
+		if len(ai.Addrs) == 0 {
+			// Nothing useful to advertise.
+			continue
+		}
+
+		loc, err := libp2pLocation(ai)
+		if err != nil {
+			return ProvideResult{}, err
+		}
+		locs = append(locs, loc)
+	}
+
+	if h.httpPublisher != nil {
+		// The upload happens in the background, so that it never delays the proposal.
+		// Until it completes, or if it fails,
+		// the data is still available from us over libp2p.
+		locs = append(locs, h.httpPublisher.Location())
+		go h.publishHTTP(context.WithoutCancel(ctx), h.httpPublisher, dataID, encoded)
 	}
 
 	return ProvideResult{
@@ -83,9 +127,258 @@ func (h *Libp2pHost) Provide(
 	}, nil
 }
 
+// libp2pLocation returns a Location for ai with the [Libp2pScheme].
+func libp2pLocation(ai libp2ppeer.AddrInfo) (Location, error) {
+	jai, err := json.Marshal(ai)
+	if err != nil {
+		return Location{}, fmt.Errorf("failed to marshal AddrInfo %v: %w", ai, err)
+	}
+	return Location{
+		Scheme: Libp2pScheme,
+		Addr:   string(jai),
+	}, nil
+}
+
+// SetRelayPeers sets the peers, such as a validator's sentry nodes,
+// to include as additional locations in subsequent calls to [*Libp2pHost.Provide].
+// Relay peers are expected to call [*Libp2pHost.Relay] for any proposed block data they retrieve.
+//
+// SetRelayPeers must not be called concurrently with Provide.
+func (h *Libp2pHost) SetRelayPeers(ids []libp2ppeer.ID) {
+	h.relayPeers = ids
+}
+
+// SetHTTPPublisher sets the publisher that uploads the block data
+// in the background after subsequent calls to [*Libp2pHost.Provide],
+// so that the proposal also includes a location with the [HTTPScheme].
+//
+// SetHTTPPublisher must not be called concurrently with Provide.
+func (h *Libp2pHost) SetHTTPPublisher(p *HTTPPublisher) {
+	h.httpPublisher = p
+}
+
+// httpPublishTimeout bounds each background HTTP upload started by Provide.
+const httpPublishTimeout = 10 * time.Second
+
+// publishHTTP uploads the encoded block data for dataID through p,
+// logging any failure.
+func (h *Libp2pHost) publishHTTP(ctx context.Context, p *HTTPPublisher, dataID string, encoded []byte) {
+	ctx, cancel := context.WithTimeout(ctx, httpPublishTimeout)
+	defer cancel()
+
+	if err := p.Publish(ctx, dataID, encoded); err != nil {
+		h.log.Warn(
+			"Failed to publish block data over HTTP; peers must retrieve it over libp2p",
+			"data_id", dataID,
+			"err", err,
+		)
+	}
+}
+
+// SetFormat sets the format of the block data encoded in subsequent calls to [*Libp2pHost.Provide].
+// The default is the [JSONFormat].
+//
+// SetFormat must not be called concurrently with Provide.
+func (h *Libp2pHost) SetFormat(f Format) {
+	h.format = f
+}
+
+// SetTxsHashScheme sets the scheme of the transactions hash in the data IDs
+// returned from subsequent calls to [*Libp2pHost.Provide].
+// The default is the [Blake2bTxsHash].
+//
+// SetTxsHashScheme must not be called concurrently with Provide.
+func (h *Libp2pHost) SetTxsHashScheme(s TxsHashScheme) {
+	h.txsHash = s
+}
+
+// SetZstdCodec sets the codec to compress the block data encoded
+// in subsequent calls to [*Libp2pHost.Provide].
+// The default, a nil codec, compresses with snappy.
+//
+// SetZstdCodec must not be called concurrently with Provide.
+func (h *Libp2pHost) SetZstdCodec(zc *ZstdCodec) {
+	h.zstd = zc
+}
+
+// relayWaitTimeout is how long a relay handler waits
+// for in-flight block data to become available before giving up on the stream.
+const relayWaitTimeout = 5 * time.Second
+
+// Relay serves the block data for dataID from req,
+// so that other peers can retrieve proposed block data through this host.
+// This is how a sentry node makes its validator's proposed block data
+// available to peers that cannot connect to the validator directly.
+//
+// The data does not need to be ready yet;
+// incoming streams wait for req.Ready before writing the encoded data.
+func (h *Libp2pHost) Relay(dataID string, req *BlockDataRequest) {
+	height, _, _, _, _, err := ParseDataID(dataID)
+	if err != nil {
+		h.log.Debug("Not relaying block data with unparseable data ID", "data_id", dataID, "err", err)
+		return
+	}
+
+	h.setHandler(height, dataID, h.makeRelayHandler(req))
+}
+
+// HandlerGraceHeights is the number of heights that [*Libp2pHost.PruneHandlers]
+// keeps serving proposed block data after its height is finalized,
+// so that peers that are slightly behind can still retrieve it directly.
+const HandlerGraceHeights = 2
+
+// setHandler sets handler as the stream handler for dataID,
+// and records it for removal by [*Libp2pHost.PruneHandlers].
+func (h *Libp2pHost) setHandler(height uint64, dataID string, handler libp2pnetwork.StreamHandler) {
+	pID := libp2pprotocol.ID(ProposedBlockDataV1Prefix + dataID)
+
+	h.host.SetStreamHandler(pID, handler)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[height] = append(h.handlers[height], pID)
+}
+
+// PruneHandlers removes the stream handlers for block data
+// that was provided or relayed more than [HandlerGraceHeights] heights
+// below finalizedHeight, releasing the data that the handlers retained.
+//
+// If a block data store was set through [*Libp2pHost.SetBlockDataStore],
+// requests for the pruned data are served from the store instead.
+func (h *Libp2pHost) PruneHandlers(finalizedHeight uint64) {
+	if finalizedHeight <= HandlerGraceHeights {
+		return
+	}
+	through := finalizedHeight - HandlerGraceHeights
+
+	var pruned []libp2pprotocol.ID
+	h.mu.Lock()
+	for height, pIDs := range h.handlers {
+		if height > through {
+			continue
+		}
+
+		pruned = append(pruned, pIDs...)
+		delete(h.handlers, height)
+	}
+	h.mu.Unlock()
+
+	// Advance the pruned height before removing the handlers,
+	// so that the fallback handler takes over without a gap.
+	if through > h.prunedThrough.Load() {
+		h.prunedThrough.Store(through)
+	}
+
+	for _, pID := range pruned {
+		h.host.RemoveStreamHandler(pID)
+	}
+}
+
+// storeLoadTimeout is how long a fallback handler may spend
+// loading block data from the block data store.
+const storeLoadTimeout = time.Second
+
+// SetBlockDataStore sets bds as the fallback source of block data
+// whose handlers were removed by [*Libp2pHost.PruneHandlers].
+// Only finalized block data is in the store,
+// so data for proposals that were never finalized is no longer available.
+//
+// The given context bounds the lifecycle of the fallback handler.
+// SetBlockDataStore must be called at most once.
+func (h *Libp2pHost) SetBlockDataStore(ctx context.Context, bds gcstore.BlockDataStore) {
+	h.host.SetStreamHandlerMatch(
+		libp2pprotocol.ID(ProposedBlockDataV1Prefix),
+		h.isPruned,
+		h.makeStoreHandler(ctx, bds),
+	)
+}
+
+// isPruned reports whether pID is a proposed block data protocol
+// whose handler has already been pruned.
+// The fallback store handler is only matched for pruned data,
+// so that it never shadows a handler still holding the data in memory.
+func (h *Libp2pHost) isPruned(pID libp2pprotocol.ID) bool {
+	dataID, ok := strings.CutPrefix(string(pID), ProposedBlockDataV1Prefix)
+	if !ok {
+		return false
+	}
+
+	height, _, _, _, _, err := ParseDataID(dataID)
+	if err != nil {
+		return false
+	}
+
+	return height <= h.prunedThrough.Load()
+}
+
+// makeStoreHandler returns a handler to serve block data from bds,
+// for block data whose in-memory handler has been pruned.
+func (h *Libp2pHost) makeStoreHandler(ctx context.Context, bds gcstore.BlockDataStore) libp2pnetwork.StreamHandler {
+	outerLog := h.log.With("handler", "stored_blockdata")
+	return func(s libp2pnetwork.Stream) {
+		_ = s.CloseRead()
+
+		dataID := strings.TrimPrefix(string(s.Protocol()), ProposedBlockDataV1Prefix)
+
+		loadCtx, cancel := context.WithTimeout(ctx, storeLoadTimeout)
+		defer cancel()
+		_, data, err := bds.LoadBlockDataByID(loadCtx, dataID, nil)
+		if err != nil {
+			if !errors.Is(err, gcstore.ErrBlockDataNotFound) {
+				outerLog.Debug("Failed to load block data from store", "data_id", dataID, "err", err)
+			}
+			_ = s.Reset()
+			return
+		}
+
+		defer s.Close()
+		if _, err := io.Copy(s, bytes.NewReader(data)); err != nil {
+			outerLog.Debug("Failed to copy stored data to stream", "err", err)
+			return
+		}
+	}
+}
+
+// makeRelayHandler returns a handler to serve the encoded data in req
+// once req is ready.
+func (h *Libp2pHost) makeRelayHandler(req *BlockDataRequest) libp2pnetwork.StreamHandler {
+	outerLog := h.log.With("handler", "relay_blockdata")
+	return func(s libp2pnetwork.Stream) {
+		_ = s.CloseRead()
+
+		t := time.NewTimer(relayWaitTimeout)
+		defer t.Stop()
+		select {
+		case <-req.Ready:
+			// Okay.
+		case <-t.C:
+			outerLog.Debug("Timed out waiting for relayed block data")
+			_ = s.Reset()
+			return
+		}
+
+		defer s.Close()
+		if _, err := io.Copy(s, bytes.NewReader(req.EncodedTransactions)); err != nil {
+			outerLog.Debug("Failed to copy relayed data to stream", "err", err)
+			return
+		}
+	}
+}
+
+// Header bytes of encoded block data,
+// indicating the transaction format and the compression.
 const (
+	// The JSONFormat.
 	uncompressedHeader byte = 0
 	snappyHeader       byte = 1
+
+	// The BinaryFormat.
+	uncompressedBinaryHeader byte = 2
+	snappyBinaryHeader       byte = 3
+
+	// Zstd compression, with or without a dictionary, for either format.
+	zstdHeader       byte = 4
+	zstdBinaryHeader byte = 5
 )
 
 // makeBlockDataHandler returns a handler to be set on the router,
@@ -119,6 +412,8 @@ type Libp2pClient struct {
 	h libp2phost.Host
 
 	decoder transaction.Codec[transaction.Tx]
+
+	zstd *ZstdCodec
 }
 
 func NewLibp2pClient(
@@ -129,25 +424,46 @@ func NewLibp2pClient(
 	return &Libp2pClient{log: log, h: host, decoder: decoder}
 }
 
+// SetZstdCodec sets the codec for decoding zstd-compressed block data
+// in subsequent calls to [*Libp2pClient.Retrieve].
+//
+// SetZstdCodec must not be called concurrently with Retrieve.
+func (c *Libp2pClient) SetZstdCodec(zc *ZstdCodec) {
+	c.zstd = zc
+}
+
 func (c *Libp2pClient) Retrieve(
 	ctx context.Context,
 	ai libp2ppeer.AddrInfo,
 	dataID string,
 ) ([]transaction.Tx, error) {
+	txs, _, err := c.RetrieveEncoded(ctx, ai, dataID)
+	return txs, err
+}
+
+// RetrieveEncoded is like [*Libp2pClient.Retrieve],
+// but it also returns the encoded data as read from the peer,
+// so that the caller can save it to a [gcstore.BlockDataStore].
+func (c *Libp2pClient) RetrieveEncoded(
+	ctx context.Context,
+	ai libp2ppeer.AddrInfo,
+	dataID string,
+) ([]transaction.Tx, []byte, error) {
 	dec, err := NewBlockDataDecoder(dataID, c.decoder)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make block data decoder: %w", err)
+		return nil, nil, fmt.Errorf("failed to make block data decoder: %w", err)
 	}
+	dec.SetZstdCodec(c.zstd)
 
 	// Ensure we have a connection.
 	if err := c.h.Connect(ctx, ai); err != nil {
-		return nil, fmt.Errorf("failed to connect to peer: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to peer: %w", err)
 	}
 
 	// Open the stream to the peer.
 	s, err := c.h.NewStream(ctx, ai.ID, libp2pprotocol.ID(ProposedBlockDataV1Prefix+dataID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to open stream to peer: %w", err)
+		return nil, nil, fmt.Errorf("failed to open stream to peer: %w", err)
 	}
 	defer s.Close()
 
@@ -156,10 +472,12 @@ func (c *Libp2pClient) Retrieve(
 		// Okay to continue anyway?
 	}
 
-	txs, err := dec.Decode(s)
+	// Keep the bytes we decode from, as they are what we would serve to other peers.
+	var buf bytes.Buffer
+	txs, err := dec.Decode(io.TeeReader(s, &buf))
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode block data: %w", err)
+		return nil, nil, fmt.Errorf("failed to decode block data: %w", err)
 	}
 
-	return txs, nil
+	return txs, buf.Bytes(), nil
 }
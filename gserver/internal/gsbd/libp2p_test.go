@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"slices"
 	"strings"
 	"testing"
@@ -19,6 +21,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/std"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	"github.com/gordian-engine/gcosmos/gccodec"
+	"github.com/gordian-engine/gcosmos/gcstore/gcmemstore"
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
 	"github.com/gordian-engine/gordian/tm/tmcodec/tmjson"
@@ -133,6 +137,194 @@ func TestLibp2p_roundTrip(t *testing.T) {
 	})
 }
 
+func TestLibp2p_relay(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := gtest.NewLogger(t)
+	net, err := tmlibp2ptest.NewNetwork(ctx, log.With("sys", "net"), tmjson.MarshalCodec{})
+	require.NoError(t, err)
+	defer net.Wait()
+	defer cancel()
+
+	validator, err := net.Connect(ctx)
+	require.NoError(t, err)
+
+	sentry, err := net.Connect(ctx)
+	require.NoError(t, err)
+
+	client, err := net.Connect(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, net.Stabilize(ctx))
+
+	provider := gsbd.NewLibp2pProviderHost(log.With("sys", "validator"), validator.Host().Libp2pHost())
+	provider.SetRelayPeers([]libp2ppeer.ID{sentry.Host().Libp2pHost().ID()})
+
+	txs := []transaction.Tx{gservertest.NextHashOnlyTransaction(), gservertest.NextHashOnlyTransaction()}
+	res, err := provider.Provide(ctx, 1, 0, txs)
+	require.NoError(t, err)
+
+	// The provider's own location comes first, followed by the relay.
+	require.Len(t, res.Addrs, 2)
+	var relayAI libp2ppeer.AddrInfo
+	require.NoError(t, json.Unmarshal([]byte(res.Addrs[1].Addr), &relayAI))
+	require.Equal(t, sentry.Host().Libp2pHost().ID(), relayAI.ID)
+
+	// The sentry relays the data before it has finished fetching it.
+	ready := make(chan struct{})
+	req := &gsbd.BlockDataRequest{Ready: ready}
+	relay := gsbd.NewLibp2pProviderHost(log.With("sys", "sentry"), sentry.Host().Libp2pHost())
+	relay.Relay(res.DataID, req)
+
+	c := gsbd.NewLibp2pClient(
+		log.With("sys", "client"),
+		client.Host().Libp2pHost(),
+		gservertest.HashOnlyTransactionDecoder{},
+	)
+
+	type result struct {
+		Txs []transaction.Tx
+		Err error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		txs, err := c.Retrieve(ctx, relayAI, res.DataID)
+		resCh <- result{Txs: txs, Err: err}
+	}()
+
+	// The client is blocked until the relayed data is ready.
+	gtest.NotSendingSoon(t, resCh)
+
+	req.Transactions = txs
+	req.EncodedTransactions = bytes.Clone(res.Encoded)
+	close(ready)
+
+	r := gtest.ReceiveSoon(t, resCh)
+	require.NoError(t, r.Err)
+	require.Equal(t, txs, r.Txs)
+}
+
+func TestLibp2p_pruneHandlers(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := gtest.NewLogger(t)
+	net, err := tmlibp2ptest.NewNetwork(ctx, log.With("sys", "net"), tmjson.MarshalCodec{})
+	require.NoError(t, err)
+	defer net.Wait()
+	defer cancel()
+
+	host, err := net.Connect(ctx)
+	require.NoError(t, err)
+
+	client, err := net.Connect(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, net.Stabilize(ctx))
+
+	provider := gsbd.NewLibp2pProviderHost(log.With("sys", "host"), host.Host().Libp2pHost())
+
+	txs1 := []transaction.Tx{gservertest.NextHashOnlyTransaction()}
+	res1, err := provider.Provide(ctx, 1, 0, txs1)
+	require.NoError(t, err)
+
+	txs2 := []transaction.Tx{gservertest.NextHashOnlyTransaction()}
+	res2, err := provider.Provide(ctx, 2, 0, txs2)
+	require.NoError(t, err)
+
+	var ai libp2ppeer.AddrInfo
+	require.NoError(t, json.Unmarshal([]byte(res1.Addrs[0].Addr), &ai))
+	c := gsbd.NewLibp2pClient(
+		log.With("sys", "client"),
+		client.Host().Libp2pHost(),
+		gservertest.HashOnlyTransactionDecoder{},
+	)
+
+	// Still within the grace period after finalizing height 1.
+	provider.PruneHandlers(gsbd.HandlerGraceHeights)
+	gotTxs, err := c.Retrieve(ctx, ai, res1.DataID)
+	require.NoError(t, err)
+	require.Equal(t, txs1, gotTxs)
+
+	// Now height 1 is out of the grace period.
+	provider.PruneHandlers(1 + gsbd.HandlerGraceHeights)
+	_, err = c.Retrieve(ctx, ai, res1.DataID)
+	require.Error(t, err)
+
+	// Height 2 is still served.
+	gotTxs, err = c.Retrieve(ctx, ai, res2.DataID)
+	require.NoError(t, err)
+	require.Equal(t, txs2, gotTxs)
+
+	// With a block data store, the pruned data is served from the store.
+	bds := gcmemstore.NewBlockDataStore()
+	require.NoError(t, bds.SaveBlockData(ctx, 1, res1.DataID, res1.Encoded))
+	provider.SetBlockDataStore(ctx, bds)
+
+	gotTxs, err = c.Retrieve(ctx, ai, res1.DataID)
+	require.NoError(t, err)
+	require.Equal(t, txs1, gotTxs)
+
+	// But the fallback does not take over data that is still held in memory.
+	gotTxs, err = c.Retrieve(ctx, ai, res2.DataID)
+	require.NoError(t, err)
+	require.Equal(t, txs2, gotTxs)
+}
+
+func TestLibp2p_httpPublishAsync(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := gtest.NewLogger(t)
+	net, err := tmlibp2ptest.NewNetwork(ctx, log.With("sys", "net"), tmjson.MarshalCodec{})
+	require.NoError(t, err)
+	defer net.Wait()
+	defer cancel()
+
+	host, err := net.Connect(ctx)
+	require.NoError(t, err)
+
+	// The upload server blocks every upload until the test releases it.
+	bs := &blobServer{blobs: map[string][]byte{}}
+	release := make(chan struct{})
+	uploaded := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			<-release
+			defer func() { uploaded <- struct{}{} }()
+		}
+		bs.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	provider := gsbd.NewLibp2pProviderHost(log.With("sys", "host"), host.Host().Libp2pHost())
+	provider.SetHTTPPublisher(gsbd.NewHTTPPublisher(srv.Client(), srv.URL, ""))
+
+	// Provide returns without waiting for the upload,
+	// and already includes the HTTP location.
+	txs := []transaction.Tx{gservertest.NextHashOnlyTransaction()}
+	res, err := provider.Provide(ctx, 1, 0, txs)
+	require.NoError(t, err)
+	require.Equal(t, gsbd.Location{Scheme: gsbd.HTTPScheme, Addr: srv.URL}, res.Addrs[len(res.Addrs)-1])
+
+	// Then the upload completes in the background.
+	close(release)
+	_ = gtest.ReceiveSoon(t, uploaded)
+
+	c := gsbd.NewHTTPClient(srv.Client(), gservertest.HashOnlyTransactionDecoder{}, 0)
+	c.AllowPrivateAddresses()
+	gotTxs, _, err := c.Retrieve(ctx, srv.URL, res.DataID)
+	require.NoError(t, err)
+	require.Equal(t, txs, gotTxs)
+}
+
 func TestLibp2p_errors(t *testing.T) {
 	t.Parallel()
 
@@ -48,4 +48,12 @@ const (
 	InvalidScheme Scheme = 0
 
 	Libp2pScheme Scheme = 1
+
+	// The Addr of an HTTPScheme location is an HTTP or HTTPS base URL;
+	// the data is at the URL returned by [HTTPURL].
+	HTTPScheme Scheme = 2
+
+	// The Addr of an ErasureScheme location is a JSON-encoded [ErasureLocation],
+	// describing the shards of the data and the validators holding them.
+	ErasureScheme Scheme = 3
 )
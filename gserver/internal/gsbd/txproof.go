@@ -0,0 +1,111 @@
+package gsbd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/gordian-engine/gcosmos/gcstore"
+)
+
+// TxProof proves that a transaction is included in a block's data,
+// by a Merkle proof from the transaction hash
+// to the transactions hash in the block's data ID.
+// Only data IDs with the [MerkleTxsHash] scheme support proofs.
+type TxProof struct {
+	// The data ID of the block, as committed in the block header.
+	DataID string
+
+	// The Merkle proof from the transaction hash to the root in DataID.
+	// Proof.Index is the index of the transaction in the block.
+	Proof merkle.Proof
+}
+
+// ProveTx returns the proof that txs[idx] is included
+// in block data whose data ID is dataID.
+func ProveTx(dataID string, txs []transaction.Tx, idx int) TxProof {
+	if idx < 0 || idx >= len(txs) {
+		panic(fmt.Errorf("BUG: transaction index %d out of range for %d transactions", idx, len(txs)))
+	}
+
+	_, proofs := merkle.ProofsFromByteSlices(txHashes(txs))
+	return TxProof{
+		DataID: dataID,
+		Proof:  *proofs[idx],
+	}
+}
+
+// RootHash returns the transactions hash in p's data ID,
+// which is the root of p's Merkle proof.
+func (p TxProof) RootHash() ([]byte, error) {
+	_, _, _, _, txsHash, scheme, err := parseDataID(p.DataID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data ID: %w", err)
+	}
+	if scheme != MerkleTxsHash {
+		return nil, ErrNoTxProofs
+	}
+	return txsHash[:], nil
+}
+
+// Verify reports an error if p does not prove
+// that the transaction with hash txHash is included in the block data.
+func (p TxProof) Verify(txHash []byte) error {
+	root, err := p.RootHash()
+	if err != nil {
+		return err
+	}
+	return p.Proof.Verify(root, txHash)
+}
+
+// ErrNoTxProofs is returned when proving a transaction in block data
+// whose data ID does not have the [MerkleTxsHash] scheme.
+var ErrNoTxProofs = errors.New(
+	"transaction proofs require data IDs with the " + MerkleTxsHashName + " txs hash scheme",
+)
+
+// LoadTxProof loads the block data at the given height from bds,
+// and returns the proof of inclusion for the transaction with hash txHash.
+//
+// The zstd codec is only required if the chain compresses block data with zstd.
+func LoadTxProof(
+	ctx context.Context,
+	bds gcstore.BlockDataStore,
+	height uint64,
+	txHash []byte,
+	decoder transaction.Codec[transaction.Tx],
+	zc *ZstdCodec,
+) (TxProof, error) {
+	dataID, data, err := bds.LoadBlockDataByHeight(ctx, height, nil)
+	if err != nil {
+		return TxProof{}, fmt.Errorf("failed to load block data at height %d: %w", height, err)
+	}
+
+	if s, err := DataIDTxsHashScheme(dataID); err != nil {
+		return TxProof{}, fmt.Errorf("failed to parse data ID: %w", err)
+	} else if s != MerkleTxsHash {
+		return TxProof{}, ErrNoTxProofs
+	}
+
+	dec, err := NewBlockDataDecoder(dataID, decoder)
+	if err != nil {
+		return TxProof{}, fmt.Errorf("failed to make block data decoder: %w", err)
+	}
+	dec.SetZstdCodec(zc)
+
+	txs, err := dec.Decode(bytes.NewReader(data))
+	if err != nil {
+		return TxProof{}, fmt.Errorf("failed to decode block data at height %d: %w", height, err)
+	}
+
+	for i, tx := range txs {
+		if h := tx.Hash(); bytes.Equal(h[:], txHash) {
+			return ProveTx(dataID, txs, i), nil
+		}
+	}
+
+	return TxProof{}, errors.New("transaction not found in block data")
+}
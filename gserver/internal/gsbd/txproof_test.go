@@ -0,0 +1,112 @@
+package gsbd_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"cosmossdk.io/core/transaction"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/gordian-engine/gcosmos/gcstore/gcmemstore"
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTxProof(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	for _, nTxs := range []int{1, 2, 5} {
+		t.Run(fmt.Sprintf("%d txs", nTxs), func(t *testing.T) {
+			t.Parallel()
+
+			txs := make([]transaction.Tx, nTxs)
+			for i := range txs {
+				txs[i] = gservertest.NextHashOnlyTransaction()
+			}
+
+			var buf bytes.Buffer
+			sz, err := gsbd.EncodeBlockData(&buf, txs)
+			require.NoError(t, err)
+			dataID := gsbd.MerkleTxsHash.DataID(3, 0, uint32(sz), txs)
+
+			bds := gcmemstore.NewBlockDataStore()
+			require.NoError(t, bds.SaveBlockData(ctx, 3, dataID, buf.Bytes()))
+
+			for i, tx := range txs {
+				h := tx.Hash()
+				p, err := gsbd.LoadTxProof(ctx, bds, 3, h[:], gservertest.HashOnlyTransactionDecoder{}, nil)
+				require.NoError(t, err)
+
+				require.Equal(t, dataID, p.DataID)
+				require.Equal(t, int64(i), p.Proof.Index)
+				require.Equal(t, int64(nTxs), p.Proof.Total)
+				require.NoError(t, p.Verify(h[:]))
+
+				other := gservertest.NextHashOnlyTransaction().Hash()
+				require.Error(t, p.Verify(other[:]))
+			}
+
+			missing := gservertest.NextHashOnlyTransaction().Hash()
+			_, err = gsbd.LoadTxProof(ctx, bds, 3, missing[:], gservertest.HashOnlyTransactionDecoder{}, nil)
+			require.ErrorContains(t, err, "not found")
+		})
+	}
+}
+
+func TestLoadTxProof_blake2b(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	txs := []transaction.Tx{gservertest.NextHashOnlyTransaction()}
+
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+	dataID := gsbd.Blake2bTxsHash.DataID(3, 0, uint32(sz), txs)
+
+	bds := gcmemstore.NewBlockDataStore()
+	require.NoError(t, bds.SaveBlockData(ctx, 3, dataID, buf.Bytes()))
+
+	h := txs[0].Hash()
+	_, err = gsbd.LoadTxProof(ctx, bds, 3, h[:], gservertest.HashOnlyTransactionDecoder{}, nil)
+	require.ErrorIs(t, err, gsbd.ErrNoTxProofs)
+
+	_, err = gsbd.ProveTx(dataID, txs, 0).RootHash()
+	require.ErrorIs(t, err, gsbd.ErrNoTxProofs)
+}
+
+func TestProveTx_cometCompatible(t *testing.T) {
+	t.Parallel()
+
+	// SDK transaction hashes are the SHA-256 hash of the transaction bytes,
+	// as in Comet.
+	cmtTxs := cmttypes.Txs{[]byte("a"), []byte("bb"), []byte("ccc")}
+	txs := make([]transaction.Tx, len(cmtTxs))
+	for i, b := range cmtTxs {
+		txs[i] = gservertest.NewRawHashOnlyTransaction(sha256.Sum256(b))
+	}
+
+	root := gsbd.MerkleTxsHash.TxsHash(txs)
+	require.Equal(t, cmtTxs.Hash(), root[:])
+
+	dataID := gsbd.MerkleTxsHash.DataID(1, 0, 1, txs)
+	for i, b := range cmtTxs {
+		p := gsbd.ProveTx(dataID, txs, i)
+
+		rootHash, err := p.RootHash()
+		require.NoError(t, err)
+
+		cmtProof := cmttypes.TxProof{
+			RootHash: rootHash,
+			Data:     b,
+			Proof:    p.Proof,
+		}
+		require.NoError(t, cmtProof.Validate(cmtTxs.Hash()))
+	}
+}
@@ -0,0 +1,228 @@
+package gsbd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression names, for selecting the compression of block data in configuration.
+// Snappy is the default; zstd is used when a [ZstdCodec] is configured.
+const (
+	SnappyCompressionName = "snappy"
+	ZstdCompressionName   = "zstd"
+)
+
+// ZstdCodec compresses and decompresses block data with zstd,
+// optionally primed with a dictionary built by [TrainZstdDictionary].
+//
+// A dictionary trained on historical transactions
+// greatly improves compression of the small, repetitive payloads typical of block data.
+// Every node must use the same dictionary,
+// so it is pinned in genesis rather than configured per node.
+//
+// A ZstdCodec is safe for concurrent use.
+type ZstdCodec struct {
+	enc *zstd.Encoder
+	dec *zstd.Decoder
+
+	dictID uint32
+}
+
+// NewZstdCodec returns a new ZstdCodec using the given dictionary,
+// which may be empty to compress without a dictionary.
+func NewZstdCodec(dictionary []byte) (*ZstdCodec, error) {
+	eOpts := []zstd.EOption{
+		// Proposed block data is small enough that concurrency within one block doesn't help,
+		// and we only ever use EncodeAll.
+		zstd.WithEncoderConcurrency(1),
+	}
+	dOpts := []zstd.DOption{
+		zstd.WithDecoderConcurrency(0),
+
+		// Never let a peer make us allocate past the expected size.
+		zstd.WithDecodeAllCapLimit(true),
+	}
+
+	var dictID uint32
+	if len(dictionary) > 0 {
+		d, err := zstd.InspectDictionary(dictionary)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zstd dictionary: %w", err)
+		}
+		dictID = d.ID()
+
+		eOpts = append(eOpts, zstd.WithEncoderDict(dictionary))
+		dOpts = append(dOpts, zstd.WithDecoderDicts(dictionary))
+	}
+
+	enc, err := zstd.NewWriter(nil, eOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+	dec, err := zstd.NewReader(nil, dOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+
+	return &ZstdCodec{
+		enc: enc,
+		dec: dec,
+
+		dictID: dictID,
+	}, nil
+}
+
+// DictionaryID returns the ID of the codec's dictionary,
+// or zero if the codec has no dictionary.
+func (c *ZstdCodec) DictionaryID() uint32 {
+	return c.dictID
+}
+
+// compress returns the zstd-compressed form of b.
+func (c *ZstdCodec) compress(b []byte) []byte {
+	return c.enc.EncodeAll(b, nil)
+}
+
+// decompress returns the decompressed form of the single zstd frame in cBuf,
+// which must decompress to exactly uSize bytes.
+func (c *ZstdCodec) decompress(cBuf []byte, uSize int) ([]byte, error) {
+	// Check the declared size before allocating anything,
+	// just like we check the snappy decoded length.
+	var h zstd.Header
+	if err := h.Decode(cBuf); err != nil {
+		return nil, fmt.Errorf("failed to read zstd frame header: %w", err)
+	}
+	if !h.HasFCS || h.FrameContentSize != uint64(uSize) {
+		return nil, fmt.Errorf(
+			"zstd frame content size %d (present=%t) differed from expected size %d",
+			h.FrameContentSize, h.HasFCS, uSize,
+		)
+	}
+	if h.DictionaryID != c.dictID {
+		return nil, fmt.Errorf(
+			"zstd frame uses dictionary %d, but configured dictionary is %d",
+			h.DictionaryID, c.dictID,
+		)
+	}
+
+	// The cap limit on the decoder ensures that a frame lying about its size
+	// fails instead of growing the output.
+	u, err := c.dec.DecodeAll(cBuf, make([]byte, 0, uSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode zstd data: %w", err)
+	}
+	if len(u) != uSize {
+		return nil, fmt.Errorf(
+			"decoded length %d differed from expected length %d",
+			len(u), uSize,
+		)
+	}
+	return u, nil
+}
+
+// DefaultZstdDictionarySize is a reasonable maximum size
+// for dictionaries built by [TrainZstdDictionary].
+const DefaultZstdDictionarySize = 64 * 1024
+
+// TrainZstdDictionary builds a zstd dictionary of at most maxSize bytes,
+// from encoded block data as written by [EncodeBlockData]
+// and as saved in a [gcstore.BlockDataStore].
+//
+// Each element of encoded is decompressed and used as one sample,
+// so the dictionary matches what is compressed when proposing blocks.
+// If any of the block data is already compressed with zstd,
+// zc must be the codec it was compressed with;
+// otherwise zc may be nil.
+func TrainZstdDictionary(encoded [][]byte, zc *ZstdCodec, maxSize int) ([]byte, error) {
+	if len(encoded) == 0 {
+		return nil, errors.New("no block data to train on")
+	}
+
+	samples := make([][]byte, 0, len(encoded))
+	for i, b := range encoded {
+		if len(b) == 0 {
+			// Blocks without transactions have no data.
+			continue
+		}
+
+		u, err := decompressBlockData(b, zc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress block data at index %d: %w", i, err)
+		}
+		samples = append(samples, u)
+	}
+	if len(samples) == 0 {
+		return nil, errors.New("all block data was empty")
+	}
+
+	d, err := dict.BuildZstdDict(samples, dict.Options{
+		MaxDictSize: maxSize,
+
+		// The default from the compress package's dictionary builder command.
+		HashBytes: 6,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build zstd dictionary: %w", err)
+	}
+	return d, nil
+}
+
+// maxTrainingSampleSize bounds the allocation for each sample in [TrainZstdDictionary],
+// since there is no data ID to check the declared sizes against.
+// It is far larger than any reasonable block.
+const maxTrainingSampleSize = 64 * 1024 * 1024
+
+// decompressBlockData returns the serialized transactions in the encoded block data b,
+// without decoding or verifying them.
+// Unlike the [BlockDataDecoder], there is no data ID to check sizes against,
+// so this is only suitable for block data that was already verified,
+// such as the contents of a block data store.
+func decompressBlockData(b []byte, zc *ZstdCodec) ([]byte, error) {
+	r := bufio.NewReader(bytes.NewReader(b[1:]))
+	size, err := binary.ReadVarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read size: %w", err)
+	}
+	data := b[len(b)-r.Buffered():]
+	if size <= 0 || size != int64(len(data)) {
+		return nil, fmt.Errorf("invalid size %d for %d remaining bytes", size, len(data))
+	}
+
+	switch b[0] {
+	case uncompressedHeader, uncompressedBinaryHeader:
+		return data, nil
+	case snappyHeader, snappyBinaryHeader:
+		uSize, err := snappy.DecodedLen(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read decoded length from compressed data: %w", err)
+		}
+		if uSize > maxTrainingSampleSize {
+			return nil, fmt.Errorf("decoded length %d exceeds maximum %d", uSize, maxTrainingSampleSize)
+		}
+		return snappy.Decode(nil, data)
+	case zstdHeader, zstdBinaryHeader:
+		if zc == nil {
+			return nil, errors.New("zstd-compressed block data requires a zstd codec")
+		}
+		var h zstd.Header
+		if err := h.Decode(data); err != nil {
+			return nil, fmt.Errorf("failed to read zstd frame header: %w", err)
+		}
+		if h.FrameContentSize > maxTrainingSampleSize {
+			return nil, fmt.Errorf(
+				"zstd frame content size %d exceeds maximum %d",
+				h.FrameContentSize, maxTrainingSampleSize,
+			)
+		}
+		return zc.decompress(data, int(h.FrameContentSize))
+	default:
+		return nil, fmt.Errorf("unrecognized header byte %x", b[0])
+	}
+}
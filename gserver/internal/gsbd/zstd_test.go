@@ -0,0 +1,141 @@
+package gsbd_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/stretchr/testify/require"
+)
+
+// hashOnlyBlock returns n hash-only transactions
+// sharing a long common prefix, like real transactions of the same message type,
+// with the remaining bytes derived from first.
+func hashOnlyBlock(first uint64, n int) []transaction.Tx {
+	txs := make([]transaction.Tx, n)
+	for i := range txs {
+		var h [gservertest.HashSize]byte
+		copy(h[:], "/cosmos.bank.v1beta1.MsgSend")
+		binary.BigEndian.PutUint32(h[gservertest.HashSize-4:], uint32(first+uint64(i))*2654435761)
+		txs[i] = gservertest.NewRawHashOnlyTransaction(h)
+	}
+	return txs
+}
+
+func TestBlockDataDecoder_zstd(t *testing.T) {
+	t.Parallel()
+
+	// Train a dictionary on some historical snappy-encoded block data.
+	var history [][]byte
+	for i := range 64 {
+		var buf bytes.Buffer
+		_, err := gsbd.EncodeBlockDataWithFormat(&buf, gsbd.BinaryFormat, hashOnlyBlock(uint64(100*i), 20))
+		require.NoError(t, err)
+		history = append(history, buf.Bytes())
+	}
+	dict, err := gsbd.TrainZstdDictionary(history, nil, gsbd.DefaultZstdDictionarySize)
+	require.NoError(t, err)
+
+	dictCodec, err := gsbd.NewZstdCodec(dict)
+	require.NoError(t, err)
+	require.NotZero(t, dictCodec.DictionaryID())
+
+	plainCodec, err := gsbd.NewZstdCodec(nil)
+	require.NoError(t, err)
+	require.Zero(t, plainCodec.DictionaryID())
+
+	for _, f := range []gsbd.Format{gsbd.JSONFormat, gsbd.BinaryFormat} {
+		for name, zc := range map[string]*gsbd.ZstdCodec{
+			"with dictionary":    dictCodec,
+			"without dictionary": plainCodec,
+		} {
+			t.Run(f.String()+" "+name, func(t *testing.T) {
+				t.Parallel()
+
+				txs := hashOnlyBlock(12345, 10)
+
+				var buf bytes.Buffer
+				sz, err := gsbd.EncodeBlockDataWithZstd(&buf, f, zc, txs)
+				require.NoError(t, err)
+				encoded := buf.Bytes()
+
+				dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+				dec, err := gsbd.NewBlockDataDecoder(dataID, gservertest.HashOnlyTransactionDecoder{})
+				require.NoError(t, err)
+
+				// Without the codec, the data cannot be decoded.
+				_, err = dec.Decode(bytes.NewReader(encoded))
+				require.Error(t, err)
+
+				dec.SetZstdCodec(zc)
+				gotTxs, err := dec.Decode(bytes.NewReader(encoded))
+				require.NoError(t, err)
+				require.Equal(t, txs, gotTxs)
+			})
+		}
+	}
+
+	t.Run("dictionary improves compression", func(t *testing.T) {
+		t.Parallel()
+
+		// Small blocks benefit the most from a dictionary.
+		txs := hashOnlyBlock(7000, 3)
+
+		var snappyBuf, plainBuf, dictBuf bytes.Buffer
+		_, err := gsbd.EncodeBlockDataWithFormat(&snappyBuf, gsbd.BinaryFormat, txs)
+		require.NoError(t, err)
+		_, err = gsbd.EncodeBlockDataWithZstd(&plainBuf, gsbd.BinaryFormat, plainCodec, txs)
+		require.NoError(t, err)
+		_, err = gsbd.EncodeBlockDataWithZstd(&dictBuf, gsbd.BinaryFormat, dictCodec, txs)
+		require.NoError(t, err)
+
+		require.Less(t, dictBuf.Len(), snappyBuf.Len())
+		require.Less(t, dictBuf.Len(), plainBuf.Len())
+	})
+
+	t.Run("mismatched dictionary", func(t *testing.T) {
+		t.Parallel()
+
+		txs := hashOnlyBlock(12345, 10)
+
+		var buf bytes.Buffer
+		sz, err := gsbd.EncodeBlockDataWithZstd(&buf, gsbd.BinaryFormat, dictCodec, txs)
+		require.NoError(t, err)
+
+		dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+		dec, err := gsbd.NewBlockDataDecoder(dataID, gservertest.HashOnlyTransactionDecoder{})
+		require.NoError(t, err)
+
+		dec.SetZstdCodec(plainCodec)
+		_, err = dec.Decode(&buf)
+		require.ErrorContains(t, err, "dictionary")
+	})
+}
+
+func TestTrainZstdDictionary_zstdInput(t *testing.T) {
+	t.Parallel()
+
+	plainCodec, err := gsbd.NewZstdCodec(nil)
+	require.NoError(t, err)
+
+	// Retraining on data that is already zstd-compressed requires its codec.
+	var history [][]byte
+	for i := range 64 {
+		var buf bytes.Buffer
+		_, err := gsbd.EncodeBlockDataWithZstd(&buf, gsbd.BinaryFormat, plainCodec, hashOnlyBlock(uint64(100*i), 20))
+		require.NoError(t, err)
+		history = append(history, buf.Bytes())
+	}
+
+	_, err = gsbd.TrainZstdDictionary(history, nil, gsbd.DefaultZstdDictionarySize)
+	require.Error(t, err)
+
+	dict, err := gsbd.TrainZstdDictionary(history, plainCodec, gsbd.DefaultZstdDictionarySize)
+	require.NoError(t, err)
+
+	_, err = gsbd.NewZstdCodec(dict)
+	require.NoError(t, err)
+}
@@ -0,0 +1,41 @@
+// Package gscheme contains the production hash and signature schemes for gcosmos,
+// replacing the human-readable placeholder schemes from tmconsensustest.
+//
+// Each scheme is identified by a versioned name,
+// recorded in the chain's genesis so that every node computes identical hashes and sign bytes.
+// A scheme's encoding must never change once its name is in use;
+// any change requires a new name.
+//
+// A genesis without scheme names uses the placeholder schemes, named "simple",
+// so that chains started before the versioned schemes existed keep working.
+// New chains should name the versioned schemes in genesis.
+//
+// The signature proof scheme, named separately in genesis,
+// selects between one ed25519 signature per validator
+// and aggregated BLS signatures (see [ProofSchemeByName]).
+//
+// # Canonical encoding, version 1
+//
+// [HashSchemeV1] and [SignatureSchemeV1] share one encoding:
+//
+//   - Every message begins with a domain separator, encoded as a byte string,
+//     such as "gcosmos/v1/header" or "gcosmos/v1/prevote".
+//     The separator keeps a signature or hash for one kind of message
+//     from being valid for another kind.
+//   - Fixed-width integers are big-endian: 8 bytes for heights and vote powers,
+//     and 4 bytes for rounds and counts.
+//   - Byte strings are prefixed with their length as an unsigned varint.
+//   - Optional byte strings, such as annotations and nil-vote block hashes,
+//     are prefixed with a single byte: 0 if absent, 1 if present,
+//     followed by the byte string when present.
+//     Absent and empty are distinct.
+//   - Collections without an inherent order, such as the signatures in a commit proof,
+//     are sorted bytewise before encoding, and prefixed with their count.
+//
+// Sign bytes additionally include the chain ID immediately after the domain separator,
+// so that a signature from one chain cannot be replayed on another chain
+// that shares the same validator keys.
+//
+// [HashSchemeV1] hashes the encoded message with SHA-256.
+// Signatures are computed over the encoded message directly.
+package gscheme
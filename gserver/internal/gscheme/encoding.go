@@ -0,0 +1,123 @@
+package gscheme
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+
+	"github.com/gordian-engine/gordian/gcrypto"
+)
+
+// encoder writes the version 1 canonical encoding described in the package documentation.
+//
+// Writes to the underlying buffer cannot fail,
+// so the encoder methods do not return errors.
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) domain(d string) {
+	e.bytes([]byte(d))
+}
+
+func (e *encoder) uint64(n uint64) {
+	e.buf.Write(binary.BigEndian.AppendUint64(nil, n))
+}
+
+func (e *encoder) uint32(n uint32) {
+	e.buf.Write(binary.BigEndian.AppendUint32(nil, n))
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.buf.Write(binary.AppendUvarint(nil, uint64(len(b))))
+	e.buf.Write(b)
+}
+
+func (e *encoder) optionalBytes(b []byte) {
+	if b == nil {
+		e.buf.WriteByte(0)
+		return
+	}
+	e.buf.WriteByte(1)
+	e.bytes(b)
+}
+
+// voteTarget encodes a block hash, where the empty string indicates a nil vote.
+func (e *encoder) voteTarget(blockHash string) {
+	if blockHash == "" {
+		e.optionalBytes(nil)
+		return
+	}
+	e.optionalBytes([]byte(blockHash))
+}
+
+// sparseSignatures encodes the proofs of a commit proof,
+// with block hashes in sorted order and the signatures for each block sorted by key ID.
+func (e *encoder) sparseSignatures(proofs map[string][]gcrypto.SparseSignature) {
+	hashes := make([]string, 0, len(proofs))
+	for h := range proofs {
+		hashes = append(hashes, h)
+	}
+	slices.Sort(hashes)
+
+	e.uint32(uint32(len(hashes)))
+	for _, h := range hashes {
+		e.voteTarget(h)
+
+		sigs := slices.Clone(proofs[h])
+		slices.SortFunc(sigs, func(a, b gcrypto.SparseSignature) int {
+			if c := bytes.Compare(a.KeyID, b.KeyID); c != 0 {
+				return c
+			}
+			return bytes.Compare(a.Sig, b.Sig)
+		})
+
+		e.uint32(uint32(len(sigs)))
+		for _, sig := range sigs {
+			e.bytes(sig.KeyID)
+			e.bytes(sig.Sig)
+		}
+	}
+}
+
+func (e *encoder) writeTo(w io.Writer) (int, error) {
+	return w.Write(e.buf.Bytes())
+}
+
+// errTruncated is returned by decoder methods when the input ends early.
+var errTruncated = errors.New("truncated input")
+
+// decoder reads the version 1 canonical encoding written by [encoder].
+type decoder struct {
+	b []byte
+}
+
+func (d *decoder) uint64() (uint64, error) {
+	if len(d.b) < 8 {
+		return 0, errTruncated
+	}
+	n := binary.BigEndian.Uint64(d.b)
+	d.b = d.b[8:]
+	return n, nil
+}
+
+func (d *decoder) uint32() (uint32, error) {
+	if len(d.b) < 4 {
+		return 0, errTruncated
+	}
+	n := binary.BigEndian.Uint32(d.b)
+	d.b = d.b[4:]
+	return n, nil
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	n, sz := binary.Uvarint(d.b)
+	if sz <= 0 || n > uint64(len(d.b)-sz) {
+		return nil, errTruncated
+	}
+	b := d.b[sz : sz+int(n)]
+	d.b = d.b[sz+int(n):]
+	return b, nil
+}
@@ -0,0 +1,84 @@
+package gscheme
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+)
+
+// HashSchemeV1Name is the genesis name of [HashSchemeV1].
+const HashSchemeV1Name = "gcosmos-sha256-v1"
+
+// HashSchemeV1 is the version 1 [tmconsensus.HashScheme],
+// hashing the canonical encoding with SHA-256.
+type HashSchemeV1 struct{}
+
+var _ tmconsensus.HashScheme = HashSchemeV1{}
+
+// Block hashes every field of h except h.Hash.
+func (HashSchemeV1) Block(h tmconsensus.Header) ([]byte, error) {
+	var e encoder
+	e.domain("gcosmos/v1/header")
+
+	e.bytes(h.PrevBlockHash)
+	e.uint64(h.Height)
+
+	e.uint32(h.PrevCommitProof.Round)
+	e.bytes([]byte(h.PrevCommitProof.PubKeyHash))
+	e.sparseSignatures(h.PrevCommitProof.Proofs)
+
+	e.bytes(h.ValidatorSet.PubKeyHash)
+	e.bytes(h.ValidatorSet.VotePowerHash)
+	e.bytes(h.NextValidatorSet.PubKeyHash)
+	e.bytes(h.NextValidatorSet.VotePowerHash)
+
+	e.bytes(h.DataID)
+	e.bytes(h.PrevAppStateHash)
+
+	e.optionalBytes(h.Annotations.User)
+	e.optionalBytes(h.Annotations.Driver)
+
+	return sum(&e), nil
+}
+
+// PubKeys hashes the type name and bytes of each key, in order.
+func (HashSchemeV1) PubKeys(keys []gcrypto.PubKey) ([]byte, error) {
+	if len(keys) == 0 {
+		panic(errors.New("BUG: HashScheme.PubKeys should never be called with zero keys"))
+	}
+
+	var e encoder
+	e.domain("gcosmos/v1/pubkeys")
+
+	e.uint32(uint32(len(keys)))
+	for _, k := range keys {
+		e.bytes([]byte(k.TypeName()))
+		e.bytes(k.PubKeyBytes())
+	}
+
+	return sum(&e), nil
+}
+
+// VotePowers hashes each vote power, in order.
+func (HashSchemeV1) VotePowers(pows []uint64) ([]byte, error) {
+	if len(pows) == 0 {
+		panic(errors.New("BUG: HashScheme.VotePowers should never be called with zero powers"))
+	}
+
+	var e encoder
+	e.domain("gcosmos/v1/votepowers")
+
+	e.uint32(uint32(len(pows)))
+	for _, p := range pows {
+		e.uint64(p)
+	}
+
+	return sum(&e), nil
+}
+
+func sum(e *encoder) []byte {
+	s := sha256.Sum256(e.buf.Bytes())
+	return s[:]
+}
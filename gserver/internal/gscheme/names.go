@@ -0,0 +1,88 @@
+package gscheme
+
+import (
+	"fmt"
+
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
+)
+
+// The schemes used when genesis does not name one.
+// These are the legacy schemes, so that existing chains keep working unchanged;
+// new chains must opt in to the versioned schemes through genesis.
+const (
+	DefaultHashSchemeName      = HashSchemeSimpleName
+	DefaultSignatureSchemeName = SignatureSchemeSimpleName
+	DefaultProofSchemeName     = ProofSchemeSimpleName
+)
+
+// HashSchemeByName returns the hash scheme with the given genesis name.
+func HashSchemeByName(name string) (tmconsensus.HashScheme, error) {
+	switch name {
+	case HashSchemeSimpleName:
+		return tmconsensustest.SimpleHashScheme{}, nil
+	case HashSchemeV1Name:
+		return HashSchemeV1{}, nil
+	default:
+		return nil, fmt.Errorf("unknown hash scheme %q", name)
+	}
+}
+
+// SignatureSchemeByName returns the signature scheme with the given genesis name,
+// bound to the given chain ID.
+func SignatureSchemeByName(name, chainID string) (tmconsensus.SignatureScheme, error) {
+	if chainID == "" {
+		return nil, fmt.Errorf("chain ID required for signature scheme %q", name)
+	}
+
+	switch name {
+	case SignatureSchemeSimpleName:
+		return SimpleSignatureScheme{}, nil
+	case SignatureSchemeV1Name:
+		return SignatureSchemeV1{ChainID: chainID}, nil
+	default:
+		return nil, fmt.Errorf("unknown signature scheme %q", name)
+	}
+}
+
+// SignedMessageKind is the kind of consensus message in sign bytes.
+type SignedMessageKind uint8
+
+const (
+	InvalidMessage SignedMessageKind = iota
+
+	ProposalMessage
+	PrevoteMessage
+	PrecommitMessage
+)
+
+// SignedMessage is the kind, height, and round of a consensus message,
+// as read from its sign bytes by a [SigningContentParser].
+type SignedMessage struct {
+	Kind   SignedMessageKind
+	Height uint64
+	Round  uint32
+}
+
+// SigningContentParser reads back the kind, height, and round of a message
+// from the sign bytes of a signature scheme.
+//
+// A remote signer uses it to check what it is asked to sign,
+// instead of trusting the height, round, and step declared by the validator.
+type SigningContentParser interface {
+	ParseSigningContent(b []byte) (SignedMessage, error)
+}
+
+// SigningContentParserByName returns the parser for the sign bytes
+// of the signature scheme with the given genesis name, bound to the given chain ID.
+func SigningContentParserByName(name, chainID string) (SigningContentParser, error) {
+	s, err := SignatureSchemeByName(name, chainID)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := s.(SigningContentParser)
+	if !ok {
+		panic(fmt.Errorf("BUG: signature scheme %q (%T) cannot parse its sign bytes", name, s))
+	}
+	return p, nil
+}
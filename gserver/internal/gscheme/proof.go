@@ -0,0 +1,49 @@
+package gscheme
+
+import (
+	"fmt"
+
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gcrypto/gblsminsig"
+)
+
+// Genesis names of the signature proof schemes,
+// which determine how votes are combined into prevote and precommit proofs.
+const (
+	// Proofs hold one ed25519 signature per validator,
+	// so they grow linearly with the validator set.
+	ProofSchemeSimpleName = "simple"
+
+	// Proofs hold aggregated BLS12-381 signatures
+	// (with public keys on G2 and signatures on G1),
+	// so a finalized commit proof is typically a single signature
+	// and a bit set of the validators who signed.
+	// Every validator must use a BLS key.
+	ProofSchemeBLSMinSigName = "bls-minsig"
+)
+
+// ProofSchemeByName returns the signature proof scheme with the given genesis name.
+func ProofSchemeByName(name string) (gcrypto.CommonMessageSignatureProofScheme, error) {
+	switch name {
+	case ProofSchemeSimpleName:
+		return gcrypto.SimpleCommonMessageSignatureProofScheme{}, nil
+	case ProofSchemeBLSMinSigName:
+		return gblsminsig.SignatureProofScheme{}, nil
+	default:
+		return nil, fmt.Errorf("unknown signature proof scheme %q", name)
+	}
+}
+
+// ValidatorKeyTypes returns the public key types, as names in a [gcrypto.Registry],
+// that validators may use with the named signature proof scheme.
+// The staking module rejects validators whose consensus key has any other type.
+func ValidatorKeyTypes(proofSchemeName string) ([]string, error) {
+	switch proofSchemeName {
+	case ProofSchemeSimpleName:
+		return []string{gcrypto.Ed25519PubKey{}.TypeName()}, nil
+	case ProofSchemeBLSMinSigName:
+		return []string{gblsminsig.PubKey{}.TypeName()}, nil
+	default:
+		return nil, fmt.Errorf("unknown signature proof scheme %q", proofSchemeName)
+	}
+}
@@ -0,0 +1,285 @@
+package gscheme_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gscheme"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/gcrypto/gblsminsig"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashSchemeV1_compliance(t *testing.T) {
+	tmconsensustest.TestHashSchemeCompliance(
+		t, gscheme.HashSchemeV1{}, gcrypto.SimpleCommonMessageSignatureProofScheme{}, nil,
+	)
+}
+
+// The encoding of a named scheme must never change,
+// so pin the exact bytes of a few messages.
+func TestSignatureSchemeV1_golden(t *testing.T) {
+	t.Parallel()
+
+	s := gscheme.SignatureSchemeV1{ChainID: "chain"}
+
+	for _, tc := range []struct {
+		name string
+		want string
+		fn   func() ([]byte, error)
+	}{
+		{
+			name: "prevote",
+			want: "1267636f736d6f732f76312f707265766f746505636861696e" +
+				"0000000000000003" + "00000001" + "01" + "0468617368",
+			fn: func() ([]byte, error) {
+				return tmconsensus.PrevoteSignBytes(tmconsensus.VoteTarget{Height: 3, Round: 1, BlockHash: "hash"}, s)
+			},
+		},
+		{
+			name: "nil precommit",
+			want: "1467636f736d6f732f76312f707265636f6d6d697405636861696e" +
+				"0000000000000003" + "00000001" + "00",
+			fn: func() ([]byte, error) {
+				return tmconsensus.PrecommitSignBytes(tmconsensus.VoteTarget{Height: 3, Round: 1}, s)
+			},
+		},
+		{
+			name: "proposal",
+			want: "1367636f736d6f732f76312f70726f706f73616c05636861696e" +
+				"0000000000000003" + "00000001" +
+				"0470726576" + "057374617465" + "0464617461" +
+				"010475736572" + "00",
+			fn: func() ([]byte, error) {
+				return tmconsensus.ProposalSignBytes(tmconsensus.Header{
+					Height:           3,
+					PrevBlockHash:    []byte("prev"),
+					PrevAppStateHash: []byte("state"),
+					DataID:           []byte("data"),
+				}, 1, tmconsensus.Annotations{User: []byte("user")}, s)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.fn()
+			require.NoError(t, err)
+			require.Equal(t, tc.want, hex.EncodeToString(got))
+		})
+	}
+}
+
+func TestSignatureSchemeV1_distinct(t *testing.T) {
+	t.Parallel()
+
+	s := gscheme.SignatureSchemeV1{ChainID: "chain"}
+	vt := tmconsensus.VoteTarget{Height: 3, Round: 1, BlockHash: "hash"}
+
+	prevote, err := tmconsensus.PrevoteSignBytes(vt, s)
+	require.NoError(t, err)
+	precommit, err := tmconsensus.PrecommitSignBytes(vt, s)
+	require.NoError(t, err)
+	require.NotEqual(t, prevote, precommit)
+
+	nilVT := vt
+	nilVT.BlockHash = ""
+	nilPrevote, err := tmconsensus.PrevoteSignBytes(nilVT, s)
+	require.NoError(t, err)
+	require.NotEqual(t, prevote, nilPrevote)
+
+	// The same vote on another chain must not produce the same sign bytes.
+	other := gscheme.SignatureSchemeV1{ChainID: "other"}
+	otherPrevote, err := tmconsensus.PrevoteSignBytes(vt, other)
+	require.NoError(t, err)
+	require.NotEqual(t, prevote, otherPrevote)
+
+	// Absent and empty annotations are distinct.
+	h := tmconsensus.Header{Height: 3, PrevBlockHash: []byte("prev"), DataID: []byte("data")}
+	absent, err := tmconsensus.ProposalSignBytes(h, 1, tmconsensus.Annotations{}, s)
+	require.NoError(t, err)
+	empty, err := tmconsensus.ProposalSignBytes(h, 1, tmconsensus.Annotations{Driver: []byte{}}, s)
+	require.NoError(t, err)
+	require.False(t, bytes.Equal(absent, empty))
+}
+
+func TestSignatureSchemeV1_ParseSigningContent(t *testing.T) {
+	t.Parallel()
+
+	s := gscheme.SignatureSchemeV1{ChainID: "chain"}
+	vt := tmconsensus.VoteTarget{Height: 3, Round: 1, BlockHash: "hash"}
+
+	prevote, err := tmconsensus.PrevoteSignBytes(vt, s)
+	require.NoError(t, err)
+	m, err := s.ParseSigningContent(prevote)
+	require.NoError(t, err)
+	require.Equal(t, gscheme.SignedMessage{Kind: gscheme.PrevoteMessage, Height: 3, Round: 1}, m)
+
+	precommit, err := tmconsensus.PrecommitSignBytes(tmconsensus.VoteTarget{Height: 4, Round: 2}, s)
+	require.NoError(t, err)
+	m, err = s.ParseSigningContent(precommit)
+	require.NoError(t, err)
+	require.Equal(t, gscheme.SignedMessage{Kind: gscheme.PrecommitMessage, Height: 4, Round: 2}, m)
+
+	proposal, err := tmconsensus.ProposalSignBytes(tmconsensus.Header{Height: 5}, 6, tmconsensus.Annotations{}, s)
+	require.NoError(t, err)
+	m, err = s.ParseSigningContent(proposal)
+	require.NoError(t, err)
+	require.Equal(t, gscheme.SignedMessage{Kind: gscheme.ProposalMessage, Height: 5, Round: 6}, m)
+
+	// Sign bytes for another chain are rejected.
+	_, err = gscheme.SignatureSchemeV1{ChainID: "other"}.ParseSigningContent(prevote)
+	require.Error(t, err)
+
+	// As are truncated or unrecognized sign bytes.
+	_, err = s.ParseSigningContent(prevote[:len(prevote)-8])
+	require.Error(t, err)
+	_, err = s.ParseSigningContent([]byte("PREVOTE:\nHeight=3\nRound=1\n"))
+	require.Error(t, err)
+}
+
+func TestSimpleSignatureScheme_ParseSigningContent(t *testing.T) {
+	t.Parallel()
+
+	s := gscheme.SimpleSignatureScheme{}
+
+	for _, tc := range []struct {
+		name string
+		kind gscheme.SignedMessageKind
+		sign func(*bytes.Buffer) error
+	}{
+		{
+			name: "proposal",
+			kind: gscheme.ProposalMessage,
+			sign: func(buf *bytes.Buffer) error {
+				_, err := s.WriteProposalSigningContent(buf, tmconsensus.Header{
+					Height: 3, DataID: []byte("data"),
+				}, 1, tmconsensus.Annotations{})
+				return err
+			},
+		},
+		{
+			name: "prevote",
+			kind: gscheme.PrevoteMessage,
+			sign: func(buf *bytes.Buffer) error {
+				_, err := s.WritePrevoteSigningContent(buf, tmconsensus.VoteTarget{
+					Height: 3, Round: 1, BlockHash: "block",
+				})
+				return err
+			},
+		},
+		{
+			name: "nil prevote",
+			kind: gscheme.PrevoteMessage,
+			sign: func(buf *bytes.Buffer) error {
+				_, err := s.WritePrevoteSigningContent(buf, tmconsensus.VoteTarget{Height: 3, Round: 1})
+				return err
+			},
+		},
+		{
+			name: "precommit",
+			kind: gscheme.PrecommitMessage,
+			sign: func(buf *bytes.Buffer) error {
+				_, err := s.WritePrecommitSigningContent(buf, tmconsensus.VoteTarget{
+					Height: 3, Round: 1, BlockHash: "block",
+				})
+				return err
+			},
+		},
+		{
+			name: "nil precommit",
+			kind: gscheme.PrecommitMessage,
+			sign: func(buf *bytes.Buffer) error {
+				_, err := s.WritePrecommitSigningContent(buf, tmconsensus.VoteTarget{Height: 3, Round: 1})
+				return err
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, tc.sign(&buf))
+
+			m, err := s.ParseSigningContent(buf.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, gscheme.SignedMessage{Kind: tc.kind, Height: 3, Round: 1}, m)
+		})
+	}
+
+	// Unrecognized or truncated sign bytes are rejected.
+	_, err := s.ParseSigningContent([]byte("VOTE:\nHeight=3\nRound=1\n"))
+	require.Error(t, err)
+	_, err = s.ParseSigningContent([]byte("PREVOTE:\nHeight=3\n"))
+	require.Error(t, err)
+	_, err = s.ParseSigningContent([]byte("PREVOTE:\nHeight=x\nRound=1\n"))
+	require.Error(t, err)
+
+	// Versioned sign bytes are not mistaken for the simple format.
+	var buf bytes.Buffer
+	_, err = gscheme.SignatureSchemeV1{ChainID: "chain"}.WritePrevoteSigningContent(
+		&buf, tmconsensus.VoteTarget{Height: 3, Round: 1},
+	)
+	require.NoError(t, err)
+	_, err = s.ParseSigningContent(buf.Bytes())
+	require.Error(t, err)
+}
+
+func TestSchemeByName(t *testing.T) {
+	t.Parallel()
+
+	// The defaults are the legacy schemes.
+	hs, err := gscheme.HashSchemeByName(gscheme.DefaultHashSchemeName)
+	require.NoError(t, err)
+	require.Equal(t, tmconsensustest.SimpleHashScheme{}, hs)
+
+	hs, err = gscheme.HashSchemeByName(gscheme.HashSchemeV1Name)
+	require.NoError(t, err)
+	require.Equal(t, gscheme.HashSchemeV1{}, hs)
+
+	_, err = gscheme.HashSchemeByName("unknown")
+	require.Error(t, err)
+
+	ss, err := gscheme.SignatureSchemeByName(gscheme.DefaultSignatureSchemeName, "chain")
+	require.NoError(t, err)
+	require.Equal(t, gscheme.SimpleSignatureScheme{}, ss)
+
+	ss, err = gscheme.SignatureSchemeByName(gscheme.SignatureSchemeV1Name, "chain")
+	require.NoError(t, err)
+	require.Equal(t, gscheme.SignatureSchemeV1{ChainID: "chain"}, ss)
+
+	_, err = gscheme.SignatureSchemeByName("unknown", "chain")
+	require.Error(t, err)
+
+	_, err = gscheme.SignatureSchemeByName(gscheme.DefaultSignatureSchemeName, "")
+	require.Error(t, err)
+
+	ps, err := gscheme.ProofSchemeByName(gscheme.DefaultProofSchemeName)
+	require.NoError(t, err)
+	require.Equal(t, gcrypto.SimpleCommonMessageSignatureProofScheme{}, ps)
+
+	ps, err = gscheme.ProofSchemeByName(gscheme.ProofSchemeBLSMinSigName)
+	require.NoError(t, err)
+	require.Equal(t, gblsminsig.SignatureProofScheme{}, ps)
+
+	_, err = gscheme.ProofSchemeByName("unknown")
+	require.Error(t, err)
+}
+
+func TestValidatorKeyTypes(t *testing.T) {
+	t.Parallel()
+
+	// The names must match the SDK key types reported in validator updates,
+	// as the driver decodes those updates through a gcrypto.Registry by type name.
+	types, err := gscheme.ValidatorKeyTypes(gscheme.ProofSchemeSimpleName)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ed25519"}, types)
+
+	types, err = gscheme.ValidatorKeyTypes(gscheme.ProofSchemeBLSMinSigName)
+	require.NoError(t, err)
+	require.Equal(t, []string{"bls-ms"}, types)
+
+	_, err = gscheme.ValidatorKeyTypes("unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package gscheme
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+)
+
+// SignatureSchemeV1Name is the genesis name of [SignatureSchemeV1].
+const SignatureSchemeV1Name = "gcosmos-v1"
+
+// SignatureSchemeV1 is the version 1 [tmconsensus.SignatureScheme].
+// Its sign bytes are the canonical encoding,
+// including the chain ID to prevent cross-chain replay.
+type SignatureSchemeV1 struct {
+	ChainID string
+}
+
+var (
+	_ tmconsensus.SignatureScheme = SignatureSchemeV1{}
+	_ SigningContentParser        = SignatureSchemeV1{}
+)
+
+func (s SignatureSchemeV1) WriteProposalSigningContent(
+	w io.Writer, h tmconsensus.Header, round uint32, pbAnnotations tmconsensus.Annotations,
+) (int, error) {
+	var e encoder
+	e.domain("gcosmos/v1/proposal")
+	e.bytes([]byte(s.ChainID))
+
+	e.uint64(h.Height)
+	e.uint32(round)
+	e.bytes(h.PrevBlockHash)
+	e.bytes(h.PrevAppStateHash)
+	e.bytes(h.DataID)
+
+	e.optionalBytes(pbAnnotations.User)
+	e.optionalBytes(pbAnnotations.Driver)
+
+	return e.writeTo(w)
+}
+
+func (s SignatureSchemeV1) WritePrevoteSigningContent(w io.Writer, vt tmconsensus.VoteTarget) (int, error) {
+	return s.writeVote(w, "gcosmos/v1/prevote", vt)
+}
+
+func (s SignatureSchemeV1) WritePrecommitSigningContent(w io.Writer, vt tmconsensus.VoteTarget) (int, error) {
+	return s.writeVote(w, "gcosmos/v1/precommit", vt)
+}
+
+func (s SignatureSchemeV1) writeVote(w io.Writer, domain string, vt tmconsensus.VoteTarget) (int, error) {
+	var e encoder
+	e.domain(domain)
+	e.bytes([]byte(s.ChainID))
+
+	e.uint64(vt.Height)
+	e.uint32(vt.Round)
+	e.voteTarget(vt.BlockHash)
+
+	return e.writeTo(w)
+}
+
+// ParseSigningContent reads the kind, height, and round of the message
+// from sign bytes produced by s.
+// It returns an error if the sign bytes are for a different chain.
+func (s SignatureSchemeV1) ParseSigningContent(b []byte) (SignedMessage, error) {
+	d := decoder{b: b}
+
+	domain, err := d.bytes()
+	if err != nil {
+		return SignedMessage{}, fmt.Errorf("failed to read domain: %w", err)
+	}
+	var m SignedMessage
+	switch string(domain) {
+	case "gcosmos/v1/proposal":
+		m.Kind = ProposalMessage
+	case "gcosmos/v1/prevote":
+		m.Kind = PrevoteMessage
+	case "gcosmos/v1/precommit":
+		m.Kind = PrecommitMessage
+	default:
+		return SignedMessage{}, fmt.Errorf("unknown domain %q", domain)
+	}
+
+	chainID, err := d.bytes()
+	if err != nil {
+		return SignedMessage{}, fmt.Errorf("failed to read chain ID: %w", err)
+	}
+	if string(chainID) != s.ChainID {
+		return SignedMessage{}, fmt.Errorf("sign bytes for chain %q, not %q", chainID, s.ChainID)
+	}
+
+	if m.Height, err = d.uint64(); err != nil {
+		return SignedMessage{}, fmt.Errorf("failed to read height: %w", err)
+	}
+	if m.Round, err = d.uint32(); err != nil {
+		return SignedMessage{}, fmt.Errorf("failed to read round: %w", err)
+	}
+
+	return m, nil
+}
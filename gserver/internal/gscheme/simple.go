@@ -0,0 +1,76 @@
+package gscheme
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
+)
+
+// Genesis names of the human-readable schemes that gcosmos used
+// before the versioned schemes existed.
+// They remain the defaults, so that existing chains keep their hashes and sign bytes.
+const (
+	HashSchemeSimpleName      = "simple"
+	SignatureSchemeSimpleName = "simple"
+)
+
+// SimpleSignatureScheme is [tmconsensustest.SimpleSignatureScheme],
+// with the addition of parsing its sign bytes for a remote signer.
+//
+// Its sign bytes do not include the chain ID,
+// so they can be replayed on any chain sharing the same validator keys.
+type SimpleSignatureScheme struct {
+	tmconsensustest.SimpleSignatureScheme
+}
+
+var (
+	_ tmconsensus.SignatureScheme = SimpleSignatureScheme{}
+	_ SigningContentParser        = SimpleSignatureScheme{}
+)
+
+// ParseSigningContent reads the kind, height, and round
+// from the first three lines of the sign bytes.
+func (s SimpleSignatureScheme) ParseSigningContent(b []byte) (SignedMessage, error) {
+	lines := strings.SplitN(string(b), "\n", 4)
+	if len(lines) < 4 {
+		return SignedMessage{}, errors.New("sign bytes too short")
+	}
+
+	var m SignedMessage
+	switch lines[0] {
+	case "PROPOSAL:":
+		m.Kind = ProposalMessage
+	case "PREVOTE:", "NIL PREVOTE:":
+		m.Kind = PrevoteMessage
+	case "PRECOMMIT:", "NIL PRECOMMIT:":
+		m.Kind = PrecommitMessage
+	default:
+		return SignedMessage{}, fmt.Errorf("unrecognized message kind %q", lines[0])
+	}
+
+	h, ok := strings.CutPrefix(lines[1], "Height=")
+	if !ok {
+		return SignedMessage{}, fmt.Errorf("expected height, got %q", lines[1])
+	}
+	var err error
+	m.Height, err = strconv.ParseUint(h, 10, 64)
+	if err != nil {
+		return SignedMessage{}, fmt.Errorf("invalid height: %w", err)
+	}
+
+	r, ok := strings.CutPrefix(lines[2], "Round=")
+	if !ok {
+		return SignedMessage{}, fmt.Errorf("expected round, got %q", lines[2])
+	}
+	r64, err := strconv.ParseUint(r, 10, 32)
+	if err != nil {
+		return SignedMessage{}, fmt.Errorf("invalid round: %w", err)
+	}
+	m.Round = uint32(r64)
+
+	return m, nil
+}
@@ -0,0 +1,335 @@
+package gsi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/trace"
+	"time"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gcstore"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+)
+
+// finalizationBlockData is the block data for a block being finalized.
+type finalizationBlockData struct {
+	Txs     []transaction.Tx
+	Encoded []byte
+
+	// Whether the data was loaded from the block data store,
+	// in which case it must not be saved again.
+	Stored bool
+}
+
+// blockDataRecoveryDelay is how long the driver waits
+// on an in-flight block data request during finalization,
+// before it recovers the block data itself.
+const blockDataRecoveryDelay = 5 * time.Second
+
+// awaitBlockData returns the block data for the finalized header h at the given round.
+//
+// If the request cache has an entry for the data, awaitBlockData waits for it,
+// until [blockDataRecoveryDelay] elapses without the data becoming ready.
+// At that point, or if there was no entry in the first place,
+// such as after a restart or if we never saw the proposal,
+// the driver recovers the data itself through [*Driver.recoverBlockData].
+//
+// The ok result is false only if ctx was canceled.
+func (d *Driver) awaitBlockData(
+	ctx context.Context, h tmconsensus.Header, round uint32,
+) (bd finalizationBlockData, ok bool) {
+	dataID := string(h.DataID)
+
+	bdr, ok := d.bdrCache.Get(dataID)
+	if !ok {
+		d.log.Info(
+			"Finalization stalled: block data was never requested; recovering it",
+			"height", h.Height,
+			"data_id", dataID,
+		)
+		return d.recoverBlockData(ctx, h, round)
+	}
+
+	// Optimistic check first.
+	select {
+	case <-bdr.Ready:
+		return finalizationBlockData{
+			Txs:     bdr.Transactions,
+			Encoded: bdr.EncodedTransactions,
+		}, true
+	default:
+	}
+
+	t := time.NewTimer(blockDataRecoveryDelay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		d.log.Info(
+			"Context canceled while waiting for block data in order to finalize",
+			"cause", context.Cause(ctx),
+		)
+		return finalizationBlockData{}, false
+	case <-bdr.Ready:
+		return finalizationBlockData{
+			Txs:     bdr.Transactions,
+			Encoded: bdr.EncodedTransactions,
+		}, true
+	case <-t.C:
+	}
+
+	// The in-flight request may never complete,
+	// for instance if the proposer went offline right after proposing.
+	d.log.Info(
+		"Finalization stalled: in-flight block data request is not ready; recovering it",
+		"height", h.Height,
+		"data_id", dataID,
+		"waited", blockDataRecoveryDelay,
+	)
+	return d.recoverBlockData(ctx, h, round)
+}
+
+// recoverBlockData retrieves the block data for h
+// when the request cache does not have it.
+//
+// It tries, in order, the local block data store,
+// the locations in the annotation of the proposal that was finalized,
+// and then every connected peer through the full block protocol of their DataHost.
+// Every result is verified against the data ID before it is accepted.
+// Until one of those sources succeeds, it retries with a backoff,
+// logging why finalization is stalled after each failed attempt.
+//
+// The ok result is false only if ctx was canceled.
+func (d *Driver) recoverBlockData(
+	ctx context.Context, h tmconsensus.Header, round uint32,
+) (bd finalizationBlockData, ok bool) {
+	defer trace.StartRegion(ctx, "recoverBlockData").End()
+
+	// Arbitrarily chosen retry delays.
+	const (
+		minDelay = 250 * time.Millisecond
+		maxDelay = 16 * time.Second
+	)
+	delay := minDelay
+
+	dataID := string(h.DataID)
+	for {
+		bd, err := d.tryRecoverBlockData(ctx, h, round)
+		if err == nil {
+			d.log.Info(
+				"Recovered block data for finalization",
+				"height", h.Height,
+				"data_id", dataID,
+			)
+			return bd, true
+		}
+
+		if ctx.Err() != nil {
+			d.log.Info(
+				"Context canceled while recovering block data in order to finalize",
+				"cause", context.Cause(ctx),
+			)
+			return finalizationBlockData{}, false
+		}
+
+		d.log.Warn(
+			"Finalization stalled: failed to recover block data from any source; will retry",
+			"height", h.Height,
+			"data_id", dataID,
+			"delay", delay,
+			"err", err,
+		)
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return finalizationBlockData{}, false
+		case <-t.C:
+		}
+
+		delay = min(2*delay, maxDelay)
+	}
+}
+
+// tryRecoverBlockData makes one pass through every block data source.
+// The returned error joins the reasons that each source failed.
+func (d *Driver) tryRecoverBlockData(
+	ctx context.Context, h tmconsensus.Header, round uint32,
+) (finalizationBlockData, error) {
+	dataID := string(h.DataID)
+
+	dec, err := gsbd.NewBlockDataDecoder(dataID, d.txDecoder)
+	if err != nil {
+		// The engine finalized a block with a data ID we cannot parse,
+		// so no source will ever satisfy it.
+		panic(fmt.Errorf("BUG: finalized block has invalid data ID %q: %w", dataID, err))
+	}
+	dec.SetZstdCodec(d.zstdCodec)
+
+	var errs []error
+
+	// First, the local store, in case we saved it before a restart.
+	_, b, err := d.bdStore.LoadBlockDataByID(ctx, dataID, nil)
+	if err == nil {
+		txs, err := dec.Decode(bytes.NewReader(b))
+		if err == nil {
+			return finalizationBlockData{Txs: txs, Encoded: b, Stored: true}, nil
+		}
+		errs = append(errs, fmt.Errorf("block data store had invalid data: %w", err))
+	} else if errors.Is(err, gcstore.ErrBlockDataNotFound) {
+		errs = append(errs, errors.New("not in block data store"))
+	} else {
+		errs = append(errs, fmt.Errorf("failed to load from block data store: %w", err))
+	}
+
+	// Next, wherever the proposer said the data was.
+	bd, err := d.recoverFromProposalLocations(ctx, h, round)
+	if err == nil {
+		return bd, nil
+	}
+	errs = append(errs, err)
+
+	// Finally, any peer that has already committed the block.
+	bd, err = d.recoverFromPeers(ctx, h, dec)
+	if err == nil {
+		return bd, nil
+	}
+	errs = append(errs, err)
+
+	return finalizationBlockData{}, errors.Join(errs...)
+}
+
+// proposalLocationFetchTimeout bounds each fetch from a proposal location,
+// so that one unresponsive location does not hold up the others.
+const proposalLocationFetchTimeout = 5 * time.Second
+
+// recoverFromProposalLocations fetches the block data for h
+// from the locations in the proposal annotation saved in the round store.
+func (d *Driver) recoverFromProposalLocations(
+	ctx context.Context, h tmconsensus.Header, round uint32,
+) (finalizationBlockData, error) {
+	if d.roundStore == nil {
+		return finalizationBlockData{}, errors.New("no round store for proposal locations")
+	}
+
+	phs, _, _, err := d.roundStore.LoadRoundState(ctx, h.Height, round)
+	if err != nil {
+		return finalizationBlockData{}, fmt.Errorf("failed to load proposal from round store: %w", err)
+	}
+
+	var pda ProposalDriverAnnotation
+	found := false
+	for _, ph := range phs {
+		if !bytes.Equal(ph.Header.Hash, h.Hash) {
+			continue
+		}
+
+		found = true
+		if len(ph.Annotations.Driver) == 0 {
+			// Replayed headers have no annotations.
+			break
+		}
+		if err := json.Unmarshal(ph.Annotations.Driver, &pda); err != nil {
+			return finalizationBlockData{}, fmt.Errorf("failed to parse proposal annotation: %w", err)
+		}
+		break
+	}
+	if !found {
+		return finalizationBlockData{}, errors.New("finalized proposal not in round store")
+	}
+
+	dataID := string(h.DataID)
+	var errs []error
+	for _, loc := range pda.Locations {
+		if loc.Scheme == gsbd.HTTPScheme {
+			fetchCtx, cancel := context.WithTimeout(ctx, proposalLocationFetchTimeout)
+			txs, encoded, err := d.httpClient.Retrieve(fetchCtx, loc.Addr, dataID)
+			cancel()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to retrieve from proposal location %s: %w", loc.Addr, err))
+				continue
+			}
+
+			return finalizationBlockData{Txs: txs, Encoded: encoded}, nil
+		}
+
+		if loc.Scheme == gsbd.ErasureScheme && d.p2pClient != nil {
+			el, err := gsbd.ParseErasureLocation(loc.Addr)
+			if err != nil {
+				continue
+			}
+
+			fetchCtx, cancel := context.WithTimeout(ctx, proposalLocationFetchTimeout)
+			txs, encoded, err := d.p2pClient.RetrieveShards(fetchCtx, el, dataID)
+			cancel()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to retrieve from proposal shards: %w", err))
+				continue
+			}
+
+			return finalizationBlockData{Txs: txs, Encoded: encoded}, nil
+		}
+
+		if loc.Scheme != gsbd.Libp2pScheme || d.p2pClient == nil {
+			continue
+		}
+
+		var ai libp2ppeer.AddrInfo
+		if err := json.Unmarshal([]byte(loc.Addr), &ai); err != nil {
+			continue
+		}
+
+		fetchCtx, cancel := context.WithTimeout(ctx, proposalLocationFetchTimeout)
+		txs, encoded, err := d.p2pClient.RetrieveEncoded(fetchCtx, ai, dataID)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to retrieve from proposal location %s: %w", ai.ID, err))
+			continue
+		}
+
+		return finalizationBlockData{Txs: txs, Encoded: encoded}, nil
+	}
+
+	if len(errs) == 0 {
+		return finalizationBlockData{}, errors.New("proposal had no usable locations")
+	}
+	return finalizationBlockData{}, errors.Join(errs...)
+}
+
+// recoverFromPeers fetches the block data for h from the DataHost of each connected peer.
+func (d *Driver) recoverFromPeers(
+	ctx context.Context, h tmconsensus.Header, dec *gsbd.BlockDataDecoder,
+) (finalizationBlockData, error) {
+	if d.host == nil || d.cuClient == nil {
+		return finalizationBlockData{}, errors.New("no libp2p host or catchup client for peers")
+	}
+
+	peers := d.host.Network().Peers()
+	if len(peers) == 0 {
+		return finalizationBlockData{}, errors.New("no connected peers")
+	}
+
+	var errs []error
+	for _, p := range peers {
+		b, err := d.cuClient.FetchBlockData(ctx, p, h)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch from peer %s: %w", p, err))
+			continue
+		}
+
+		txs, err := dec.Decode(bytes.NewReader(b))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("peer %s served invalid block data: %w", p, err))
+			continue
+		}
+
+		return finalizationBlockData{Txs: txs, Encoded: b}, nil
+	}
+
+	return finalizationBlockData{}, errors.Join(errs...)
+}
@@ -0,0 +1,140 @@
+package gsi
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gcstore/gcmemstore"
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	"github.com/gordian-engine/gordian/gcrypto"
+	"github.com/gordian-engine/gordian/tm/tmcodec/tmjson"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
+	"github.com/gordian-engine/gordian/tm/tmengine/tmelink"
+	"github.com/gordian-engine/gordian/tm/tmp2p/tmlibp2p/tmlibp2ptest"
+	"github.com/gordian-engine/gordian/tm/tmstore/tmmemstore"
+	"github.com/stretchr/testify/require"
+)
+
+// The driver is finalizing a block whose data it never requested
+// and which is in none of its local stores,
+// so it must recover the data from a peer that already committed the block.
+func TestDriver_awaitBlockData_recoverFromPeer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := gtest.NewLogger(t)
+
+	reg := new(gcrypto.Registry)
+	gcrypto.RegisterEd25519(reg)
+	codec := tmjson.MarshalCodec{CryptoRegistry: reg}
+
+	net, err := tmlibp2ptest.NewNetwork(ctx, log.With("sys", "net"), codec)
+	require.NoError(t, err)
+	t.Cleanup(net.Wait)
+
+	peerConn, err := net.Connect(ctx)
+	require.NoError(t, err)
+
+	driverConn, err := net.Connect(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, net.Stabilize(ctx))
+
+	// The peer has committed the block at height 1, along with its data.
+	fx := tmconsensustest.NewEd25519Fixture(4)
+	txs := []transaction.Tx{
+		gservertest.NewHashOnlyTransaction(1),
+		gservertest.NewHashOnlyTransaction(2),
+	}
+
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+	dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+
+	peerBDS := gcmemstore.NewBlockDataStore()
+	require.NoError(t, peerBDS.SaveBlockData(ctx, 1, dataID, buf.Bytes()))
+
+	ph1 := fx.NextProposedHeader([]byte(dataID), 0)
+	fx.SignProposal(ctx, &ph1, 0)
+
+	precommitProofs := fx.PrecommitProofMap(ctx, 1, 0, map[string][]int{
+		string(ph1.Header.Hash): {0, 1, 2},
+		"":                      {3},
+	})
+	fx.CommitBlock(ph1.Header, []byte("app_state_1"), 0, precommitProofs)
+	nextPH := fx.NextProposedHeader([]byte("whatever"), 0)
+
+	peerCHS := tmmemstore.NewCommittedHeaderStore()
+	require.NoError(t, peerCHS.SaveCommittedHeader(ctx, tmconsensus.CommittedHeader{
+		Header: ph1.Header,
+		Proof:  nextPH.Header.PrevCommitProof,
+	}))
+
+	dh := gp2papi.NewDataHost(
+		ctx, log.With("sys", "datahost"),
+		gp2papi.DataHostConfig{
+			Host:                 peerConn.Host().Libp2pHost(),
+			CommittedHeaderStore: peerCHS,
+			BlockDataStore:       peerBDS,
+			Codec:                codec,
+			CryptoRegistry:       reg,
+		},
+	)
+	t.Cleanup(dh.Wait)
+
+	// The driver's request cache, block data store, and round store are all empty.
+	driverHost := driverConn.Host().Libp2pHost()
+	cache := gsbd.NewRequestCache()
+	cu := gp2papi.NewCatchupClient(
+		ctx,
+		log.With("sys", "catchupclient"),
+		gp2papi.CatchupClientConfig{
+			Host:               driverHost,
+			Unmarshaler:        codec,
+			CryptoRegistry:     reg,
+			TxDecoder:          gservertest.HashOnlyTransactionDecoder{},
+			RequestCache:       cache,
+			ReplayedHeadersOut: make(chan tmelink.ReplayedHeaderRequest),
+		},
+	)
+	defer cu.Wait()
+	defer cancel()
+
+	driverBDS := gcmemstore.NewBlockDataStore()
+	d := &Driver{
+		log: log.With("sys", "driver"),
+
+		bdStore:  driverBDS,
+		bdrCache: cache,
+
+		roundStore: tmmemstore.NewRoundStore(),
+		host:       driverHost,
+		txDecoder:  gservertest.HashOnlyTransactionDecoder{},
+
+		cuClient: cu,
+	}
+
+	bd, ok := d.awaitBlockData(ctx, ph1.Header, 0)
+	require.True(t, ok)
+	require.Equal(t, txs, bd.Txs)
+	require.Equal(t, buf.Bytes(), bd.Encoded)
+
+	// The data came from a peer, so finalization must still save it.
+	require.False(t, bd.Stored)
+
+	// Once saved, the local store satisfies the next attempt.
+	require.NoError(t, driverBDS.SaveBlockData(ctx, 1, dataID, bd.Encoded))
+	bd, ok = d.awaitBlockData(ctx, ph1.Header, 0)
+	require.True(t, ok)
+	require.Equal(t, txs, bd.Txs)
+	require.True(t, bd.Stored)
+}
@@ -0,0 +1,193 @@
+package gsi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/internal/copy/glog"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmstore"
+)
+
+// initialHeight is the height of the first block on the chain,
+// which has no previous block time.
+// TODO: don't hardcode the initial height.
+const initialHeight = 1
+
+// DefaultMaxClockDrift is the default allowance for a proposed block time
+// to be ahead of the local clock,
+// used when [ConsensusStrategyConfig.MaxClockDrift] or [DriverConfig.MaxClockDrift] is zero.
+const DefaultMaxClockDrift = 500 * time.Millisecond
+
+// minBlockTimeIncrement is how far past the previous block time
+// a proposer sets its block time, if its own clock is not already past it.
+const minBlockTimeIncrement = time.Millisecond
+
+var (
+	// ErrBlockTimeNotIncreasing is returned from [CheckBlockTime]
+	// when the block time is not after the previous block time.
+	// Such a block can never become valid.
+	ErrBlockTimeNotIncreasing = errors.New("block time not after previous block time")
+
+	// ErrBlockTimeInFuture is returned from [CheckBlockTime]
+	// when the block time is too far ahead of the local clock.
+	// Such a block may become valid once the local clock catches up.
+	ErrBlockTimeInFuture = errors.New("block time too far in the future")
+)
+
+// CheckBlockTime reports an error if the block time bt
+// is not strictly after prevTime, the time of the previous block,
+// or if it is more than maxDrift ahead of now.
+// A zero prevTime, as at the initial height, imposes no lower bound.
+//
+// The error wraps either [ErrBlockTimeNotIncreasing] or [ErrBlockTimeInFuture].
+func CheckBlockTime(bt, prevTime, now time.Time, maxDrift time.Duration) error {
+	if !prevTime.IsZero() && !bt.After(prevTime) {
+		return fmt.Errorf(
+			"%w: block time %s, previous block time %s",
+			ErrBlockTimeNotIncreasing,
+			bt.Format(time.RFC3339Nano), prevTime.Format(time.RFC3339Nano),
+		)
+	}
+
+	if latest := now.Add(maxDrift); bt.After(latest) {
+		return fmt.Errorf(
+			"%w: block time %s, latest acceptable time %s",
+			ErrBlockTimeInFuture,
+			bt.Format(time.RFC3339Nano), latest.Format(time.RFC3339Nano),
+		)
+	}
+
+	return nil
+}
+
+// loadPrevHeader returns the committed header preceding the given height, from chs.
+// At the initial height, there is no previous header and ok is false.
+func loadPrevHeader(
+	ctx context.Context, chs tmstore.CommittedHeaderStore, height uint64,
+) (h tmconsensus.Header, ok bool, err error) {
+	if height <= initialHeight {
+		return tmconsensus.Header{}, false, nil
+	}
+
+	ch, err := chs.LoadCommittedHeader(ctx, height-1)
+	if err != nil {
+		return tmconsensus.Header{}, false, fmt.Errorf("failed to load committed header at height %d: %w", height-1, err)
+	}
+
+	return ch.Header, true, nil
+}
+
+// headerBlockTime returns the block time in the annotation of h.
+func headerBlockTime(h tmconsensus.Header) (time.Time, error) {
+	var ba BlockAnnotation
+	if err := json.Unmarshal(h.Annotations.Driver, &ba); err != nil {
+		return time.Time{}, fmt.Errorf("failed to extract block annotation at height %d: %w", h.Height, err)
+	}
+
+	t, err := ba.Time()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse block time at height %d: %w", h.Height, err)
+	}
+
+	return t, nil
+}
+
+// loadPrevBlockTime returns the block time of the committed header
+// preceding the given height, from chs.
+// At the initial height, it returns the zero time.
+func loadPrevBlockTime(
+	ctx context.Context, chs tmstore.CommittedHeaderStore, height uint64,
+) (time.Time, error) {
+	h, ok, err := loadPrevHeader(ctx, chs, height)
+	if err != nil || !ok {
+		return time.Time{}, err
+	}
+
+	return headerBlockTime(h)
+}
+
+// checkFinalizedBlockTime applies the block time rules of the [ConsensusStrategy]
+// again to the header of a block being finalized, and returns its block time.
+//
+// The block is already committed, so the strict increase over the previous block time,
+// which proposals must satisfy in [*ConsensusStrategy.ConsiderProposedBlocks],
+// is only logged here:
+// blocks committed before that rule, whose whole-second times often repeat,
+// must still replay and catch up.
+// A block time too far ahead of the local clock more likely means that our clock is behind,
+// so finalization waits for the local clock to catch up.
+//
+// The ok result is false if the block time cannot be read,
+// or if ctx was canceled.
+func (d *Driver) checkFinalizedBlockTime(
+	ctx context.Context, h tmconsensus.Header,
+) (bt time.Time, ok bool) {
+	var ba BlockAnnotation
+	if err := json.Unmarshal(h.Annotations.Driver, &ba); err != nil {
+		d.log.Warn(
+			"Failed to extract driver annotation from finalize block request",
+			"err", err,
+		)
+		return time.Time{}, false
+	}
+
+	bt, err := ba.Time()
+	if err != nil {
+		d.log.Warn(
+			"Failed to parse block time from driver annotation",
+			"err", err,
+		)
+		return time.Time{}, false
+	}
+
+	prevTime, err := loadPrevBlockTime(ctx, d.chStore, h.Height)
+	if err != nil {
+		d.log.Warn(
+			"Failed to load previous block time to check finalized block time",
+			"height", h.Height,
+			"err", err,
+		)
+		return time.Time{}, false
+	}
+
+	for {
+		err := CheckBlockTime(bt, prevTime, time.Now(), d.maxClockDrift)
+		if err == nil {
+			return bt, true
+		}
+
+		if errors.Is(err, ErrBlockTimeNotIncreasing) {
+			d.log.Warn(
+				"Finalizing committed block whose block time is not after the previous block time",
+				"height", h.Height,
+				"hash", glog.Hex(h.Hash),
+				"err", err,
+			)
+			return bt, true
+		}
+
+		wait := time.Until(bt.Add(-d.maxClockDrift))
+		d.log.Warn(
+			"Finalized block time is ahead of local clock; waiting for clock to catch up",
+			"height", h.Height,
+			"wait", wait,
+			"err", err,
+		)
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			d.log.Info(
+				"Context canceled while waiting for clock to reach finalized block time",
+				"cause", context.Cause(ctx),
+			)
+			return time.Time{}, false
+		case <-t.C:
+		}
+	}
+}
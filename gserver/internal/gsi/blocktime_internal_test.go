@@ -0,0 +1,52 @@
+package gsi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
+	"github.com/gordian-engine/gordian/tm/tmstore/tmmemstore"
+	"github.com/stretchr/testify/require"
+)
+
+// A committed block whose time repeats the previous block time,
+// as is common for blocks from before block times had sub-second precision,
+// must still be finalized, so that replay and catchup do not halt.
+func TestDriver_checkFinalizedBlockTime_notIncreasing(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fx := tmconsensustest.NewEd25519Fixture(2)
+	chs := tmmemstore.NewCommittedHeaderStore()
+
+	bt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	annotated := func(height uint64) tmconsensus.Header {
+		ph := fx.NextProposedHeader([]byte("app_data"), 0)
+		ph.Header.Height = height
+		j, err := json.Marshal(NewBlockAnnotation(bt))
+		require.NoError(t, err)
+		ph.Header.Annotations.Driver = j
+		fx.RecalculateHash(&ph.Header)
+		return ph.Header
+	}
+
+	h1 := annotated(1)
+	require.NoError(t, chs.SaveCommittedHeader(ctx, tmconsensus.CommittedHeader{Header: h1}))
+
+	d := &Driver{
+		log: gtest.NewLogger(t),
+
+		chStore:       chs,
+		maxClockDrift: DefaultMaxClockDrift,
+	}
+
+	got, ok := d.checkFinalizedBlockTime(ctx, annotated(2))
+	require.True(t, ok)
+	require.True(t, bt.Equal(got))
+}
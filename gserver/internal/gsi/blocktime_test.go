@@ -0,0 +1,88 @@
+package gsi_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBlockTime(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 600_000_000, time.UTC)
+	const drift = 500 * time.Millisecond
+
+	for name, tc := range map[string]struct {
+		bt, prev time.Time
+		wantErr  error
+	}{
+		"initial height": {
+			bt: now,
+		},
+		"after previous": {
+			bt:   now,
+			prev: now.Add(-time.Millisecond),
+		},
+		"within drift": {
+			bt:   now.Add(drift),
+			prev: now.Add(-time.Second),
+		},
+		"equal to previous": {
+			bt:      now,
+			prev:    now,
+			wantErr: gsi.ErrBlockTimeNotIncreasing,
+		},
+		"before previous": {
+			bt:      now.Add(-time.Second),
+			prev:    now,
+			wantErr: gsi.ErrBlockTimeNotIncreasing,
+		},
+		"beyond drift": {
+			bt:      now.Add(drift + time.Nanosecond),
+			prev:    now.Add(-time.Second),
+			wantErr: gsi.ErrBlockTimeInFuture,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := gsi.CheckBlockTime(tc.bt, tc.prev, now, drift)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBlockAnnotation_time(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sub-second precision", func(t *testing.T) {
+		t.Parallel()
+
+		want := time.Date(2025, 1, 2, 3, 4, 5, 678_901_234, time.UTC)
+		b, err := json.Marshal(gsi.NewBlockAnnotation(want.In(time.FixedZone("X", 3600))))
+		require.NoError(t, err)
+
+		var ba gsi.BlockAnnotation
+		require.NoError(t, json.Unmarshal(b, &ba))
+		got, err := ba.Time()
+		require.NoError(t, err)
+		require.True(t, want.Equal(got))
+	})
+
+	t.Run("whole seconds", func(t *testing.T) {
+		t.Parallel()
+
+		// Annotations written before sub-second precision.
+		ba := gsi.BlockAnnotation{TimeS: "2025-01-02T03:04:05Z"}
+		got, err := ba.Time()
+		require.NoError(t, err)
+		require.True(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC).Equal(got))
+	})
+}
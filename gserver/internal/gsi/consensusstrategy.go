@@ -6,15 +6,21 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"cosmossdk.io/core/transaction"
 	"cosmossdk.io/server/v2/appmanager"
+	storev2 "cosmossdk.io/store/v2"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/internal/copy/gchan"
 	"github.com/gordian-engine/gcosmos/internal/copy/glog"
 	"github.com/gordian-engine/gordian/gcrypto"
 	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmengine"
+	"github.com/gordian-engine/gordian/tm/tmstore"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
 )
 
 type ConsensusStrategy struct {
@@ -22,12 +28,25 @@ type ConsensusStrategy struct {
 
 	am appmanager.AppManager[transaction.Tx]
 
+	chainID string
+	store   storev2.RootStore
+
+	execCache *ExecutionCache
+
+	chStore       tmstore.CommittedHeaderStore
+	maxClockDrift time.Duration
+
 	txBuf *SDKTxBuf
 
+	signer tmconsensus.Signer
+
+	// The signer's key as of the current round.
 	signerPubKey gcrypto.PubKey
 
 	provider gsbd.Provider
 
+	txsHashScheme gsbd.TxsHashScheme
+
 	curH uint64
 	curR uint32
 
@@ -35,14 +54,39 @@ type ConsensusStrategy struct {
 
 	bdrCache *gsbd.RequestCache
 
+	shardHost   *gsbd.ErasureHost
+	valBindings *gpeer.ValidatorBindings
+
 	proposerSelection ProposerSelectionFunc
+
+	proposalHandler ProposalHandler
+
+	// Whether and when to propose blocks without transactions.
+	noEmptyBlocks      bool
+	emptyBlockInterval time.Duration
+
+	// For the proposal timeout of each round,
+	// after which a proposal waiting for transactions is too late.
+	timeouts tmengine.TimeoutStrategy
+
+	// Set while we wait for transactions before proposing,
+	// to stop waiting once we have decided our prevote,
+	// or once the round ends.
+	cancelTxWait func()
+	wg           sync.WaitGroup
+
+	// Hashes of proposed blocks in the current round
+	// that we have determined can never be valid.
+	// Reset upon entering a new round.
+	invalidProposals map[string]struct{}
 }
 
 // ProposerSelectionFunc decides which validator
 // is supposed to be proposing at a given height and round.
 //
 // The [ConsensusStrategy] currently only inspects when deciding whether the current process
-// should propose a block.
+// should propose a block, and when checking who pushed a block data shard;
+// the latter happens concurrently, so the function must be safe for concurrent use.
 type ProposerSelectionFunc func(
 	ctx context.Context,
 	height uint64, round uint32,
@@ -66,27 +110,89 @@ type ConsensusStrategyConfig struct {
 	// If nil, defaults to [DefaultProposerSelection].
 	ProposerSelection ProposerSelectionFunc
 
-	// Needed to simulate transactions.
+	// Needed to execute proposed blocks.
 	AppManager appmanager.AppManager[transaction.Tx]
 
+	// The chain ID and the store of committed state,
+	// to execute proposed blocks exactly as the driver would upon finalization.
+	ChainID string
+	Store   storev2.RootStore
+
+	// Where to record the execution of every valid proposed block,
+	// for reuse by the driver.
+	ExecutionCache *ExecutionCache
+
+	// The engine's committed headers,
+	// for the time of the block preceding the current height.
+	// Block times must strictly increase.
+	CommittedHeaderStore tmstore.CommittedHeaderStore
+
+	// How far a proposed block's time may be ahead of the local clock.
+	// If zero, [DefaultMaxClockDrift] is used.
+	MaxClockDrift time.Duration
+
 	// To get the pending transactions when proposing a block.
 	// Maybe could be nil if signer is nil
 	// and we know we will never propose a block?
 	TxBuf *SDKTxBuf
 
-	// The public key of our signer.
-	// May be nil.
-	SignerPubKey gcrypto.PubKey
+	// Our signer, whose public key determines when we propose.
+	// May be nil, as on a full node,
+	// in which case the strategy never proposes blocks.
+	// The key is read upon entering each round,
+	// as a signer guarded by a startup double sign check
+	// only reports its real key once the check passes.
+	Signer tmconsensus.Signer
 
 	// How to provide our proposed block data to other network participants.
 	BlockDataProvider gsbd.Provider
 
+	// The chain's scheme for the transactions hash in block data IDs,
+	// which must match the scheme of the BlockDataProvider.
+	// Proposed blocks whose data IDs use another scheme are invalid.
+	TxsHashScheme gsbd.TxsHashScheme
+
 	ProposedBlockDataRetriever *PBDRetriever
 
 	// The request cache indicating what block data requests are in flight
 	// and which ones have already been completed.
 	// Not yet entirely used.
 	BlockDataRequestCache *gsbd.RequestCache
+
+	// If set, the shard host only holds shards pushed by the proposer
+	// of a round at the current height,
+	// resolved to its peer through the validator bindings.
+	BlockDataShardHost *gsbd.ErasureHost
+	ValidatorBindings  *gpeer.ValidatorBindings
+
+	// Like create_empty_blocks=false in Comet:
+	// when the transaction buffer is empty,
+	// the proposer waits for transactions instead of proposing an empty block,
+	// until the round ends or EmptyBlockInterval passes.
+	// The round's proposal timeout is extended by [EmptyBlocksTimeoutStrategy.Wait].
+	NoEmptyBlocks bool
+
+	// Like create_empty_blocks_interval in Comet:
+	// in the first round of a height, when the transaction buffer is empty,
+	// the proposer waits up to this long for transactions
+	// before it proposes an empty block.
+	//
+	// If either this or NoEmptyBlocks is set,
+	// the engine's timeout strategy must be wrapped in an [EmptyBlocksTimeoutStrategy]
+	// with the same settings, so that other validators wait for the proposal.
+	EmptyBlockInterval time.Duration
+
+	// The engine's timeout strategy, including any [EmptyBlocksTimeoutStrategy].
+	// A proposer waiting for transactions stops waiting
+	// once the round's proposal timeout elapses,
+	// as the other validators no longer wait for its proposal.
+	// If nil, the proposer waits until it decides its prevote or the round ends.
+	TimeoutStrategy tmengine.TimeoutStrategy
+
+	// If set, the application prepares our proposed blocks
+	// and validates every proposed block through this handler,
+	// which must be safe for concurrent use.
+	ProposalHandler ProposalHandler
 }
 
 func NewConsensusStrategy(
@@ -98,22 +204,47 @@ func NewConsensusStrategy(
 		log: log,
 		am:  cfg.AppManager,
 
+		chainID: cfg.ChainID,
+		store:   cfg.Store,
+
+		execCache: cfg.ExecutionCache,
+
+		chStore:       cfg.CommittedHeaderStore,
+		maxClockDrift: cfg.MaxClockDrift,
+
 		txBuf: cfg.TxBuf,
 
-		signerPubKey: cfg.SignerPubKey,
+		signer: cfg.Signer,
 
 		provider: cfg.BlockDataProvider,
 
+		txsHashScheme: cfg.TxsHashScheme,
+
 		pbdr: cfg.ProposedBlockDataRetriever,
 
 		bdrCache: cfg.BlockDataRequestCache,
 
+		shardHost:   cfg.BlockDataShardHost,
+		valBindings: cfg.ValidatorBindings,
+
 		proposerSelection: cfg.ProposerSelection,
+
+		noEmptyBlocks:      cfg.NoEmptyBlocks,
+		emptyBlockInterval: cfg.EmptyBlockInterval,
+
+		timeouts: cfg.TimeoutStrategy,
+
+		proposalHandler: cfg.ProposalHandler,
+
+		invalidProposals: make(map[string]struct{}),
 	}
 
 	if cs.proposerSelection == nil {
 		cs.proposerSelection = DefaultProposerSelection
 	}
+	if cs.maxClockDrift <= 0 {
+		cs.maxClockDrift = DefaultMaxClockDrift
+	}
 
 	return cs
 }
@@ -121,22 +252,32 @@ func NewConsensusStrategy(
 func (s *ConsensusStrategy) Wait() {
 	// The pbdr is an implementation detail of the consensus strategy,
 	// so we don't expose it directly.
-	// The pbdr is the only background work created here.
+	// Besides the pbdr, the only background work
+	// is waiting for transactions to propose.
 	s.pbdr.Wait()
+	s.wg.Wait()
 }
 
 // BlockAnnotation is the data encoded as a block annotation.
 // Block annotations are persisted on-chain,
 // unlike proposal annotations which are not persisted to chain.
 type BlockAnnotation struct {
-	// The time that the proposer said that it proposed the block.
+	// The time that the proposer said that it proposed the block,
+	// in UTC with sub-second precision.
 	// S suffix indicating string, to allow the Time method to exist without a name conflict.
 	TimeS string `json:"Time"`
 }
 
+// NewBlockAnnotation returns the BlockAnnotation for block time t.
+func NewBlockAnnotation(t time.Time) BlockAnnotation {
+	return BlockAnnotation{TimeS: t.UTC().Format(time.RFC3339Nano)}
+}
+
 // Time parses and returns the time value from the annotation.
+// Annotations from before block times had sub-second precision
+// are also accepted.
 func (ba BlockAnnotation) Time() (time.Time, error) {
-	return time.Parse(time.RFC3339, ba.TimeS)
+	return time.Parse(time.RFC3339Nano, ba.TimeS)
 }
 
 func (c *ConsensusStrategy) EnterRound(
@@ -144,13 +285,34 @@ func (c *ConsensusStrategy) EnterRound(
 	rv tmconsensus.RoundView,
 	proposalOut chan<- tmconsensus.Proposal,
 ) error {
+	// A proposal still waiting for transactions is for a round that is over.
+	c.stopTxWait()
+
 	// Track the current height and round for later when we get to voting.
 	c.curH = rv.Height
 	c.curR = rv.Round
+	clear(c.invalidProposals)
 
-	if c.signerPubKey == nil {
+	// Proposals in this round cannot use the data from earlier rounds,
+	// so release it now rather than when the height is finalized.
+	if rv.Round > 0 {
+		if n := c.bdrCache.PurgeBelowRound(rv.Height, rv.Round); n > 0 {
+			c.log.Debug(
+				"Purged block data requests from earlier rounds",
+				"height", rv.Height,
+				"round", rv.Round,
+				"n_purged", n,
+			)
+		}
+	}
+
+	c.setShardProposers(rv.Height, rv.ValidatorSet)
+
+	if c.signer == nil {
 		// Not participating, stop early.
+		return nil
 	}
+	c.signerPubKey = c.signer.PubKey()
 
 	proposingVal := c.proposerSelection(ctx, rv.Height, rv.Round, rv.ValidatorSet)
 	weShouldPropose := proposingVal.PubKey.Equal(c.signerPubKey)
@@ -174,22 +336,224 @@ func (c *ConsensusStrategy) EnterRound(
 		))
 	}
 
-	ba, err := json.Marshal(BlockAnnotation{
-		// TODO: this needs something much more sophisticated than just time.Now.
-		TimeS: time.Now().UTC().Format(time.RFC3339),
-	})
+	prevHeader, hasPrev, err := loadPrevHeader(ctx, c.chStore, rv.Height)
 	if err != nil {
-		return fmt.Errorf("failed to marshal block driver annotations: %w", err)
+		return fmt.Errorf("failed to get previous header: %w", err)
+	}
+	var prevTime time.Time
+	if hasPrev {
+		prevTime, err = headerBlockTime(prevHeader)
+		if err != nil {
+			return fmt.Errorf("failed to get previous block time: %w", err)
+		}
 	}
 
 	pendingTxs := c.txBuf.Buffered(ctx, nil)
+	if len(pendingTxs) > 0 {
+		return c.propose(ctx, rv.Height, rv.Round, prevTime, pendingTxs, proposalOut)
+	}
+
+	// Comet proposes a block right after a block with transactions,
+	// even when not creating empty blocks,
+	// so that the app state resulting from those transactions is committed.
+	prevHadTxs := hasPrev && !gsbd.IsZeroTxDataID(string(prevHeader.DataID))
+
+	switch {
+	case !c.noEmptyBlocks && c.emptyBlockInterval == 0,
+		prevHadTxs,
+		!c.noEmptyBlocks && rv.Round > 0:
+		// Propose the empty block now.
+		// After the first round, waiting any longer only delays the chain.
+		return c.propose(ctx, rv.Height, rv.Round, prevTime, nil, proposalOut)
+	}
+
+	// Otherwise, wait for transactions in the background,
+	// until the empty block interval passes in the first round,
+	// or until we stop waiting at the end of the round:
+	// when its proposal timeout elapses, when we decide our prevote,
+	// or upon the next call to EnterRound.
+	now := time.Now()
+	var emptyDeadline, roundDeadline time.Time
+	if c.emptyBlockInterval > 0 && rv.Round == 0 {
+		emptyDeadline = now.Add(c.emptyBlockInterval)
+	}
+	if c.timeouts != nil {
+		roundDeadline = now.Add(c.timeouts.ProposalTimeout(rv.Height, rv.Round))
+	}
+
+	waitCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	c.cancelTxWait = func() {
+		cancel()
+		<-done
+	}
+	c.wg.Add(1)
+	go c.awaitTxs(waitCtx, done, rv.Height, rv.Round, prevTime, emptyDeadline, roundDeadline, proposalOut)
+
+	return nil
+}
+
+// setShardProposers restricts the shard host to shards
+// pushed by the proposer of any round at height h.
+func (c *ConsensusStrategy) setShardProposers(h uint64, valSet tmconsensus.ValidatorSet) {
+	if c.shardHost == nil || c.valBindings == nil {
+		return
+	}
+
+	sel := c.proposerSelection
+	c.shardHost.SetProposerFunc(func(height uint64, round uint32) (libp2ppeer.ID, bool) {
+		if height != h {
+			return "", false
+		}
+		v := sel(context.Background(), height, round, valSet)
+		return c.valBindings.PeerID(v.PubKey)
+	})
+}
+
+// txPollInterval is how often a proposer waiting for transactions
+// checks the transaction buffer.
+const txPollInterval = 100 * time.Millisecond
+
+// stopTxWait stops waiting for transactions to propose, if we are waiting,
+// and returns once the waiting goroutine has returned.
+//
+// Once we have decided our prevote, we must not propose in the round:
+// the proposal would arrive too late for the other validators anyway,
+// and a signer's watermark refuses to sign a proposal after a prevote.
+func (c *ConsensusStrategy) stopTxWait() {
+	if c.cancelTxWait != nil {
+		c.cancelTxWait()
+		c.cancelTxWait = nil
+	}
+}
+
+// awaitTxs proposes a block for height h and round r once the transaction buffer has transactions,
+// or proposes an empty block at emptyDeadline, if it is not the zero time.
+// It gives up without proposing at roundDeadline, if it is not the zero time.
+// It closes done upon returning.
+func (c *ConsensusStrategy) awaitTxs(
+	ctx context.Context,
+	done chan<- struct{},
+	h uint64, r uint32,
+	prevTime, emptyDeadline, roundDeadline time.Time,
+	proposalOut chan<- tmconsensus.Proposal,
+) {
+	defer c.wg.Done()
+	defer close(done)
+
+	c.log.Debug(
+		"Waiting for transactions before proposing",
+		"h", h, "r", r,
+		"empty_deadline", emptyDeadline,
+		"round_deadline", roundDeadline,
+	)
+
+	var emptyCh, roundCh <-chan time.Time
+	if !emptyDeadline.IsZero() {
+		t := time.NewTimer(time.Until(emptyDeadline))
+		defer t.Stop()
+		emptyCh = t.C
+	}
+	if !roundDeadline.IsZero() {
+		t := time.NewTimer(time.Until(roundDeadline))
+		defer t.Stop()
+		roundCh = t.C
+	}
+
+	ticker := time.NewTicker(txPollInterval)
+	defer ticker.Stop()
+
+	var txs []transaction.Tx
+WAIT:
+	for {
+		select {
+		case <-ctx.Done():
+			// The round ended, or the process is shutting down.
+			return
+		case <-roundCh:
+			c.log.Debug(
+				"Proposal timeout elapsed before transactions arrived; not proposing in this round",
+				"h", h, "r", r,
+			)
+			return
+		case <-emptyCh:
+			// Propose the empty block.
+			break WAIT
+		case <-ticker.C:
+			txs = c.txBuf.Buffered(ctx, nil)
+			if len(txs) > 0 {
+				break WAIT
+			}
+		}
+	}
+
+	if err := c.propose(ctx, h, r, prevTime, txs, proposalOut); err != nil && ctx.Err() == nil {
+		c.log.Warn(
+			"Failed to propose block after waiting for transactions",
+			"h", h, "r", r,
+			"err", err,
+		)
+	}
+}
+
+// propose sends the proposal for height h and round r containing txs to proposalOut.
+func (c *ConsensusStrategy) propose(
+	ctx context.Context,
+	h uint64, r uint32,
+	prevTime time.Time,
+	txs []transaction.Tx,
+	proposalOut chan<- tmconsensus.Proposal,
+) error {
+	// Block times must strictly increase,
+	// so if our clock is not past the previous block time,
+	// propose the earliest time that is.
+	bt := time.Now()
+	if !prevTime.IsZero() && !bt.After(prevTime) {
+		c.log.Warn(
+			"Local clock is not past previous block time; proposing the minimum block time instead",
+			"h", h, "r", r,
+			"prev_block_time", prevTime,
+		)
+		bt = prevTime.Add(minBlockTimeIncrement)
+	}
+
+	if c.proposalHandler != nil {
+		var err error
+		txs, err = c.proposalHandler.PrepareProposal(ctx, ProposalInfo{
+			Height:         h,
+			Round:          r,
+			Time:           bt,
+			ProposerPubKey: c.signerPubKey,
+			Txs:            txs,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+
+			// An application error must not halt the engine.
+			// Without our proposal, the other validators prevote nil,
+			// and the next round has another proposer.
+			c.log.Warn(
+				"Application failed to prepare proposal; not proposing in this round",
+				"h", h, "r", r,
+				"err", err,
+			)
+			return nil
+		}
+	}
+
+	ba, err := json.Marshal(NewBlockAnnotation(bt))
+	if err != nil {
+		return fmt.Errorf("failed to marshal block driver annotations: %w", err)
+	}
 
 	var blockDataID string
 	var pda []byte
-	if len(pendingTxs) == 0 {
-		blockDataID = gsbd.DataID(c.curH, c.curR, 0, nil)
+	if len(txs) == 0 {
+		blockDataID = c.txsHashScheme.DataID(h, r, 0, nil)
 	} else {
-		res, err := c.provider.Provide(ctx, c.curH, c.curR, pendingTxs)
+		res, err := c.provider.Provide(ctx, h, r, txs)
 		if err != nil {
 			return fmt.Errorf("failed to provide block data: %w", err)
 		}
@@ -204,7 +568,7 @@ func (c *ConsensusStrategy) EnterRound(
 		blockDataID = res.DataID
 
 		// We are proposing this data, so mark it as locally available.
-		c.bdrCache.SetImmediatelyAvailable(blockDataID, pendingTxs, res.Encoded)
+		c.bdrCache.SetImmediatelyAvailable(blockDataID, txs, res.Encoded)
 	}
 
 	if !gchan.SendC(
@@ -234,10 +598,7 @@ func (c *ConsensusStrategy) ConsiderProposedBlocks(
 ) (string, error) {
 PH_LOOP:
 	for _, ph := range phs {
-		// TODO: handle a particular proposed block being excluded from a round,
-		// presumably because we got its data and we chose not to accept it.
-		const excluded = false
-		if excluded {
+		if _, excluded := c.invalidProposals[string(ph.Header.Hash)]; excluded {
 			continue
 		}
 
@@ -265,6 +626,7 @@ PH_LOOP:
 				"block_hash", glog.Hex(ph.Header.Hash),
 				"err", err,
 			)
+			c.rejectProposal(ph)
 			continue
 		}
 		if h != c.curH {
@@ -273,6 +635,7 @@ PH_LOOP:
 				"h", c.curH, "r", c.curR,
 				"got_h", h,
 			)
+			c.rejectProposal(ph)
 			continue
 		}
 		if r != c.curR {
@@ -281,9 +644,29 @@ PH_LOOP:
 				"h", c.curH, "r", c.curR,
 				"got_r", r,
 			)
+			c.rejectProposal(ph)
+			continue
+		}
+		if s, err := gsbd.DataIDTxsHashScheme(string(ph.Header.DataID)); err != nil || s != c.txsHashScheme {
+			// Decoders accept every scheme, so this is the only place that enforces the chain's.
+			c.log.Debug(
+				"Ignoring proposed block due to wrong txs hash scheme in app data ID",
+				"h", c.curH, "r", c.curR,
+				"want", c.txsHashScheme, "got", s,
+			)
+			c.rejectProposal(ph)
 			continue
 		}
 
+		// We already executed this block during an earlier call,
+		// so we know it is valid.
+		if c.execCache.Has(string(ph.Header.Hash)) {
+			// Returning a hash decides our prevote.
+			c.stopTxWait()
+			return string(ph.Header.Hash), nil
+		}
+
+		var txs []transaction.Tx
 		if nTxs != 0 {
 			bdr, ok := c.bdrCache.Get(string(ph.Header.DataID))
 			if !ok {
@@ -309,50 +692,7 @@ PH_LOOP:
 				continue
 			}
 
-			txs := bdr.Transactions
-
-			// We do have the transactions.
-			// Can they be applied?
-			// We know we have at least one transaction,
-			// and we needs its result to seed subsequent transactions starting state.
-			txRes, state, err := c.am.Simulate(ctx, txs[0])
-			if err != nil {
-				c.log.Debug(
-					"Ignoring proposed block due to failure to simulate",
-					"err", err,
-				)
-				continue
-			}
-			if txRes.Error != nil {
-				txHash := txs[0].Hash()
-				c.log.Debug(
-					"Ignoring proposed block due to failure to apply transaction",
-					"tx_hash", glog.Hex(txHash[:]),
-					"err", txRes.Error,
-				)
-				continue
-			}
-
-			for _, tx := range txs[1:] {
-				txRes, state, err = c.am.SimulateWithState(ctx, state, tx)
-				if err != nil {
-					c.log.Info(
-						"Failed to run SimulateWithState for incoming transaction; discarding the transaction",
-						"err", err,
-					)
-					continue PH_LOOP
-				}
-
-				if txRes.Error != nil {
-					txHash := tx.Hash()
-					c.log.Debug(
-						"Ignoring proposed block due to failure to apply transaction",
-						"tx_hash", glog.Hex(txHash[:]),
-						"err", txRes.Error,
-					)
-					continue PH_LOOP
-				}
-			}
+			txs = bdr.Transactions
 		}
 
 		var ba BlockAnnotation
@@ -361,6 +701,7 @@ PH_LOOP:
 				"Ignoring proposed block due to error extracting block annotation",
 				"h", c.curH, "r", c.curR, "err", err,
 			)
+			c.rejectProposal(ph)
 			continue
 		}
 
@@ -370,27 +711,123 @@ PH_LOOP:
 				"Ignoring proposed block due to error extracting block time from annotation",
 				"h", c.curH, "r", c.curR, "err", err,
 			)
+			c.rejectProposal(ph)
+			continue
+		}
+
+		prevTime, err := loadPrevBlockTime(ctx, c.chStore, c.curH)
+		if err != nil {
+			c.log.Warn(
+				"Failed to get previous block time to check proposed block",
+				"h", c.curH, "r", c.curR, "err", err,
+			)
+			continue
+		}
+
+		if err := CheckBlockTime(bt, prevTime, time.Now(), c.maxClockDrift); err != nil {
+			c.log.Debug(
+				"Ignoring proposed block due to invalid block time",
+				"h", c.curH, "r", c.curR, "err", err,
+			)
+			if errors.Is(err, ErrBlockTimeNotIncreasing) {
+				c.rejectProposal(ph)
+			}
+			// Otherwise the block time is in the future,
+			// and the proposal may become valid as our clock catches up.
 			continue
 		}
 
-		if bt.After(time.Now()) {
+		if c.proposalHandler != nil {
+			if err := c.proposalHandler.ProcessProposal(ctx, ProposalInfo{
+				Height:         ph.Header.Height,
+				Round:          ph.Round,
+				Time:           bt,
+				ProposerPubKey: ph.ProposerPubKey,
+				Txs:            txs,
+			}); err != nil {
+				if ctx.Err() != nil {
+					return "", context.Cause(ctx)
+				}
+
+				c.log.Debug(
+					"Ignoring proposed block rejected by application",
+					"h", c.curH, "r", c.curR,
+					"block_hash", glog.Hex(ph.Header.Hash),
+					"err", err,
+				)
+				c.rejectProposal(ph)
+				continue
+			}
+		}
+
+		// Execute the block exactly as the driver would upon finalization,
+		// so that the driver can reuse the result if this block is finalized.
+		cID, err := c.store.LastCommitID()
+		if err != nil {
+			c.log.Warn(
+				"Failed to get last commit ID to execute proposed block",
+				"h", c.curH, "r", c.curR, "err", err,
+			)
+			continue
+		}
+		blockReq := newBlockRequest(ph.Header, c.chainID, cID.Hash, bt, txs)
+		blockResp, state, err := deliverBlock(ctx, c.am, blockReq)
+		if err != nil {
 			c.log.Debug(
-				"Ignoring proposed block due to block time in the future",
+				"Ignoring proposed block due to failure to execute",
 				"h", c.curH, "r", c.curR, "err", err,
 			)
 			continue
 		}
 
+		for i, txRes := range blockResp.TxResults {
+			if txRes.Error != nil {
+				txHash := txs[i].Hash()
+				c.log.Debug(
+					"Ignoring proposed block due to failure to apply transaction",
+					"tx_hash", glog.Hex(txHash[:]),
+					"err", txRes.Error,
+				)
+				c.rejectProposal(ph)
+				continue PH_LOOP
+			}
+		}
+
+		c.execCache.Put(string(ph.Header.Hash), BlockExecution{
+			Request:  blockReq,
+			Response: blockResp,
+			State:    state,
+		})
+
+		// Returning a hash decides our prevote.
+		c.stopTxWait()
 		return string(ph.Header.Hash), nil
 	}
 
 	return "", tmconsensus.ErrProposedBlockChoiceNotReady
 }
 
+// rejectProposal excludes ph from further consideration in the current round.
+//
+// This must only be called for proposals that can never become valid.
+// A proposal that is merely not ready yet, such as one with a block time in the future,
+// must be considered again later.
+//
+// No peer is reported for an invalid proposal:
+// the block data locations in the proposal annotation are chosen by the proposer,
+// who could name honest peers to have them penalized.
+// The retriever reports a peer only if the data it served fails verification.
+func (c *ConsensusStrategy) rejectProposal(ph tmconsensus.ProposedHeader) {
+	c.invalidProposals[string(ph.Header.Hash)] = struct{}{}
+}
+
 func (c *ConsensusStrategy) ChooseProposedBlock(
 	ctx context.Context,
 	phs []tmconsensus.ProposedHeader,
 ) (string, error) {
+	// Whatever we choose, we prevote now.
+	c.stopTxWait()
+
 	h, err := c.ConsiderProposedBlocks(ctx, phs, tmconsensus.ConsiderProposedBlocksReason{})
 	if err == tmconsensus.ErrProposedBlockChoiceNotReady {
 		return "", nil
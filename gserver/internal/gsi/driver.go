@@ -2,7 +2,6 @@ package gsi
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"maps"
@@ -10,7 +9,6 @@ import (
 	"slices"
 	"time"
 
-	corecomet "cosmossdk.io/core/comet"
 	corecontext "cosmossdk.io/core/context"
 	coreserver "cosmossdk.io/core/server"
 	"cosmossdk.io/core/store"
@@ -24,6 +22,7 @@ import (
 	"github.com/gordian-engine/gcosmos/gccodec"
 	"github.com/gordian-engine/gcosmos/gcstore"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gp2papi"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/internal/copy/gchan"
 	"github.com/gordian-engine/gcosmos/internal/copy/glog"
@@ -32,6 +31,9 @@ import (
 	"github.com/gordian-engine/gordian/tm/tmconsensus"
 	"github.com/gordian-engine/gordian/tm/tmdriver"
 	"github.com/gordian-engine/gordian/tm/tmengine/tmelink"
+	"github.com/gordian-engine/gordian/tm/tmstore"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
 )
 
 type DriverConfig struct {
@@ -56,6 +58,54 @@ type DriverConfig struct {
 
 	BlockDataRequestCache *gsbd.RequestCache
 	BlockDataStore        gcstore.BlockDataStore
+
+	// Set if the chain compresses block data with zstd,
+	// for decoding recovered block data.
+	BlockDataZstdCodec *gsbd.ZstdCodec
+
+	// If set, the host's handlers for proposed block data
+	// are pruned after each finalization.
+	BlockDataHost *gsbd.Libp2pHost
+
+	// If set, the shards held for other proposers are pruned after each finalization,
+	// and the shards of our own proposals are pushed to the latest validators.
+	BlockDataShardHost *gsbd.ErasureHost
+
+	// Where to find a finalized block's data when the request cache lacks it,
+	// besides the BlockDataStore and the CatchupClient:
+	// the round store holds the proposal's block data locations,
+	// and the host connects to those locations and lists the peers to try.
+	RoundStore tmstore.RoundStore
+	Host       libp2phost.Host
+
+	// If set, the allowlist is updated with every new validator set,
+	// so that validators on a private network can reach each other.
+	PeerAllowlist *gpeer.Allowlist
+
+	// Resolves validators to the peers they operate,
+	// for pushing our proposed block data shards to the validators.
+	ValidatorBindings *gpeer.ValidatorBindings
+
+	// Optimistic executions from the consensus strategy,
+	// reused when finalizing the same block.
+	ExecutionCache *ExecutionCache
+
+	// The engine's committed headers, for the previous block time
+	// when checking the time of a block being finalized.
+	CommittedHeaderStore tmstore.CommittedHeaderStore
+
+	// How far a finalized block's time may be ahead of the local clock
+	// before finalization waits for the clock to catch up.
+	// If zero, [DefaultMaxClockDrift] is used.
+	MaxClockDrift time.Duration
+
+	// Decodes validator public keys from the staking module's validator updates,
+	// by the SDK key type name.
+	CryptoRegistry *gcrypto.Registry
+
+	// The public key types the staking module accepts for validators,
+	// set in the consensus params at genesis.
+	ValidatorPubKeyTypes []string
 }
 
 type Driver struct {
@@ -68,9 +118,30 @@ type Driver struct {
 	bdStore gcstore.BlockDataStore
 
 	bdrCache *gsbd.RequestCache
+	bdHost   *gsbd.Libp2pHost
+	bdShards *gsbd.ErasureHost
+
+	// For recovering block data missing from the request cache.
+	roundStore tmstore.RoundStore
+	host       libp2phost.Host
+	p2pClient  *gsbd.Libp2pClient
+	httpClient *gsbd.HTTPClient
+	txDecoder  transaction.Codec[transaction.Tx]
+	zstdCodec  *gsbd.ZstdCodec
 
 	cuClient *gp2papi.CatchupClient
 
+	execCache *ExecutionCache
+
+	chStore       tmstore.CommittedHeaderStore
+	maxClockDrift time.Duration
+
+	peerAllowlist *gpeer.Allowlist
+	valBindings   *gpeer.ValidatorBindings
+
+	reg         *gcrypto.Registry
+	valKeyTypes []string
+
 	txStore txstore.Store
 
 	am       appmanager.AppManager[transaction.Tx]
@@ -105,9 +176,27 @@ func NewDriver(
 		bdStore: cfg.BlockDataStore,
 
 		bdrCache: cfg.BlockDataRequestCache,
+		bdHost:   cfg.BlockDataHost,
+		bdShards: cfg.BlockDataShardHost,
+
+		roundStore: cfg.RoundStore,
+		host:       cfg.Host,
+		txDecoder:  gccodec.NewTxDecoder(cc.TxConfig),
+		zstdCodec:  cfg.BlockDataZstdCodec,
 
 		cuClient: cfg.CatchupClient,
 
+		execCache: cfg.ExecutionCache,
+
+		chStore:       cfg.CommittedHeaderStore,
+		maxClockDrift: cfg.MaxClockDrift,
+
+		peerAllowlist: cfg.PeerAllowlist,
+		valBindings:   cfg.ValidatorBindings,
+
+		reg:         cfg.CryptoRegistry,
+		valKeyTypes: cfg.ValidatorPubKeyTypes,
+
 		txStore: cfg.TxStore,
 
 		finalizeBlockRequests: cfg.FinalizeBlockRequests,
@@ -119,6 +208,21 @@ func NewDriver(
 		done: make(chan struct{}),
 	}
 
+	if d.maxClockDrift <= 0 {
+		d.maxClockDrift = DefaultMaxClockDrift
+	}
+
+	if cfg.Host != nil {
+		d.p2pClient = gsbd.NewLibp2pClient(
+			log.With("d_sys", "block_data_recovery"), cfg.Host, d.txDecoder,
+		)
+		d.p2pClient.SetZstdCodec(d.zstdCodec)
+	}
+	// Proposal locations are untrusted,
+	// so this client keeps the default restriction to public addresses.
+	d.httpClient = gsbd.NewHTTPClient(nil, d.txDecoder, 0)
+	d.httpClient.SetZstdCodec(d.zstdCodec)
+
 	go d.run(lifeCtx, ag, cc.TxConfig, cfg)
 
 	return d, nil
@@ -223,7 +327,7 @@ func (d *Driver) handleInitialization(
 			MaxBytes:        1024,
 		},
 		Validator: &cometapitypes.ValidatorParams{
-			PubKeyTypes: []string{"ed25519"},
+			PubKeyTypes: d.valKeyTypes,
 		},
 	})
 	blockResp, genesisState, err := d.am.InitGenesis(
@@ -272,16 +376,13 @@ func (d *Driver) handleInitialization(
 
 	gVals := make([]tmconsensus.Validator, len(blockResp.ValidatorUpdates))
 	for i, vu := range blockResp.ValidatorUpdates {
-		if vu.PubKeyType != "ed25519" {
-			panic(fmt.Errorf(
-				"TODO: handle validator with non-ed25519 key type: %q",
-				vu.PubKeyType,
-			))
-		}
-		pk, err := gcrypto.NewEd25519PubKey(vu.PubKey)
+		pk, err := d.reg.Decode(vu.PubKeyType, vu.PubKey)
 		if err != nil {
+			// The staking module only accepts the configured key types,
+			// so this would indicate a mismatch between the registry and those types.
 			panic(fmt.Errorf(
-				"BUG: NewEd25519PubKey should never error; got %w", err,
+				"BUG: failed to decode genesis validator key of type %q: %w",
+				vu.PubKeyType, err,
 			))
 		}
 		gVals[i] = tmconsensus.Validator{
@@ -290,6 +391,9 @@ func (d *Driver) handleInitialization(
 		}
 	}
 
+	d.updatePeerAllowlist(gVals)
+	d.updateShardPeers(gVals)
+
 	resp := tmdriver.InitChainResponse{
 		AppStateHash: stateRoot,
 
@@ -343,8 +447,11 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 	// TODO: the comet implementation does some validation and checking for halt height and time,
 	// which we are not yet doing.
 
-	// TODO: don't hardcode the initial height.
-	const initialHeight = 1
+	blockTime, ok := d.checkFinalizedBlockTime(ctx, req.Header)
+	if !ok {
+		return false
+	}
+
 	if req.Header.Height == initialHeight {
 		appHash, err := d.sdkStore.Commit(store.NewChangeset(initialHeight))
 		if err != nil {
@@ -363,6 +470,8 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 			Validators:   req.Header.NextValidatorSet.Validators,
 			AppStateHash: appHash,
 		}
+		d.updatePeerAllowlist(resp.Validators)
+		d.updateShardPeers(resp.Validators)
 		if !gchan.SendC(
 			ctx, d.log,
 			req.Resp, resp,
@@ -384,60 +493,15 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 		return false
 	}
 
-	var ba BlockAnnotation
-	if err := json.Unmarshal(req.Header.Annotations.Driver, &ba); err != nil {
-		d.log.Warn(
-			"Failed to extract driver annotation from finalize block request",
-			"err", err,
-		)
-		return false
-	}
-
-	blockTime, err := ba.Time()
-	if err != nil {
-		d.log.Warn(
-			"Failed to parse block time from driver annotation",
-			"err", err,
-		)
-		return false
-	}
-
 	var txs []transaction.Tx
 
 	if !gsbd.IsZeroTxDataID(string(req.Header.DataID)) {
-		// The entry must already exist in the cache.
-		// If it doesn't, then something went wrong in the consensus strategy.
-		bdr, ok := d.bdrCache.Get(string(req.Header.DataID))
+		bd, ok := d.awaitBlockData(ctx, req.Header, req.Round)
 		if !ok {
-			panic(fmt.Errorf(
-				"TODO: handle missing entry (%q) in block data request cache",
-				req.Header.DataID,
-			))
-		}
-
-		// We have the bdr, so now we have to block until it's ready.
-		// Optimistic check first.
-		needToBlock := false
-		select {
-		case <-bdr.Ready:
-			// Good.
-		default:
-			needToBlock = true
-		}
-
-		if needToBlock {
-			// TODO: this should have some slightly sophisticated logging,
-			// so that an observer could see why the state machine is stuck here.
-			if _, ok := gchan.RecvC(
-				ctx, d.log,
-				bdr.Ready,
-				"waiting for block data in order to finalize",
-			); !ok {
-				return false
-			}
+			return false
 		}
 
-		txs = bdr.Transactions
+		txs = bd.Txs
 
 		// Save the block data to its store,
 		// so that we can serve it to peers who need it later.
@@ -448,51 +512,54 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 		// It probably should not be in the finalization path at all,
 		// but rather part of the mirror kernel.
 		// But it works enough here for the moment.
-		if err := d.bdStore.SaveBlockData(
-			ctx,
-			req.Header.Height,
-			string(req.Header.DataID),
-			bdr.EncodedTransactions,
-		); err != nil {
-			// Fatal error.
-			d.log.Error(
-				"Failed to write block data",
-				"height", req.Header.Height,
-				"data_id", req.Header.DataID,
-				"err", err,
-			)
-			return false
+		// Block data recovered from the store is already there.
+		if !bd.Stored {
+			if err := d.bdStore.SaveBlockData(
+				ctx,
+				req.Header.Height,
+				string(req.Header.DataID),
+				bd.Encoded,
+			); err != nil {
+				// Fatal error.
+				d.log.Error(
+					"Failed to write block data",
+					"height", req.Header.Height,
+					"data_id", req.Header.DataID,
+					"err", err,
+				)
+				return false
+			}
 		}
 	}
 
-	blockReq := &coreserver.BlockRequest[transaction.Tx]{
-		Height: req.Header.Height,
-
-		Time: blockTime,
-
-		Hash:    req.Header.Hash,
-		AppHash: cID.Hash,
-		ChainId: d.chainID,
-		Txs:     txs,
-	}
-
-	// The app manager requires that comet info is set on its context
-	// when dealing with a delivered block.
-	ctx = context.WithValue(ctx, corecontext.CometInfoKey, corecomet.Info{
-		// Somewhat surprisingly, just the presence of the key
-		// without any values populated, is enough to progress past the panic.
-	})
+	blockReq := newBlockRequest(req.Header, d.chainID, cID.Hash, blockTime, txs)
 
-	blockResp, newState, err := d.am.DeliverBlock(ctx, blockReq)
-	if err != nil {
-		d.log.Warn(
-			"Failed to deliver block",
+	// If the consensus strategy already executed this block on the same state,
+	// reuse that result instead of delivering the block again.
+	var blockResp *coreserver.BlockResponse
+	var newState store.WriterMap
+	if e, ok := d.execCache.Take(blockReq); ok {
+		d.log.Debug(
+			"Reusing optimistic execution of finalized block",
 			"height", blockReq.Height,
-			"err", err,
+			"block_hash", glog.Hex(blockReq.Hash),
 		)
-		return false
+		blockResp, newState = e.Response, e.State
+	} else {
+		blockResp, newState, err = deliverBlock(ctx, d.am, blockReq)
+		if err != nil {
+			d.log.Warn(
+				"Failed to deliver block",
+				"height", blockReq.Height,
+				"err", err,
+			)
+			return false
+		}
 	}
 
+	// No other block at this height can be finalized now.
+	d.execCache.Prune(req.Header.Height)
+
 	// By default, we just use the block's declared next validators block.
 	updatedVals := req.Header.NextValidatorSet.Validators
 	if len(blockResp.ValidatorUpdates) > 0 {
@@ -500,14 +567,26 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 		// so create a clone.
 		updatedVals = slices.Clone(req.Header.NextValidatorSet.Validators)
 
-		// Make a map of pubkeys that have a power change.
-		// TODO: this doesn't respect the public key type, and it should.
+		// Make a map of pubkeys that have a power change,
+		// keyed by the registry encoding so that the key type is respected.
 		var valsToUpdate = make(map[string]uint64)
 		hasDelete := false
 		for _, vu := range blockResp.ValidatorUpdates {
+			pk, err := d.reg.Decode(vu.PubKeyType, vu.PubKey)
+			if err != nil {
+				d.log.Warn(
+					"Skipping validator update with invalid public key",
+					"pub_key_type", vu.PubKeyType,
+					"pub_key_bytes", glog.Hex(vu.PubKey),
+					"power", vu.Power,
+					"err", err,
+				)
+				continue
+			}
+
 			// TODO: vu.Power is an int64, and we are casting it to uint64 here.
 			// There needs to be a safety check on conversion.
-			valsToUpdate[string(vu.PubKey)] = uint64(vu.Power)
+			valsToUpdate[string(d.reg.Marshal(pk))] = uint64(vu.Power)
 
 			if vu.Power == 0 {
 				// Track whether we need to delete any.
@@ -520,7 +599,8 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 		// Now iterate over all the validators, applying the new powers.
 		for i := range updatedVals {
 			// Is the current validator in the update map?
-			newPow, ok := valsToUpdate[string(updatedVals[i].PubKey.PubKeyBytes())]
+			key := string(d.reg.Marshal(updatedVals[i].PubKey))
+			newPow, ok := valsToUpdate[key]
 			if !ok {
 				continue
 			}
@@ -529,7 +609,7 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 			updatedVals[i].Power = newPow
 
 			// Delete this entry.
-			delete(valsToUpdate, string(updatedVals[i].PubKey.PubKeyBytes()))
+			delete(valsToUpdate, key)
 
 			// Stop iterating if this was the last entry.
 			if len(valsToUpdate) == 0 {
@@ -557,15 +637,12 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 				// Another dangerous int64->uint64 conversion.
 				pow := uint64(valsToUpdate[pk])
 
-				// Another poor assumption that we always use ed25519.
-				pubKey, err := gcrypto.NewEd25519PubKey([]byte(pk))
+				// The key was already decoded successfully when building the map.
+				pubKey, err := d.reg.Unmarshal([]byte(pk))
 				if err != nil {
-					d.log.Warn(
-						"Skipping new validator with invalid public key",
-						"pub_key_bytes", glog.Hex(pk),
-						"power", pow,
-					)
-					continue
+					panic(fmt.Errorf(
+						"BUG: failed to unmarshal previously marshaled validator key: %w", err,
+					))
 				}
 
 				updatedVals = append(updatedVals, tmconsensus.Validator{
@@ -599,8 +676,28 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 	}
 	d.log.Info("Committed change to root store", "height", req.Header.Height, "apphash", glog.Hex(appHash))
 
+	// The finalized block data is in the block data store now,
+	// and no other block at or below this height can be finalized,
+	// so release all of those entries from the request cache.
+	if n := d.bdrCache.PurgeThroughHeight(req.Header.Height); n > 0 {
+		d.log.Debug(
+			"Purged block data requests through finalized height",
+			"height", req.Header.Height,
+			"n_purged", n,
+		)
+	}
+	if d.bdHost != nil {
+		d.bdHost.PruneHandlers(req.Header.Height)
+	}
+	if d.bdShards != nil {
+		d.bdShards.PruneShards(req.Header.Height)
+	}
+
 	// TODO: There could be updated consensus params that we care about here.
 
+	d.updatePeerAllowlist(updatedVals)
+	d.updateShardPeers(updatedVals)
+
 	fbResp := tmdriver.FinalizeBlockResponse{
 		Height:    req.Header.Height,
 		Round:     req.Round,
@@ -631,6 +728,33 @@ func (d *Driver) handleFinalization(ctx context.Context, req tmdriver.FinalizeBl
 	return true
 }
 
+// updatePeerAllowlist sets vals as the latest validators on d's peer allowlist,
+// if the driver was configured with one.
+func (d *Driver) updatePeerAllowlist(vals []tmconsensus.Validator) {
+	if d.peerAllowlist == nil {
+		return
+	}
+	d.peerAllowlist.SetValidators(vals)
+}
+
+// updateShardPeers sets the peers of vals as the holders
+// of our proposed block data shards,
+// if the driver was configured with a shard host.
+// Only validators whose binding we have learned have a known peer ID.
+func (d *Driver) updateShardPeers(vals []tmconsensus.Validator) {
+	if d.bdShards == nil || d.valBindings == nil {
+		return
+	}
+
+	ids := make([]libp2ppeer.ID, 0, len(vals))
+	for _, v := range vals {
+		if id, ok := d.valBindings.PeerID(v.PubKey); ok {
+			ids = append(ids, id)
+		}
+	}
+	d.bdShards.SetShardPeers(ids)
+}
+
 func (d *Driver) handleLagStateUpdate(ctx context.Context, ls tmelink.LagState) bool {
 	defer trace.StartRegion(ctx, "handleLagStateUpdate").End()
 
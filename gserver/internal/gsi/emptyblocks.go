@@ -0,0 +1,48 @@
+package gsi
+
+import (
+	"time"
+
+	"github.com/gordian-engine/gordian/tm/tmengine"
+)
+
+// DefaultNoEmptyBlocksWait is the default for [EmptyBlocksTimeoutStrategy.Wait],
+// used when the chain does not set its own.
+const DefaultNoEmptyBlocksWait = time.Minute
+
+// EmptyBlocksTimeoutStrategy wraps a [tmengine.TimeoutStrategy]
+// to extend the proposal timeout while a proposer may be waiting for transactions,
+// as configured by [ConsensusStrategyConfig.NoEmptyBlocks]
+// and [ConsensusStrategyConfig.EmptyBlockInterval].
+//
+// The first round of every height is extended by Interval, if it is positive,
+// which is how long a proposer may wait for transactions
+// before it proposes an empty block.
+// With NoEmptyBlocks, every other round is extended by Wait,
+// as the proposer waits for transactions until the round ends;
+// otherwise, later rounds use the wrapped timeouts unchanged,
+// as the proposer then proposes immediately.
+type EmptyBlocksTimeoutStrategy struct {
+	tmengine.TimeoutStrategy
+
+	NoEmptyBlocks bool
+	Interval      time.Duration
+
+	// How much longer than the wrapped proposal timeout to wait for a proposal
+	// when empty blocks are disabled.
+	// An idle chain moves to a new round about this often,
+	// so a longer wait means fewer rounds without blocks,
+	// but a longer delay when a proposer is offline.
+	Wait time.Duration
+}
+
+func (s EmptyBlocksTimeoutStrategy) ProposalTimeout(height uint64, round uint32) time.Duration {
+	d := s.TimeoutStrategy.ProposalTimeout(height, round)
+	switch {
+	case round == 0 && s.Interval > 0:
+		d += s.Interval
+	case s.NoEmptyBlocks:
+		d += s.Wait
+	}
+	return d
+}
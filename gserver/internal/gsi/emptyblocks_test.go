@@ -0,0 +1,150 @@
+package gsi_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsi"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmengine"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmptyBlocksTimeoutStrategy(t *testing.T) {
+	t.Parallel()
+
+	inner := tmengine.LinearTimeoutStrategy{
+		ProposalBase:      time.Second,
+		ProposalIncrement: 100 * time.Millisecond,
+	}
+	s := gsi.EmptyBlocksTimeoutStrategy{
+		TimeoutStrategy: inner,
+		Interval:        30 * time.Second,
+	}
+
+	// Only the first round's proposal timeout includes the interval.
+	require.Equal(t, 31*time.Second, s.ProposalTimeout(5, 0))
+	require.Equal(t, inner.ProposalTimeout(5, 1), s.ProposalTimeout(5, 1))
+
+	// Other timeouts are unchanged.
+	require.Equal(t, inner.PrevoteDelayTimeout(5, 0), s.PrevoteDelayTimeout(5, 0))
+	require.Equal(t, inner.CommitWaitTimeout(5, 2), s.CommitWaitTimeout(5, 2))
+}
+
+func TestEmptyBlocksTimeoutStrategy_noEmptyBlocks(t *testing.T) {
+	t.Parallel()
+
+	inner := tmengine.LinearTimeoutStrategy{
+		ProposalBase:      time.Second,
+		ProposalIncrement: 100 * time.Millisecond,
+	}
+
+	t.Run("without interval", func(t *testing.T) {
+		t.Parallel()
+
+		s := gsi.EmptyBlocksTimeoutStrategy{
+			TimeoutStrategy: inner,
+			NoEmptyBlocks:   true,
+			Wait:            5 * time.Minute,
+		}
+
+		// Every round waits for the proposer to get transactions.
+		require.Equal(t, inner.ProposalTimeout(5, 0)+5*time.Minute, s.ProposalTimeout(5, 0))
+		require.Equal(t, inner.ProposalTimeout(5, 1)+5*time.Minute, s.ProposalTimeout(5, 1))
+
+		require.Equal(t, inner.PrevoteDelayTimeout(5, 0), s.PrevoteDelayTimeout(5, 0))
+	})
+
+	t.Run("with interval", func(t *testing.T) {
+		t.Parallel()
+
+		s := gsi.EmptyBlocksTimeoutStrategy{
+			TimeoutStrategy: inner,
+			NoEmptyBlocks:   true,
+			Interval:        30 * time.Second,
+			Wait:            gsi.DefaultNoEmptyBlocksWait,
+		}
+
+		// The proposer proposes an empty block once the interval passes in the first round.
+		require.Equal(t, 31*time.Second, s.ProposalTimeout(5, 0))
+		require.Equal(t, inner.ProposalTimeout(5, 1)+gsi.DefaultNoEmptyBlocksWait, s.ProposalTimeout(5, 1))
+	})
+}
+
+func TestConsensusStrategy_noEmptyBlocks_proposesOnceTxsArrive(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pfx := newProposalHandlerFixture(t, ctx, nil, true, func(cfg *gsi.ConsensusStrategyConfig) {
+		cfg.NoEmptyBlocks = true
+	})
+
+	proposalOut := make(chan tmconsensus.Proposal, 1)
+	require.NoError(t, pfx.CS.EnterRound(ctx, pfx.RoundView(), proposalOut))
+
+	// Nothing to propose yet.
+	gtest.NotSendingSoon(t, proposalOut)
+
+	pfx.AddTx(t, ctx, gservertest.NewHashOnlyTransaction(1))
+	p := gtest.ReceiveSoon(t, proposalOut)
+	require.Equal(t, pfx.Provider.dataID, p.DataID)
+}
+
+func TestConsensusStrategy_noEmptyBlocks_proposalTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	proposalTimeout := time.Duration(gtest.ScaleMs(150))
+	pfx := newProposalHandlerFixture(t, ctx, nil, true, func(cfg *gsi.ConsensusStrategyConfig) {
+		cfg.NoEmptyBlocks = true
+		cfg.TimeoutStrategy = tmengine.LinearTimeoutStrategy{ProposalBase: proposalTimeout}
+	})
+
+	proposalOut := make(chan tmconsensus.Proposal, 1)
+	require.NoError(t, pfx.CS.EnterRound(ctx, pfx.RoundView(), proposalOut))
+
+	// Transactions arriving after the proposal timeout are too late for this round,
+	// as the other validators have already prevoted nil.
+	gtest.Sleep(gtest.ScaleMs(300))
+	pfx.AddTx(t, ctx, gservertest.NewHashOnlyTransaction(1))
+
+	// Allow for a few polls of the transaction buffer.
+	gtest.Sleep(gtest.ScaleMs(300))
+	gtest.NotSending(t, proposalOut)
+	require.Empty(t, pfx.Provider.provided)
+}
+
+func TestConsensusStrategy_noEmptyBlocks_stopWaitingAtPrevote(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pfx := newProposalHandlerFixture(t, ctx, nil, true, func(cfg *gsi.ConsensusStrategyConfig) {
+		cfg.NoEmptyBlocks = true
+	})
+
+	proposalOut := make(chan tmconsensus.Proposal, 1)
+	require.NoError(t, pfx.CS.EnterRound(ctx, pfx.RoundView(), proposalOut))
+
+	// Without a proposal, we prevote nil.
+	hash, err := pfx.CS.ChooseProposedBlock(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, hash)
+
+	// Having prevoted, we must not propose in this round
+	// even though transactions are now available.
+	pfx.AddTx(t, ctx, gservertest.NewHashOnlyTransaction(1))
+
+	// Allow for a few polls of the transaction buffer.
+	gtest.Sleep(gtest.ScaleMs(300))
+	gtest.NotSending(t, proposalOut)
+	require.Empty(t, pfx.Provider.provided)
+}
@@ -0,0 +1,159 @@
+package gsi
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	corecomet "cosmossdk.io/core/comet"
+	corecontext "cosmossdk.io/core/context"
+	coreserver "cosmossdk.io/core/server"
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/core/transaction"
+	"cosmossdk.io/server/v2/appmanager"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+)
+
+// BlockExecution is the uncommitted result of delivering a block to the app manager.
+type BlockExecution struct {
+	Request  *coreserver.BlockRequest[transaction.Tx]
+	Response *coreserver.BlockResponse
+	State    corestore.WriterMap
+}
+
+// ExecutionCache holds the results of optimistically executing proposed blocks,
+// keyed by block hash.
+//
+// The [ConsensusStrategy] executes every proposed block it considers valid,
+// and the [Driver] reuses the result if the same block is finalized
+// on top of the same committed state,
+// rather than executing the block a second time.
+//
+// The strategy and the driver run on separate goroutines,
+// so the cache is safe for concurrent use.
+type ExecutionCache struct {
+	mu sync.Mutex
+
+	byHash map[string]BlockExecution
+}
+
+// NewExecutionCache returns a new, empty ExecutionCache.
+func NewExecutionCache() *ExecutionCache {
+	return &ExecutionCache{
+		byHash: make(map[string]BlockExecution),
+	}
+}
+
+// Put stores e as the execution of the block with the given hash,
+// replacing any previous execution of that block.
+func (c *ExecutionCache) Put(blockHash string, e BlockExecution) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.byHash[blockHash] = e
+}
+
+// Has reports whether the cache holds an execution for the block with the given hash.
+func (c *ExecutionCache) Has(blockHash string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.byHash[blockHash]
+	return ok
+}
+
+// Take removes and returns the execution for the block in req,
+// if one exists and it was executed with a request identical to req.
+//
+// A stale execution, such as one run against a different committed state,
+// is discarded and not returned.
+func (c *ExecutionCache) Take(req *coreserver.BlockRequest[transaction.Tx]) (BlockExecution, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.byHash[string(req.Hash)]
+	if !ok {
+		return BlockExecution{}, false
+	}
+	delete(c.byHash, string(req.Hash))
+
+	if !sameBlockRequest(e.Request, req) {
+		return BlockExecution{}, false
+	}
+	return e, true
+}
+
+// Prune removes every execution for a block at or below the given height,
+// as none of those blocks can be finalized anymore.
+func (c *ExecutionCache) Prune(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for hash, e := range c.byHash {
+		if e.Request.Height <= height {
+			delete(c.byHash, hash)
+		}
+	}
+}
+
+// sameBlockRequest reports whether delivering a and b
+// would produce the same result.
+func sameBlockRequest(a, b *coreserver.BlockRequest[transaction.Tx]) bool {
+	if a.Height != b.Height ||
+		a.ChainId != b.ChainId ||
+		!a.Time.Equal(b.Time) ||
+		!bytes.Equal(a.Hash, b.Hash) ||
+		!bytes.Equal(a.AppHash, b.AppHash) ||
+		len(a.Txs) != len(b.Txs) {
+		return false
+	}
+
+	for i := range a.Txs {
+		if a.Txs[i].Hash() != b.Txs[i].Hash() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newBlockRequest returns the request to deliver the block with header h
+// on top of the committed state whose root hash is appHash.
+func newBlockRequest(
+	h tmconsensus.Header,
+	chainID string,
+	appHash []byte,
+	blockTime time.Time,
+	txs []transaction.Tx,
+) *coreserver.BlockRequest[transaction.Tx] {
+	return &coreserver.BlockRequest[transaction.Tx]{
+		Height: h.Height,
+
+		Time: blockTime,
+
+		Hash:    h.Hash,
+		AppHash: appHash,
+		ChainId: chainID,
+		Txs:     txs,
+	}
+}
+
+// deliverBlock delivers req to am without committing the resulting state.
+//
+// Optimistic execution and finalization both deliver blocks through this function,
+// so that an optimistic result is interchangeable with delivering the block at finalization.
+func deliverBlock(
+	ctx context.Context,
+	am appmanager.AppManager[transaction.Tx],
+	req *coreserver.BlockRequest[transaction.Tx],
+) (*coreserver.BlockResponse, corestore.WriterMap, error) {
+	// The app manager requires that comet info is set on its context
+	// when dealing with a delivered block.
+	ctx = context.WithValue(ctx, corecontext.CometInfoKey, corecomet.Info{
+		// Somewhat surprisingly, just the presence of the key
+		// without any values populated, is enough to progress past the panic.
+	})
+
+	return am.DeliverBlock(ctx, req)
+}
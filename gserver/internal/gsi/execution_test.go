@@ -0,0 +1,72 @@
+package gsi_test
+
+import (
+	"testing"
+	"time"
+
+	coreserver "cosmossdk.io/core/server"
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionCache_take(t *testing.T) {
+	t.Parallel()
+
+	c := gsi.NewExecutionCache()
+
+	req := &coreserver.BlockRequest[transaction.Tx]{
+		Height:  3,
+		Time:    time.Unix(1_700_000_000, 0).UTC(),
+		Hash:    []byte("block_hash"),
+		AppHash: []byte("app_hash"),
+		ChainId: "test",
+	}
+	resp := &coreserver.BlockResponse{}
+	c.Put(string(req.Hash), gsi.BlockExecution{Request: req, Response: resp})
+	require.True(t, c.Has(string(req.Hash)))
+
+	// The same block delivered on a different committed state is not reused.
+	stale := *req
+	stale.AppHash = []byte("other_app_hash")
+	_, ok := c.Take(&stale)
+	require.False(t, ok)
+
+	// And the stale execution was discarded.
+	require.False(t, c.Has(string(req.Hash)))
+
+	c.Put(string(req.Hash), gsi.BlockExecution{Request: req, Response: resp})
+
+	// An equivalent request, with a time in another location, is reused.
+	same := *req
+	same.Time = req.Time.Local()
+	e, ok := c.Take(&same)
+	require.True(t, ok)
+	require.Same(t, resp, e.Response)
+
+	// Taking removes the entry.
+	_, ok = c.Take(&same)
+	require.False(t, ok)
+}
+
+func TestExecutionCache_prune(t *testing.T) {
+	t.Parallel()
+
+	c := gsi.NewExecutionCache()
+
+	for h := uint64(1); h <= 3; h++ {
+		hash := string([]byte{byte(h)})
+		c.Put(hash, gsi.BlockExecution{
+			Request: &coreserver.BlockRequest[transaction.Tx]{
+				Height: h,
+				Hash:   []byte(hash),
+			},
+		})
+	}
+
+	c.Prune(2)
+
+	require.False(t, c.Has(string([]byte{1})))
+	require.False(t, c.Has(string([]byte{2})))
+	require.True(t, c.Has(string([]byte{3})))
+}
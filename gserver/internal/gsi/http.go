@@ -12,6 +12,9 @@ import (
 	"cosmossdk.io/core/transaction"
 	"cosmossdk.io/server/v2/appmanager"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/gordian-engine/gcosmos/gcstore"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gcosmos/txstore"
 	"github.com/gordian-engine/gordian/gcrypto"
 	"github.com/gordian-engine/gordian/tm/tmp2p/tmlibp2p"
 	"github.com/gordian-engine/gordian/tm/tmstore"
@@ -38,6 +41,14 @@ type HTTPServerConfig struct {
 	Codec      codec.Codec
 
 	TxBuffer *SDKTxBuf
+
+	// For the Comet-compatible tx route:
+	// the stored transaction results,
+	// and the block data to prove their inclusion,
+	// decompressed with the zstd codec if the chain uses zstd compression.
+	TxStore            txstore.Store
+	BlockDataStore     gcstore.BlockDataStore
+	BlockDataZstdCodec *gsbd.ZstdCodec
 }
 
 func NewHTTPServer(ctx context.Context, log *slog.Logger, cfg HTTPServerConfig) *HTTPServer {
@@ -1,26 +1,46 @@
 package gsi
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"cosmossdk.io/core/event"
+	"cosmossdk.io/core/transaction"
 	abcitypes "github.com/cometbft/cometbft/api/cometbft/abci/v1"
 	v1types "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmtp2p "github.com/cometbft/cometbft/p2p"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/gordian-engine/gcosmos/gcstore"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gcosmos/txstore"
 	"github.com/gorilla/mux"
 )
 
 type compatHandler struct {
 	log *slog.Logger
+
+	txs       txstore.Store
+	bds       gcstore.BlockDataStore
+	txc       transaction.Codec[transaction.Tx]
+	zstdCodec *gsbd.ZstdCodec
 }
 
 func setCompatRoutes(log *slog.Logger, cfg HTTPServerConfig, r *mux.Router) {
 	h := compatHandler{
 		log: log,
+
+		txs:       cfg.TxStore,
+		bds:       cfg.BlockDataStore,
+		txc:       cfg.TxCodec,
+		zstdCodec: cfg.BlockDataZstdCodec,
 	}
 
 	r.HandleFunc("abci_info", h.HandleABCIInfo).Methods("GET")
@@ -288,17 +308,111 @@ func (h compatHandler) HandleSubscribe(w http.ResponseWriter, req *http.Request)
 }
 
 func (h compatHandler) HandleTx(w http.ResponseWriter, req *http.Request) {
-	// Request
-	// hash []byte
-	// prove bool
-	_ = &ctypes.ResultTx{
-		Hash:     []byte{},
-		Height:   0,
-		TxResult: abcitypes.ExecTxResult{},
-		Tx:       tmtypes.Tx{},
-		Proof:    tmtypes.TxProof{},
+	if h.txs == nil {
+		http.Error(w, "no transaction store configured", http.StatusNotImplemented)
+		return
 	}
-	http.Error(w, "not yet implemented", http.StatusNotImplemented)
+
+	// The hash is hex-encoded, optionally with the 0x prefix used in Comet URIs.
+	hash, err := hex.DecodeString(strings.TrimPrefix(req.FormValue("hash"), "0x"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid hash: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var prove bool
+	if p := req.FormValue("prove"); p != "" {
+		prove, err = strconv.ParseBool(p)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid prove value: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx := req.Context()
+	height, txBytes, txResult, err := h.txs.LoadTxByHash(ctx, hash)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to load transaction: %v", err), http.StatusNotFound)
+		return
+	}
+
+	events, err := abciEvents(txResult.Events)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to convert events: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	res := &ctypes.ResultTx{
+		Hash:   hash,
+		Height: int64(height),
+		TxResult: abcitypes.ExecTxResult{
+			GasWanted: int64(txResult.GasWanted),
+			GasUsed:   int64(txResult.GasUsed),
+			Events:    events,
+		},
+		Tx: tmtypes.Tx(txBytes),
+	}
+	if txResult.Error != nil {
+		res.TxResult.Log = txResult.Error.Error()
+	}
+
+	if prove {
+		if h.bds == nil {
+			http.Error(w, "no block data store configured for proofs", http.StatusNotImplemented)
+			return
+		}
+
+		p, err := gsbd.LoadTxProof(ctx, h.bds, height, hash, h.txc, h.zstdCodec)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to prove transaction: %v", err), http.StatusInternalServerError)
+			return
+		}
+		root, err := p.RootHash()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to get proof root hash: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		// The Comet TxProof leaf is the hash of Data,
+		// which is the transaction hash for SDK transactions.
+		res.Index = uint32(p.Proof.Index)
+		res.Proof = tmtypes.TxProof{
+			RootHash: root,
+			Data:     tmtypes.Tx(txBytes),
+			Proof:    p.Proof,
+		}
+	}
+
+	b, err := cmtjson.Marshal(res)
+	if err != nil {
+		h.log.Warn("Failed to marshal tx response", "err", err)
+		http.Error(w, "failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(b)
+}
+
+// abciEvents converts SDK events to ABCI events.
+func abciEvents(evs []event.Event) ([]abcitypes.Event, error) {
+	out := make([]abcitypes.Event, len(evs))
+	for i, ev := range evs {
+		attrs, err := ev.Attributes()
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = abcitypes.Event{
+			Type:       ev.Type,
+			Attributes: make([]abcitypes.EventAttribute, len(attrs)),
+		}
+		for j, a := range attrs {
+			out[i].Attributes[j] = abcitypes.EventAttribute{
+				Key:   a.Key,
+				Value: a.Value,
+			}
+		}
+	}
+	return out, nil
 }
 
 func (h compatHandler) HandleTxSearch(w http.ResponseWriter, req *http.Request) {
@@ -4,13 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"math/rand/v2"
+	"net/http"
+	"net/url"
 	"sync"
+	"time"
 
 	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/internal/copy/gchan"
 	libp2phost "github.com/libp2p/go-libp2p/core/host"
@@ -24,6 +29,12 @@ type pbdP2PFetchRequest struct {
 	DataID string
 	Addrs  []libp2ppeer.AddrInfo
 
+	// Base URLs of locations with the HTTP scheme.
+	HTTPURLs []string
+
+	// Shards of the data held by other validators.
+	Erasure []gsbd.ErasureLocation
+
 	Accepted chan struct{}
 }
 
@@ -31,8 +42,10 @@ type pbdP2PFetchRequest struct {
 // When the worker completes the request,
 // it sends a response to the main goroutine.
 type workerP2PFetchRequest struct {
-	DataID string
-	Addrs  []libp2ppeer.AddrInfo
+	DataID   string
+	Addrs    []libp2ppeer.AddrInfo
+	HTTPURLs []string
+	Erasure  []gsbd.ErasureLocation
 }
 
 // workerFetchResult is the successful result of a proposal's block data fetch.
@@ -48,14 +61,24 @@ type workerFetchResult struct {
 type PBDRetriever struct {
 	log *slog.Logger
 
-	p2pClient *gsbd.Libp2pClient
+	p2pClient  *gsbd.Libp2pClient
+	httpClient *gsbd.HTTPClient
 
 	rCache *gsbd.RequestCache
 
-	decoder transaction.Codec[transaction.Tx]
+	decoder   transaction.Codec[transaction.Tx]
+	zstdCodec *gsbd.ZstdCodec
 
 	host libp2phost.Host
 
+	peerReporter gpeer.Reporter
+
+	relay         *gsbd.Libp2pHost
+	relayInFlight bool
+	fallbackPeers []libp2ppeer.ID
+
+	sourceTimeout time.Duration
+
 	p2pFetchRequests       chan pbdP2PFetchRequest
 	workerP2PFetchRequests chan workerP2PFetchRequest
 
@@ -74,13 +97,53 @@ type PBDRetrieverConfig struct {
 	// How to decode transactions.
 	Decoder transaction.Codec[transaction.Tx]
 
+	// How to decompress zstd-compressed block data,
+	// if the chain uses zstd compression.
+	ZstdCodec *gsbd.ZstdCodec
+
 	// The libp2p host from which connections will be made.
 	Host libp2phost.Host
 
+	// Optional client for locations with the HTTP scheme;
+	// if nil, a client with a short timeout is used.
+	// Either way, the locations come from proposers,
+	// so only public addresses are retrieved from, without redirects.
+	HTTPClient *http.Client
+
+	// Optional limit on the size of block data retrieved over HTTP;
+	// if zero, [gsbd.DefaultHTTPMaxBlockDataSize] is used.
+	MaxHTTPBlockDataSize int
+
 	// How many worker goroutines to run.
 	NWorkers int
+
+	// Optional reporter for peers who serve block data
+	// that does not match the requested data ID.
+	PeerReporter gpeer.Reporter
+
+	// Optional host to serve retrieved block data to other peers.
+	// Serving the data advertises its protocol to connected peers,
+	// so that their retrievers can fall back to us if the proposer is unreachable.
+	Relay *gsbd.Libp2pHost
+
+	// Whether to serve the data through Relay as soon as its retrieval begins,
+	// rather than once it has been retrieved.
+	// Sentry nodes set this, as the validators behind them
+	// list them as locations before they have retrieved the data.
+	RelayInFlight bool
+
+	// Optional peers to try after the locations in the proposal annotation,
+	// such as the sentry nodes in front of this node.
+	FallbackPeers []libp2ppeer.ID
+
+	// Optional limit on each attempt to retrieve the data from a single source;
+	// if zero, [DefaultPBDSourceTimeout] is used.
+	SourceTimeout time.Duration
 }
 
+// DefaultPBDSourceTimeout is the default for [PBDRetrieverConfig.SourceTimeout].
+const DefaultPBDSourceTimeout = 5 * time.Second
+
 func NewPBDRetriever(
 	ctx context.Context,
 	log *slog.Logger,
@@ -95,8 +158,17 @@ func NewPBDRetriever(
 		// p2pClient: cfg.P2PClient,
 		rCache: cfg.RequestCache,
 
-		decoder: cfg.Decoder,
-		host:    cfg.Host,
+		decoder:   cfg.Decoder,
+		zstdCodec: cfg.ZstdCodec,
+		host:      cfg.Host,
+
+		peerReporter: cfg.PeerReporter,
+
+		relay:         cfg.Relay,
+		relayInFlight: cfg.RelayInFlight,
+		fallbackPeers: cfg.FallbackPeers,
+
+		sourceTimeout: cfg.SourceTimeout,
 
 		p2pFetchRequests:       make(chan pbdP2PFetchRequest),                  // Unbuffered.
 		workerP2PFetchRequests: make(chan workerP2PFetchRequest, cfg.NWorkers), // One per worker. Should it be +1?
@@ -104,6 +176,16 @@ func NewPBDRetriever(
 		workerFetchResults: make(chan workerFetchResult, cfg.NWorkers),
 	}
 
+	if r.sourceTimeout <= 0 {
+		r.sourceTimeout = DefaultPBDSourceTimeout
+	}
+
+	r.p2pClient = gsbd.NewLibp2pClient(log.With("serversys", "shard_client"), cfg.Host, cfg.Decoder)
+	r.p2pClient.SetZstdCodec(cfg.ZstdCodec)
+
+	r.httpClient = gsbd.NewHTTPClient(cfg.HTTPClient, cfg.Decoder, cfg.MaxHTTPBlockDataSize)
+	r.httpClient.SetZstdCodec(cfg.ZstdCodec)
+
 	r.wg.Add(1)
 	go r.mainLoop(ctx)
 
@@ -152,13 +234,19 @@ func (r *PBDRetriever) mainLoop(ctx context.Context) {
 			// Or maybe if we have two different schemes in flight for the same data ID.
 			r.rCache.SetInFlight(req.DataID, bdr)
 
+			if r.relay != nil && r.relayInFlight {
+				r.relay.Relay(req.DataID, bdr)
+			}
+
 			// This might be risky, but block sending it to an available worker.
 			// It might be better to just fail if it blocks?
 			if !gchan.SendC(
 				ctx, r.log,
 				r.workerP2PFetchRequests, workerP2PFetchRequest{
-					DataID: req.DataID,
-					Addrs:  req.Addrs,
+					DataID:   req.DataID,
+					Addrs:    req.Addrs,
+					HTTPURLs: req.HTTPURLs,
+					Erasure:  req.Erasure,
 				},
 				"sending p2p fetch request to workers",
 			) {
@@ -175,6 +263,10 @@ func (r *PBDRetriever) mainLoop(ctx context.Context) {
 			ifr.BDR.EncodedTransactions = res.EncodedTxs
 			close(ifr.Ready)
 
+			if r.relay != nil && !r.relayInFlight {
+				r.relay.Relay(res.DataID, ifr.BDR)
+			}
+
 			delete(ifrs, res.DataID)
 		}
 	}
@@ -199,16 +291,39 @@ func (r *PBDRetriever) worker(ctx context.Context, idx int) {
 	}
 }
 
+// pbdSource is one place from which a worker may retrieve proposed block data.
+type pbdSource struct {
+	// Describes the source in log messages.
+	Desc string
+
+	Fetch func(ctx context.Context) ([]transaction.Tx, []byte, error)
+}
+
+// pbdSourceResult is the outcome of fetching from a single [pbdSource].
+type pbdSourceResult struct {
+	Desc string
+
+	Txs        []transaction.Tx
+	EncodedTxs []byte
+
+	Err error
+}
+
+// pbdRaceDelay is how long a worker waits on the sources it has already started
+// before it also starts the next source.
+// A failed source starts the next source immediately.
+const pbdRaceDelay = 500 * time.Millisecond
+
+// pbdRetrieveWindow is how long a worker keeps starting new sources for one data ID.
+// Once the proposer-supplied sources are exhausted,
+// the worker spends the rest of the window looking for connected peers that have the data.
+const pbdRetrieveWindow = 10 * time.Second
+
 func (r *PBDRetriever) workerFetchP2P(
 	ctx context.Context,
 	wLog *slog.Logger,
 	req workerP2PFetchRequest,
 ) bool {
-	dec, err := gsbd.NewBlockDataDecoder(req.DataID, r.decoder)
-	if err != nil {
-		panic(fmt.Errorf("BUG: requested to fetch invalid data ID %q", req.DataID))
-	}
-
 	// Shuffle the addresses in place.
 	// If every node does this, as a courtesy,
 	// then hopefully the first address won't become overloaded immediately.
@@ -218,61 +333,265 @@ func (r *PBDRetriever) workerFetchP2P(
 	rand.Shuffle(len(req.Addrs), func(i, j int) {
 		req.Addrs[i], req.Addrs[j] = req.Addrs[j], req.Addrs[i]
 	})
+	rand.Shuffle(len(req.HTTPURLs), func(i, j int) {
+		req.HTTPURLs[i], req.HTTPURLs[j] = req.HTTPURLs[j], req.HTTPURLs[i]
+	})
+
+	// Peers we have already tried, or will try, over libp2p.
+	tried := map[libp2ppeer.ID]struct{}{
+		r.host.ID(): {},
+	}
+
+	var sources []pbdSource
+
+	// Reconstructing from the shards held by other validators comes first,
+	// to spare the proposer from serving the full data to every validator.
+	for _, el := range req.Erasure {
+		sources = append(sources, pbdSource{
+			Desc: "shards",
+			Fetch: func(ctx context.Context) ([]transaction.Tx, []byte, error) {
+				// The client verifies each shard against its hash,
+				// and the reconstructed data against the data ID.
+				return r.p2pClient.RetrieveShards(ctx, el, req.DataID)
+			},
+		})
+	}
 
 	for _, addr := range req.Addrs {
-		done, ok := r.workerFetchOneP2P(ctx, wLog, req.DataID, addr, dec)
+		if _, ok := tried[addr.ID]; ok {
+			// The proposer may list us as one of its relays.
+			continue
+		}
+		tried[addr.ID] = struct{}{}
+
+		sources = append(sources, r.p2pSource(wLog, req.DataID, addr))
+	}
+
+	// Then any web servers the proposer published to.
+	for _, u := range req.HTTPURLs {
+		sources = append(sources, pbdSource{
+			Desc: u,
+			Fetch: func(ctx context.Context) ([]transaction.Tx, []byte, error) {
+				// The HTTP client verifies the data against the data ID.
+				return r.httpClient.Retrieve(ctx, u, req.DataID)
+			},
+		})
+	}
+
+	// The fallback peers go after the proposer-supplied locations, without shuffling,
+	// as they may only be relaying the data from the proposer anyway.
+	for _, id := range r.fallbackPeers {
+		if _, ok := tried[id]; ok {
+			continue
+		}
+		tried[id] = struct{}{}
+
+		sources = append(sources, r.p2pSource(wLog, req.DataID, r.host.Peerstore().PeerInfo(id)))
+	}
+
+	next := func() (pbdSource, bool) {
+		if len(sources) > 0 {
+			src := sources[0]
+			sources = sources[1:]
+			return src, true
+		}
+
+		// Last, any other peer that has retrieved the data,
+		// in case the proposer went offline right after proposing.
+		id, ok := r.nextPeerWithData(req.DataID, tried)
 		if !ok {
-			return false
+			return pbdSource{}, false
 		}
-		if done {
-			return true
+		return r.p2pSource(wLog, req.DataID, r.host.Peerstore().PeerInfo(id)), true
+	}
+
+	res, found := r.race(ctx, wLog, req.DataID, next)
+	if !found {
+		if ctx.Err() != nil {
+			return false
 		}
+
+		wLog.Warn(
+			"Failed to fetch block data from any source",
+			"data_id", req.DataID,
+		)
+
+		// The outer loop can continue even though we failed here.
+		// The driver recovers the data itself if the block is finalized.
+		return true
 	}
 
-	// We didn't return in the address loop,
-	// which means we failed to fetch the data.
-	wLog.Warn(
-		"Failed to fetch block data from any proposer-supplied address",
-		"data_id", req.DataID,
+	return gchan.SendC(
+		ctx, wLog,
+		r.workerFetchResults, workerFetchResult{
+			DataID:     req.DataID,
+			Txs:        res.Txs,
+			EncodedTxs: res.EncodedTxs,
+		},
+		"sending fetch result to main goroutine",
 	)
+}
+
+// race fetches the block data for dataID from the sources returned by next,
+// starting another source every [pbdRaceDelay] or whenever one fails,
+// until one succeeds or [pbdRetrieveWindow] elapses with no source in progress.
+// Each source is limited to the retriever's source timeout.
+//
+// The sources still in progress are canceled and awaited before race returns.
+func (r *PBDRetriever) race(
+	ctx context.Context,
+	wLog *slog.Logger,
+	dataID string,
+	next func() (pbdSource, bool),
+) (res pbdSourceResult, found bool) {
+	raceCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	// Unbuffered, so losing sources exit through raceCtx.
+	results := make(chan pbdSourceResult)
+	pending := 0
+
+	start := func() bool {
+		src, ok := next()
+		if !ok {
+			return false
+		}
+
+		pending++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			fetchCtx, cancel := context.WithTimeout(raceCtx, r.sourceTimeout)
+			defer cancel()
+
+			txs, encoded, err := src.Fetch(fetchCtx)
+			select {
+			case results <- pbdSourceResult{
+				Desc:       src.Desc,
+				Txs:        txs,
+				EncodedTxs: encoded,
+				Err:        err,
+			}:
+			case <-raceCtx.Done():
+			}
+		}()
+		return true
+	}
+
+	deadline := time.Now().Add(pbdRetrieveWindow)
+	start()
+
+	t := time.NewTimer(pbdRaceDelay)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return pbdSourceResult{}, false
+
+		case res := <-results:
+			pending--
+			if res.Err == nil {
+				return res, true
+			}
+
+			wLog.Info(
+				"Failed to fetch proposed data from source",
+				"source", res.Desc,
+				"data_id", dataID,
+				"err", res.Err,
+			)
+
+			if start() {
+				t.Reset(pbdRaceDelay)
+			}
 
-	// The outer loop can continue even though we failed here.
-	// TODO: we probably need some other way to signal that we need to fall back
-	// to another mechanism of retrieving block data.
-	return true
+		case <-t.C:
+			start()
+			t.Reset(pbdRaceDelay)
+		}
+
+		if pending == 0 && time.Now().After(deadline) {
+			return pbdSourceResult{}, false
+		}
+	}
 }
 
-func (r *PBDRetriever) workerFetchOneP2P(
+// nextPeerWithData returns a connected peer, not yet in tried,
+// that advertises the proposed block data protocol for dataID,
+// and adds it to tried.
+//
+// Every node serves the proposed block data it retrieves,
+// and the libp2p identify protocol informs connected peers
+// whenever a host's supported protocols change,
+// so the protocol doubles as the peer's report that it has the data.
+func (r *PBDRetriever) nextPeerWithData(
+	dataID string, tried map[libp2ppeer.ID]struct{},
+) (libp2ppeer.ID, bool) {
+	pID := libp2pprotocol.ID(gsbd.ProposedBlockDataV1Prefix + dataID)
+	for _, id := range r.host.Network().Peers() {
+		if _, ok := tried[id]; ok {
+			continue
+		}
+
+		supported, err := r.host.Peerstore().SupportsProtocols(id, pID)
+		if err != nil || len(supported) == 0 {
+			continue
+		}
+
+		tried[id] = struct{}{}
+		return id, true
+	}
+
+	return "", false
+}
+
+// p2pSource returns a source for the block data for dataID
+// served by the peer at addr.
+func (r *PBDRetriever) p2pSource(
+	wLog *slog.Logger, dataID string, addr libp2ppeer.AddrInfo,
+) pbdSource {
+	return pbdSource{
+		Desc: addr.ID.String(),
+		Fetch: func(ctx context.Context) ([]transaction.Tx, []byte, error) {
+			return r.fetchP2P(ctx, wLog, dataID, addr)
+		},
+	}
+}
+
+func (r *PBDRetriever) fetchP2P(
 	ctx context.Context,
 	wLog *slog.Logger,
 	dataID string,
 	addr libp2ppeer.AddrInfo,
-	dec *gsbd.BlockDataDecoder,
-) (done, ok bool) {
+) ([]transaction.Tx, []byte, error) {
+	// Sources run concurrently, so each needs its own decoder.
+	dec, err := gsbd.NewBlockDataDecoder(dataID, r.decoder)
+	if err != nil {
+		panic(fmt.Errorf("BUG: requested to fetch invalid data ID %q", dataID))
+	}
+	dec.SetZstdCodec(r.zstdCodec)
+
 	// Ensure we have a connection to the peer.
 	if err := r.host.Connect(ctx, addr); err != nil {
-		wLog.Info(
-			"Failed to connect to peer to fetch proposed data",
-			"peer_id", addr.ID,
-			"data_id", dataID,
-			"err", err,
-		)
-		return false, ctx.Err() == nil
+		return nil, nil, fmt.Errorf("failed to connect to peer: %w", err)
 	}
 
 	// Open the stream to the peer.
 	s, err := r.host.NewStream(ctx, addr.ID, libp2pprotocol.ID(gsbd.ProposedBlockDataV1Prefix+dataID))
 	if err != nil {
-		wLog.Info(
-			"Failed to open stream to peer when attempting to fetch proposed data",
-			"peer_id", addr.ID,
-			"data_id", dataID,
-			"err", err,
-		)
-		return false, ctx.Err() == nil
+		return nil, nil, fmt.Errorf("failed to open stream to peer: %w", err)
 	}
 	defer s.Close()
 
+	// Reads from the stream do not observe the context,
+	// so reset the stream if we time out or lose the race.
+	stop := context.AfterFunc(ctx, func() { _ = s.Reset() })
+	defer stop()
+
 	if err := s.CloseWrite(); err != nil {
 		// Not sure how informative this error is,
 		// but we can log it and keep going anyway.
@@ -295,7 +614,7 @@ func (r *PBDRetriever) workerFetchOneP2P(
 	txs, err := dec.Decode(tee)
 
 	// We are done with the stream, so eagerly close it as a courtesy to the remote end.
-	if closeErr := s.Close(); closeErr != nil {
+	if closeErr := s.Close(); closeErr != nil && ctx.Err() == nil {
 		wLog.Info(
 			"Failed to close stream after fetching proposed data",
 			"peer_id", addr.ID,
@@ -305,13 +624,18 @@ func (r *PBDRetriever) workerFetchOneP2P(
 	}
 
 	if err != nil {
-		wLog.Info(
-			"Failed to decode fetched proposed data",
-			"peer_id", addr.ID,
-			"data_id", dataID,
-			"err", err,
-		)
-		return false, ctx.Err() == nil
+		if ctx.Err() != nil {
+			// The decode failure was most likely due to the canceled context,
+			// so the peer is not at fault.
+			return nil, nil, fmt.Errorf("interrupted decoding fetched data: %w", context.Cause(ctx))
+		}
+		if r.peerReporter != nil && errors.Is(err, gsbd.ErrTxsHashMismatch) {
+			// Only data that was read in full and failed verification
+			// proves the peer served bad data;
+			// a truncated stream may be an ordinary network failure.
+			r.peerReporter.ReportPeer(addr.ID, gpeer.BadBlockDataOffense)
+		}
+		return nil, nil, fmt.Errorf("failed to decode fetched data: %w", err)
 	}
 
 	// No error, and we have the transactions and the decoded data.
@@ -320,18 +644,7 @@ func (r *PBDRetriever) workerFetchOneP2P(
 	// To address that, we would probably need to adjust the Decode signature
 	// to return the number of bytes read,
 	// and ensure we truncate the byte buffer to that same number.
-
-	ok = gchan.SendC(
-		ctx, wLog,
-		r.workerFetchResults, workerFetchResult{
-			DataID:     dataID,
-			Txs:        txs,
-			EncodedTxs: buf.Bytes(),
-		},
-		"sending fetch result to main goroutine",
-	)
-
-	return true, ok
+	return txs, buf.Bytes(), nil
 }
 
 func (r *PBDRetriever) Retrieve(
@@ -352,6 +665,34 @@ func (r *PBDRetriever) Retrieve(
 	}
 
 	for _, loc := range pda.Locations {
+		if loc.Scheme == gsbd.HTTPScheme {
+			u, err := url.Parse(loc.Addr)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				r.log.Debug(
+					"Skipping retrieval location due to invalid HTTP URL",
+					"url", loc.Addr,
+				)
+				continue
+			}
+
+			req.HTTPURLs = append(req.HTTPURLs, loc.Addr)
+			continue
+		}
+
+		if loc.Scheme == gsbd.ErasureScheme {
+			el, err := gsbd.ParseErasureLocation(loc.Addr)
+			if err != nil {
+				r.log.Debug(
+					"Skipping retrieval location due to invalid erasure location",
+					"err", err,
+				)
+				continue
+			}
+
+			req.Erasure = append(req.Erasure, el)
+			continue
+		}
+
 		if loc.Scheme != gsbd.Libp2pScheme {
 			r.log.Warn("Unknown scheme for proposal annotation", "scheme_id", uint8(loc.Scheme))
 			continue
@@ -373,7 +714,7 @@ func (r *PBDRetriever) Retrieve(
 
 	// We've parsed out all the addresses.
 	// If we ended up with zero, something went quite wrong.
-	if len(req.Addrs) == 0 {
+	if len(req.Addrs) == 0 && len(req.HTTPURLs) == 0 && len(req.Erasure) == 0 {
 		return fmt.Errorf("cannot fetch block data for data ID %q; no usable addresses in metadata", dataID)
 	}
 
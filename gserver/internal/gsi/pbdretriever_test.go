@@ -1,13 +1,16 @@
 package gsi_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"log/slog"
 	"testing"
+	"time"
 
 	"cosmossdk.io/core/transaction"
 	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
 	"github.com/gordian-engine/gcosmos/gserver/internal/gsi"
 	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
@@ -15,6 +18,10 @@ import (
 	"github.com/gordian-engine/gordian/tm/tmcodec/tmjson"
 	"github.com/gordian-engine/gordian/tm/tmp2p/tmlibp2p"
 	"github.com/gordian-engine/gordian/tm/tmp2p/tmlibp2p/tmlibp2ptest"
+	libp2phost "github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	libp2pprotocol "github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,6 +62,151 @@ func TestPBDRetriever_completedRequest(t *testing.T) {
 	_ = gtest.ReceiveSoon(t, bdr.Ready)
 }
 
+func TestPBDRetriever_peerWithData(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := gtest.NewLogger(t)
+	net, err := tmlibp2ptest.NewNetwork(ctx, log.With("sys", "net"), tmjson.MarshalCodec{})
+	require.NoError(t, err)
+	defer net.Wait()
+	defer cancel()
+
+	// The proposer, an early retriever, and a late retriever.
+	hosts := make([]libp2phost.Host, 3)
+	for i := range hosts {
+		conn, err := net.Connect(ctx)
+		require.NoError(t, err)
+		hosts[i] = conn.Host().Libp2pHost()
+	}
+	require.NoError(t, net.Stabilize(ctx))
+
+	ph := gsbd.NewLibp2pProviderHost(log.With("sys", "provider_host"), hosts[0])
+	res, err := ph.Provide(ctx, 1, 0, []transaction.Tx{gservertest.NewHashOnlyTransaction(10)})
+	require.NoError(t, err)
+
+	j, err := json.Marshal(gsi.ProposalDriverAnnotation{
+		Locations: res.Addrs,
+	})
+	require.NoError(t, err)
+
+	// The early retriever fetches from the proposer and serves the data back.
+	earlyCache := gsbd.NewRequestCache()
+	early := gsi.NewPBDRetriever(ctx, log.With("sys", "early_retriever"), gsi.PBDRetrieverConfig{
+		RequestCache: earlyCache,
+		Decoder:      gservertest.HashOnlyTransactionDecoder{},
+		Host:         hosts[1],
+		NWorkers:     1,
+		Relay:        gsbd.NewLibp2pProviderHost(log.With("sys", "relay_host"), hosts[1]),
+	})
+	require.NoError(t, early.Retrieve(ctx, res.DataID, j))
+	bdr, ok := earlyCache.Get(res.DataID)
+	require.True(t, ok)
+	_ = gtest.ReceiveSoon(t, bdr.Ready)
+
+	// Then the proposer stops serving the data,
+	// as though it went offline right after proposing.
+	ph.PruneHandlers(1 + gsbd.HandlerGraceHeights)
+
+	lateCache := gsbd.NewRequestCache()
+	late := gsi.NewPBDRetriever(ctx, log.With("sys", "late_retriever"), gsi.PBDRetrieverConfig{
+		RequestCache: lateCache,
+		Decoder:      gservertest.HashOnlyTransactionDecoder{},
+		Host:         hosts[2],
+		NWorkers:     1,
+	})
+	require.NoError(t, late.Retrieve(ctx, res.DataID, j))
+	bdr, ok = lateCache.Get(res.DataID)
+	require.True(t, ok)
+
+	// Discovering the early retriever depends on the identify protocol,
+	// so allow more than the usual short wait.
+	select {
+	case <-bdr.Ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("late retriever did not fetch data from the early retriever")
+	}
+	require.Equal(t, res.Encoded, bdr.EncodedTransactions)
+}
+
+func TestPBDRetriever_reportsOnlyVerificationFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := gtest.NewLogger(t)
+	net, err := tmlibp2ptest.NewNetwork(ctx, log.With("sys", "net"), tmjson.MarshalCodec{})
+	require.NoError(t, err)
+	defer net.Wait()
+	defer cancel()
+
+	// A peer serving the wrong data, a peer truncating the right data, and the retriever.
+	hosts := make([]libp2phost.Host, 3)
+	for i := range hosts {
+		conn, err := net.Connect(ctx)
+		require.NoError(t, err)
+		hosts[i] = conn.Host().Libp2pHost()
+	}
+	require.NoError(t, net.Stabilize(ctx))
+
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+	otherTxs := []transaction.Tx{gservertest.NewHashOnlyTransaction(2)}
+
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+	encoded := bytes.Clone(buf.Bytes())
+
+	buf.Reset()
+	_, err = gsbd.EncodeBlockData(&buf, otherTxs)
+	require.NoError(t, err)
+	otherEncoded := buf.Bytes()
+
+	dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+	pID := libp2pprotocol.ID(gsbd.ProposedBlockDataV1Prefix + dataID)
+	serve := func(h libp2phost.Host, b []byte) {
+		h.SetStreamHandler(pID, func(s libp2pnetwork.Stream) {
+			defer s.Close()
+			_, _ = s.Write(b)
+		})
+	}
+	badHost, truncHost := hosts[0], hosts[1]
+	serve(badHost, otherEncoded)
+	serve(truncHost, encoded[:len(encoded)-1])
+
+	var locs []gsbd.Location
+	for _, h := range []libp2phost.Host{truncHost, badHost} {
+		ai, err := json.Marshal(libp2phost.InfoFromHost(h))
+		require.NoError(t, err)
+		locs = append(locs, gsbd.Location{Scheme: gsbd.Libp2pScheme, Addr: string(ai)})
+	}
+	j, err := json.Marshal(gsi.ProposalDriverAnnotation{Locations: locs})
+	require.NoError(t, err)
+
+	reports := make(chan libp2ppeer.ID, 2)
+	r := gsi.NewPBDRetriever(ctx, log.With("sys", "pbd_retriever"), gsi.PBDRetrieverConfig{
+		RequestCache: gsbd.NewRequestCache(),
+		Decoder:      gservertest.HashOnlyTransactionDecoder{},
+		Host:         hosts[2],
+		NWorkers:     1,
+		PeerReporter: chanPeerReporter(reports),
+	})
+	require.NoError(t, r.Retrieve(ctx, dataID, j))
+
+	// Only the peer whose data was read in full and failed verification is reported.
+	require.Equal(t, badHost.ID(), gtest.ReceiveSoon(t, reports))
+	gtest.NotSendingSoon(t, reports)
+}
+
+type chanPeerReporter chan<- libp2ppeer.ID
+
+func (r chanPeerReporter) ReportPeer(p libp2ppeer.ID, _ gpeer.Offense) {
+	r <- p
+}
+
 type PBDFixture struct {
 	Log *slog.Logger
 
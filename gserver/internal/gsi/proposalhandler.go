@@ -0,0 +1,57 @@
+package gsi
+
+import (
+	"context"
+	"time"
+
+	"cosmossdk.io/core/transaction"
+	"github.com/gordian-engine/gordian/gcrypto"
+)
+
+// ProposalHandler lets the application take part in building and validating proposed blocks,
+// like PrepareProposal and ProcessProposal in ABCI.
+//
+// Set a ProposalHandler through [ConsensusStrategyConfig.ProposalHandler].
+//
+// Implementations must be safe for concurrent use.
+// When a proposer waits for transactions before proposing,
+// as configured by [ConsensusStrategyConfig.NoEmptyBlocks]
+// and [ConsensusStrategyConfig.EmptyBlockInterval],
+// PrepareProposal is called on a separate goroutine
+// and may run at the same time as ProcessProposal.
+type ProposalHandler interface {
+	// PrepareProposal is called when we are about to propose a block,
+	// with the transactions taken from our transaction buffer, which may be empty.
+	// The returned transactions, which may be reordered, filtered, or added to,
+	// are proposed instead.
+	//
+	// If PrepareProposal returns an error, it is logged,
+	// and we do not propose a block in the round.
+	PrepareProposal(ctx context.Context, p ProposalInfo) ([]transaction.Tx, error)
+
+	// ProcessProposal is called for every proposed block,
+	// including our own, after its block data has been retrieved
+	// and before its transactions are executed.
+	// A non-nil error rejects the proposal for the rest of the round,
+	// so we prevote nil unless another proposal is valid.
+	// Neither the proposer nor the peers serving the block data are penalized.
+	//
+	// ProcessProposal must be deterministic,
+	// or else correct validators may disagree about which proposals are valid.
+	ProcessProposal(ctx context.Context, p ProposalInfo) error
+}
+
+// ProposalInfo describes a proposed block to a [ProposalHandler].
+type ProposalInfo struct {
+	Height uint64
+	Round  uint32
+
+	// The block time that is, or will be, in the block annotation.
+	Time time.Time
+
+	// The public key of the validator proposing the block.
+	ProposerPubKey gcrypto.PubKey
+
+	// The block's transactions, in order.
+	Txs []transaction.Tx
+}
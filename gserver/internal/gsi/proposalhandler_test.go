@@ -0,0 +1,368 @@
+package gsi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	coreserver "cosmossdk.io/core/server"
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/core/transaction"
+	"cosmossdk.io/server/v2/appmanager"
+	storev2 "cosmossdk.io/store/v2"
+	"cosmossdk.io/store/v2/proof"
+	"github.com/gordian-engine/gcosmos/gserver/gservertest"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsbd"
+	"github.com/gordian-engine/gcosmos/gserver/internal/gsi"
+	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
+	"github.com/gordian-engine/gordian/gdriver/gtxbuf"
+	"github.com/gordian-engine/gordian/tm/tmconsensus"
+	"github.com/gordian-engine/gordian/tm/tmconsensus/tmconsensustest"
+	"github.com/gordian-engine/gordian/tm/tmstore/tmmemstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusStrategy_PrepareProposal_error(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ph := &fakeProposalHandler{
+		prepareErr: errors.New("prepare failed"),
+	}
+	pfx := newProposalHandlerFixture(t, ctx, ph, true)
+	pfx.AddTx(t, ctx, gservertest.NewHashOnlyTransaction(1))
+
+	proposalOut := make(chan tmconsensus.Proposal, 1)
+
+	// The error must not propagate to the engine, which would stop the node.
+	require.NoError(t, pfx.CS.EnterRound(ctx, pfx.RoundView(), proposalOut))
+
+	require.Len(t, ph.prepared, 1)
+	require.Empty(t, pfx.Provider.provided)
+
+	// And we did not propose anything.
+	select {
+	case p := <-proposalOut:
+		t.Fatalf("expected no proposal, got %#v", p)
+	default:
+	}
+}
+
+func TestConsensusStrategy_PrepareProposal_modifiedTxs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	buffered := []transaction.Tx{
+		gservertest.NewHashOnlyTransaction(1),
+		gservertest.NewHashOnlyTransaction(2),
+	}
+	prepared := []transaction.Tx{
+		buffered[1],
+		gservertest.NewHashOnlyTransaction(3),
+	}
+
+	ph := &fakeProposalHandler{
+		prepareTxs: prepared,
+	}
+	pfx := newProposalHandlerFixture(t, ctx, ph, true)
+	for _, tx := range buffered {
+		pfx.AddTx(t, ctx, tx)
+	}
+
+	proposalOut := make(chan tmconsensus.Proposal, 1)
+	require.NoError(t, pfx.CS.EnterRound(ctx, pfx.RoundView(), proposalOut))
+
+	// The application saw the buffered transactions.
+	require.Len(t, ph.prepared, 1)
+	pi := ph.prepared[0]
+	require.Equal(t, uint64(1), pi.Height)
+	require.Zero(t, pi.Round)
+	require.True(t, pi.ProposerPubKey.Equal(pfx.Fx.PrivVals[0].Val.PubKey))
+	require.Equal(t, buffered, pi.Txs)
+
+	// But the prepared transactions were provided and proposed.
+	require.Equal(t, prepared, pfx.Provider.provided)
+
+	p := gtest.ReceiveSoon(t, proposalOut)
+	require.Equal(t, pfx.Provider.dataID, p.DataID)
+
+	var ba gsi.BlockAnnotation
+	require.NoError(t, json.Unmarshal(p.BlockAnnotations.Driver, &ba))
+	bt, err := ba.Time()
+	require.NoError(t, err)
+	require.True(t, bt.Equal(pi.Time))
+}
+
+func TestConsensusStrategy_ProcessProposal_accept(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ph := &fakeProposalHandler{}
+	pfx := newProposalHandlerFixture(t, ctx, ph, false)
+	require.NoError(t, pfx.CS.EnterRound(ctx, pfx.RoundView(), nil))
+
+	txs := []transaction.Tx{
+		gservertest.NewHashOnlyTransaction(1),
+		gservertest.NewHashOnlyTransaction(2),
+	}
+	bt := time.Now().Add(-time.Second)
+	proposed := pfx.ProposedHeader(t, txs, bt)
+
+	hash, err := pfx.CS.ConsiderProposedBlocks(
+		ctx, []tmconsensus.ProposedHeader{proposed}, tmconsensus.ConsiderProposedBlocksReason{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, string(proposed.Header.Hash), hash)
+
+	require.Len(t, ph.processed, 1)
+	pi := ph.processed[0]
+	require.Equal(t, uint64(1), pi.Height)
+	require.Zero(t, pi.Round)
+	require.True(t, pi.Time.Equal(bt))
+	require.True(t, pi.ProposerPubKey.Equal(proposed.ProposerPubKey))
+	require.Equal(t, txs, pi.Txs)
+
+	// The accepted block was executed.
+	require.Len(t, pfx.AppManager.delivered, 1)
+	require.Equal(t, txs, pfx.AppManager.delivered[0].Txs)
+	require.True(t, pfx.ExecCache.Has(string(proposed.Header.Hash)))
+}
+
+func TestConsensusStrategy_ProcessProposal_reject(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ph := &fakeProposalHandler{
+		processErr: errors.New("rejected"),
+	}
+	pfx := newProposalHandlerFixture(t, ctx, ph, false)
+	require.NoError(t, pfx.CS.EnterRound(ctx, pfx.RoundView(), nil))
+
+	txs := []transaction.Tx{gservertest.NewHashOnlyTransaction(1)}
+	proposed := pfx.ProposedHeader(t, txs, time.Now().Add(-time.Second))
+	phs := []tmconsensus.ProposedHeader{proposed}
+
+	_, err := pfx.CS.ConsiderProposedBlocks(ctx, phs, tmconsensus.ConsiderProposedBlocksReason{})
+	require.ErrorIs(t, err, tmconsensus.ErrProposedBlockChoiceNotReady)
+	require.Len(t, ph.processed, 1)
+
+	// The rejected block was not executed.
+	require.Empty(t, pfx.AppManager.delivered)
+
+	// The proposal stays rejected for the round, without asking the application again.
+	_, err = pfx.CS.ConsiderProposedBlocks(ctx, phs, tmconsensus.ConsiderProposedBlocksReason{})
+	require.ErrorIs(t, err, tmconsensus.ErrProposedBlockChoiceNotReady)
+	require.Len(t, ph.processed, 1)
+
+	// So once the proposal delay elapses, we prevote nil.
+	hash, err := pfx.CS.ChooseProposedBlock(ctx, phs)
+	require.NoError(t, err)
+	require.Empty(t, hash)
+}
+
+type proposalHandlerFixture struct {
+	Fx *tmconsensustest.Fixture
+
+	CS *gsi.ConsensusStrategy
+
+	TxBuf      *gsi.SDKTxBuf
+	Provider   *fakeBlockDataProvider
+	AppManager *fakeDeliverAppManager
+	ExecCache  *gsi.ExecutionCache
+	BDRCache   *gsbd.RequestCache
+}
+
+// newProposalHandlerFixture returns a fixture for a consensus strategy at height 1,
+// using ph as its proposal handler.
+// If proposer is true, the strategy signs with the key of the first validator,
+// which proposes in every round.
+// Any configure functions may adjust the strategy's configuration before it is created.
+func newProposalHandlerFixture(
+	t *testing.T, ctx context.Context, ph gsi.ProposalHandler, proposer bool,
+	configure ...func(*gsi.ConsensusStrategyConfig),
+) *proposalHandlerFixture {
+	t.Helper()
+
+	log := gtest.NewLogger(t)
+
+	fx := tmconsensustest.NewEd25519Fixture(2)
+
+	txBuf := gtxbuf.New(
+		ctx, log.With("sys", "txbuf"),
+		func(_ context.Context, s corestore.ReaderMap, _ transaction.Tx) (corestore.ReaderMap, error) {
+			return s, nil
+		},
+		func(context.Context, []transaction.Tx) func(transaction.Tx) bool {
+			return func(transaction.Tx) bool { return false }
+		},
+	)
+	t.Cleanup(txBuf.Wait)
+	require.True(t, txBuf.Initialize(ctx, nil))
+
+	pfx := &proposalHandlerFixture{
+		Fx: fx,
+
+		TxBuf:      txBuf,
+		Provider:   new(fakeBlockDataProvider),
+		AppManager: new(fakeDeliverAppManager),
+		ExecCache:  gsi.NewExecutionCache(),
+		BDRCache:   gsbd.NewRequestCache(),
+	}
+
+	cfg := gsi.ConsensusStrategyConfig{
+		ProposerSelection: func(
+			_ context.Context, _ uint64, _ uint32, _ tmconsensus.ValidatorSet,
+		) tmconsensus.Validator {
+			return fx.PrivVals[0].Val
+		},
+
+		AppManager: pfx.AppManager,
+
+		ChainID:        "test-chain",
+		Store:          fakeRootStore{},
+		ExecutionCache: pfx.ExecCache,
+
+		CommittedHeaderStore: tmmemstore.NewCommittedHeaderStore(),
+
+		TxBuf: txBuf,
+
+		BlockDataProvider:     pfx.Provider,
+		BlockDataRequestCache: pfx.BDRCache,
+
+		ProposalHandler: ph,
+	}
+	if proposer {
+		cfg.Signer = tmconsensus.PassthroughSigner{
+			Signer:          fx.PrivVals[0].Signer,
+			SignatureScheme: fx.SignatureScheme,
+		}
+	}
+
+	for _, fn := range configure {
+		fn(&cfg)
+	}
+
+	pfx.CS = gsi.NewConsensusStrategy(ctx, log.With("sys", "consensus_strategy"), cfg)
+
+	return pfx
+}
+
+func (f *proposalHandlerFixture) AddTx(t *testing.T, ctx context.Context, tx transaction.Tx) {
+	t.Helper()
+	require.NoError(t, f.TxBuf.AddTx(ctx, tx))
+}
+
+func (f *proposalHandlerFixture) RoundView() tmconsensus.RoundView {
+	return tmconsensus.RoundView{
+		Height:       1,
+		ValidatorSet: f.Fx.ValSet(),
+	}
+}
+
+// ProposedHeader returns a header at height 1 and round 0,
+// proposed by the second validator, with the given transactions and block time.
+// The block data is made available in the request cache,
+// as if it had already been retrieved.
+func (f *proposalHandlerFixture) ProposedHeader(
+	t *testing.T, txs []transaction.Tx, bt time.Time,
+) tmconsensus.ProposedHeader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	require.NoError(t, err)
+	dataID := gsbd.DataID(1, 0, uint32(sz), txs)
+	f.BDRCache.SetImmediatelyAvailable(dataID, txs, buf.Bytes())
+
+	ph := f.Fx.NextProposedHeader([]byte(dataID), 1)
+	ph.Header.Annotations.Driver, err = json.Marshal(gsi.NewBlockAnnotation(bt))
+	require.NoError(t, err)
+	f.Fx.RecalculateHash(&ph.Header)
+	f.Fx.SignProposal(context.Background(), &ph, 1)
+
+	return ph
+}
+
+// fakeProposalHandler records every call,
+// and returns the configured results.
+type fakeProposalHandler struct {
+	prepareTxs []transaction.Tx
+	prepareErr error
+
+	processErr error
+
+	prepared  []gsi.ProposalInfo
+	processed []gsi.ProposalInfo
+}
+
+func (h *fakeProposalHandler) PrepareProposal(
+	_ context.Context, p gsi.ProposalInfo,
+) ([]transaction.Tx, error) {
+	h.prepared = append(h.prepared, p)
+	return h.prepareTxs, h.prepareErr
+}
+
+func (h *fakeProposalHandler) ProcessProposal(_ context.Context, p gsi.ProposalInfo) error {
+	h.processed = append(h.processed, p)
+	return h.processErr
+}
+
+// fakeBlockDataProvider encodes provided transactions without serving them anywhere.
+type fakeBlockDataProvider struct {
+	provided []transaction.Tx
+	dataID   string
+}
+
+func (p *fakeBlockDataProvider) Provide(
+	_ context.Context, height uint64, round uint32, txs []transaction.Tx,
+) (gsbd.ProvideResult, error) {
+	var buf bytes.Buffer
+	sz, err := gsbd.EncodeBlockData(&buf, txs)
+	if err != nil {
+		return gsbd.ProvideResult{}, err
+	}
+
+	p.provided = txs
+	p.dataID = gsbd.DataID(height, round, uint32(sz), txs)
+	return gsbd.ProvideResult{
+		DataID:  p.dataID,
+		Encoded: buf.Bytes(),
+	}, nil
+}
+
+// fakeDeliverAppManager only implements DeliverBlock,
+// successfully applying every transaction.
+type fakeDeliverAppManager struct {
+	appmanager.AppManager[transaction.Tx]
+
+	delivered []*coreserver.BlockRequest[transaction.Tx]
+}
+
+func (m *fakeDeliverAppManager) DeliverBlock(
+	_ context.Context, req *coreserver.BlockRequest[transaction.Tx],
+) (*coreserver.BlockResponse, corestore.WriterMap, error) {
+	m.delivered = append(m.delivered, req)
+	return &coreserver.BlockResponse{
+		TxResults: make([]coreserver.TxResult, len(req.Txs)),
+	}, nil, nil
+}
+
+// fakeRootStore only implements LastCommitID.
+type fakeRootStore struct {
+	storev2.RootStore
+}
+
+func (fakeRootStore) LastCommitID() (proof.CommitID, error) {
+	return proof.CommitID{Hash: []byte("app_hash")}, nil
+}
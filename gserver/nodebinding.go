@@ -0,0 +1,80 @@
+package gserver
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+)
+
+// nodeBindingFileName is the name of the file, within the home config directory,
+// holding the validator's signed binding to this node's peer ID.
+const nodeBindingFileName = "gordian_node_binding.json"
+
+// defaultNodeBindingPath returns the default node binding path within homeDir.
+func defaultNodeBindingPath(homeDir string) string {
+	return filepath.Join(homeDir, "config", nodeBindingFileName)
+}
+
+// loadNodeBinding loads the validator binding at path, if the file exists,
+// and checks that it binds our validator key to our node key on our chain.
+//
+// Without a binding, peers cannot tell that this node operates our validator,
+// so validators on a private network refuse our connections
+// and proposers do not push block data shards to us.
+func (c *Component) loadNodeBinding(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	j, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			c.log.Warn(
+				"No validator node binding; peers will not recognize this node as our validator (see the node-key bind command)",
+				"path", path,
+			)
+			return nil
+		}
+		return fmt.Errorf("failed to read validator node binding: %w", err)
+	}
+
+	b, err := gpeer.UnmarshalValidatorBinding(c.reg, j)
+	if err != nil {
+		return fmt.Errorf("failed to parse validator node binding %q: %w", path, err)
+	}
+
+	if c.nodeKey == nil {
+		return fmt.Errorf(
+			"validator node binding %q requires a persistent node key (set --%s)",
+			path, nodeKeyPathFlag,
+		)
+	}
+	id, err := libp2ppeer.IDFromPrivateKey(c.nodeKey)
+	if err != nil {
+		return fmt.Errorf("failed to derive peer ID from node key: %w", err)
+	}
+	if b.PeerID != id {
+		return fmt.Errorf(
+			"validator node binding %q is for peer %s, but the node key is for peer %s",
+			path, b.PeerID, id,
+		)
+	}
+	if !b.PubKey.Equal(c.signer.PubKey()) {
+		return fmt.Errorf("validator node binding %q is signed by a different validator key", path)
+	}
+	if b.ChainID != c.chainID {
+		return fmt.Errorf(
+			"validator node binding %q is for chain %q, not %q", path, b.ChainID, c.chainID,
+		)
+	}
+	if err := b.Verify(); err != nil {
+		return fmt.Errorf("invalid validator node binding %q: %w", path, err)
+	}
+
+	c.nodeBinding = &b
+	return nil
+}
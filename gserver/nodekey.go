@@ -0,0 +1,208 @@
+package gserver
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/libp2p/go-libp2p"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// nodeKeyFileName is the name of the file, within the home config directory,
+// holding the libp2p identity of a gcosmos node.
+//
+// This is deliberately distinct from Comet's node_key.json,
+// which the SDK's init command creates in a different format.
+const nodeKeyFileName = "gordian_node_key.json"
+
+// defaultNodeKeyPath returns the default node key path within homeDir.
+func defaultNodeKeyPath(homeDir string) string {
+	return filepath.Join(homeDir, "config", nodeKeyFileName)
+}
+
+// signerNodeKeyFileName is the name of the file, within the home config directory,
+// holding the identity that the signer command presents to validators over TCP.
+const signerNodeKeyFileName = "gordian_signer_node_key.json"
+
+// nodeKeyFile is the JSON representation of the node key file.
+type nodeKeyFile struct {
+	// The libp2p protobuf encoding of the private key.
+	// Encoded as base64 in JSON.
+	PrivKey []byte `json:"priv_key"`
+}
+
+// generateNodeKey creates a new ed25519 node key and writes it to path.
+// It fails if a file already exists at path.
+func generateNodeKey(path string) (libp2pcrypto.PrivKey, error) {
+	priv, _, err := libp2pcrypto.GenerateEd25519Key(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate node key: %w", err)
+	}
+
+	if err := writeNodeKey(path, priv); err != nil {
+		return nil, err
+	}
+	return priv, nil
+}
+
+// writeNodeKey writes priv as the node key at path.
+// It fails if a file already exists at path.
+func writeNodeKey(path string, priv libp2pcrypto.PrivKey) error {
+	b, err := libp2pcrypto.MarshalPrivateKey(priv)
+	if err != nil {
+		return fmt.Errorf("failed to marshal node key: %w", err)
+	}
+	j, err := json.Marshal(nodeKeyFile{PrivKey: b})
+	if err != nil {
+		return fmt.Errorf("failed to marshal node key file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for node key: %w", err)
+	}
+
+	// O_EXCL so that we never clobber an existing identity.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create node key file: %w", err)
+	}
+	if _, err := f.Write(j); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write node key file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close node key file: %w", err)
+	}
+
+	return nil
+}
+
+// loadNodeKey reads the node key at path.
+// If the file does not exist, the returned error wraps [os.ErrNotExist].
+func loadNodeKey(path string) (libp2pcrypto.PrivKey, error) {
+	j, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node key file: %w", err)
+	}
+
+	var nkf nodeKeyFile
+	if err := json.Unmarshal(j, &nkf); err != nil {
+		return nil, fmt.Errorf("failed to parse node key file %q: %w", path, err)
+	}
+
+	priv, err := libp2pcrypto.UnmarshalPrivateKey(nkf.PrivKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal node key in %q: %w", path, err)
+	}
+	return priv, nil
+}
+
+// loadOrGenerateNodeKey loads the node key at path,
+// generating and persisting a new key if the file does not yet exist.
+func loadOrGenerateNodeKey(path string) (priv libp2pcrypto.PrivKey, created bool, err error) {
+	priv, err = loadNodeKey(path)
+	if err == nil {
+		return priv, false, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, false, err
+	}
+
+	priv, err = generateNodeKey(path)
+	if err != nil {
+		return nil, false, err
+	}
+	return priv, true, nil
+}
+
+// nodeKeyEd25519 returns the ed25519 key underlying the node key priv,
+// which identifies the node to a remote signer over TCP
+// by the same peer ID it has on the p2p network.
+func nodeKeyEd25519(priv libp2pcrypto.PrivKey) (ed25519.PrivateKey, error) {
+	k, ok := priv.(*libp2pcrypto.Ed25519PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("node key has unsupported type %s (must be ed25519)", priv.Type())
+	}
+	raw, err := k.Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw node key: %w", err)
+	}
+	return ed25519.PrivateKey(raw), nil
+}
+
+// peerIDEd25519 returns the ed25519 public key embedded in the peer ID s.
+func peerIDEd25519(s string) (ed25519.PublicKey, error) {
+	id, err := libp2ppeer.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer ID %q: %w", s, err)
+	}
+	pub, err := id.ExtractPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract public key from peer ID %q: %w", s, err)
+	}
+	k, ok := pub.(*libp2pcrypto.Ed25519PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("peer ID %q has unsupported key type %s (must be ed25519)", s, pub.Type())
+	}
+	raw, err := k.Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw public key of peer ID %q: %w", s, err)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// hostAddrOptions returns the libp2p options to listen on
+// and to announce the given multiaddrs.
+//
+// Both listen and announce are lists of multiaddrs separated by commas or newlines.
+// If listen is blank, libp2p's default listen addresses are used.
+// If announce is blank, libp2p announces the addresses it is listening on.
+func hostAddrOptions(listen, announce string) ([]libp2p.Option, error) {
+	var opts []libp2p.Option
+
+	if la := splitFlagList(listen); len(la) > 0 {
+		for _, a := range la {
+			if _, err := multiaddr.NewMultiaddr(a); err != nil {
+				return nil, fmt.Errorf("invalid listen address %q: %w", a, err)
+			}
+		}
+		opts = append(opts, libp2p.ListenAddrStrings(la...))
+	}
+
+	if aa := splitFlagList(announce); len(aa) > 0 {
+		mas := make([]multiaddr.Multiaddr, len(aa))
+		for i, a := range aa {
+			ma, err := multiaddr.NewMultiaddr(a)
+			if err != nil {
+				return nil, fmt.Errorf("invalid announce address %q: %w", a, err)
+			}
+			mas[i] = ma
+		}
+		opts = append(opts, libp2p.AddrsFactory(func([]multiaddr.Multiaddr) []multiaddr.Multiaddr {
+			return mas
+		}))
+	}
+
+	return opts, nil
+}
+
+// splitFlagList splits s on commas and newlines,
+// discarding surrounding whitespace and empty entries.
+func splitFlagList(s string) []string {
+	var out []string
+	for _, a := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '\n'
+	}) {
+		if a = strings.TrimSpace(a); a != "" {
+			out = append(out, a)
+		}
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package gserver
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gordian-engine/gcosmos/gserver/internal/gpeer"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/pnet"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// validatorsAllowlistEntry is the special entry in the peer allowlist flag
+// indicating that the current validators are allowed to connect.
+const validatorsAllowlistEntry = "validators"
+
+// parsePeerAllowlist parses the comma-separated value of the peer allowlist flag.
+// The seed addresses, if any, and the implicit peers, such as persistent peers,
+// are implicitly allowed.
+// The validators, if allowed, are resolved to peers through bindings.
+//
+// If s is blank, parsePeerAllowlist returns a nil allowlist,
+// indicating that any peer may connect.
+func parsePeerAllowlist(
+	s, seedAddrs string, implicit []libp2ppeer.ID, bindings *gpeer.ValidatorBindings,
+) (a *gpeer.Allowlist, withValidators bool, err error) {
+	entries := splitFlagList(s)
+	if len(entries) == 0 {
+		return nil, false, nil
+	}
+
+	var ids []libp2ppeer.ID
+	for _, e := range entries {
+		if e == validatorsAllowlistEntry {
+			withValidators = true
+			continue
+		}
+
+		id, err := libp2ppeer.Decode(e)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid peer ID %q in allowlist: %w", e, err)
+		}
+		ids = append(ids, id)
+	}
+
+	// Without the seeds, we would never be able to discover anyone.
+	for _, sa := range strings.Split(seedAddrs, "\n") {
+		if sa == "" {
+			continue
+		}
+		ai, err := libp2ppeer.AddrInfoFromString(sa)
+		if err != nil {
+			// The seed connection will log the parse failure later.
+			continue
+		}
+		ids = append(ids, ai.ID)
+	}
+	ids = append(ids, implicit...)
+
+	return gpeer.NewAllowlist(ids, bindings), withValidators, nil
+}
+
+// loadPSK reads a libp2p private network key
+// in the standard swarm.key format from path.
+func loadPSK(path string) (pnet.PSK, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open private network key file: %w", err)
+	}
+	defer f.Close()
+
+	psk, err := pnet.DecodeV1PSK(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private network key in %q: %w", path, err)
+	}
+	return psk, nil
+}
+
+// checkPSKListenAddrs returns an error if any of the listen addresses
+// use a transport that libp2p cannot secure with a pre-shared key.
+func checkPSKListenAddrs(listen string) error {
+	for _, a := range splitFlagList(listen) {
+		ma, err := multiaddr.NewMultiaddr(a)
+		if err != nil {
+			// Already validated elsewhere.
+			continue
+		}
+		for _, p := range ma.Protocols() {
+			if p.Code == multiaddr.P_QUIC || p.Code == multiaddr.P_QUIC_V1 {
+				return fmt.Errorf("cannot listen on %q: libp2p private networks do not support QUIC", a)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package gserver
+
+import (
+	"fmt"
+	"strconv"
+
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Values for the node mode flag.
+const (
+	// A validator node signs with the validator key from the Comet config.
+	validatorNodeMode = "validator"
+
+	// A full node follows the chain without signing,
+	// and it relays proposed block data to its peers while still retrieving it,
+	// which makes it suitable as a sentry in front of a validator.
+	fullNodeMode = "full"
+)
+
+// parseNodeMode validates the value of the node mode flag,
+// treating a blank value as [validatorNodeMode].
+func parseNodeMode(s string) (string, error) {
+	switch s {
+	case "", validatorNodeMode:
+		return validatorNodeMode, nil
+	case fullNodeMode:
+		return fullNodeMode, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid node mode %q (must be %q or %q)", s, validatorNodeMode, fullNodeMode,
+		)
+	}
+}
+
+// parsePersistentPeers parses the comma- or newline-separated multiaddrs
+// of the persistent peers flag.
+// Each multiaddr must include the peer ID, e.g. /ip4/10.0.0.1/tcp/26656/p2p/12D3KooW...
+func parsePersistentPeers(s string) ([]libp2ppeer.AddrInfo, error) {
+	var mas []multiaddr.Multiaddr
+	for _, a := range splitFlagList(s) {
+		ma, err := multiaddr.NewMultiaddr(a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid persistent peer address %q: %w", a, err)
+		}
+		mas = append(mas, ma)
+	}
+
+	// Multiple addresses for the same peer are grouped into a single AddrInfo.
+	ais, err := libp2ppeer.AddrInfosFromP2pAddrs(mas...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid persistent peer addresses: %w", err)
+	}
+	return ais, nil
+}
+
+// boolFlag returns the boolean value of the named flag in cfg,
+// or def if the flag is not set.
+// Depending on its source, the value may be a bool or a string.
+func boolFlag(cfg map[string]any, name string, def bool) (bool, error) {
+	switch v := cfg[name].(type) {
+	case nil:
+		return def, nil
+	case bool:
+		return v, nil
+	case string:
+		if v == "" {
+			return def, nil
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("invalid value %q for %s: %w", v, name, err)
+		}
+		return b, nil
+	default:
+		return false, fmt.Errorf("invalid type %T for %s", v, name)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"slices"
@@ -66,6 +68,10 @@ type Chain struct {
 	// Path to a canonical genesis.
 	// This file must be treated as read-only.
 	CanonicalGenesisPath string
+
+	// Optional additional arguments to the start command
+	// for the validator at the given index.
+	ExtraStartArgs func(idx int) []string
 }
 
 func ConfigureChain(t *testing.T, ctx context.Context, cfg ChainConfig) Chain {
@@ -322,6 +328,10 @@ func (c Chain) Start(t *testing.T, ctx context.Context, nVals int) ChainAddresse
 				}
 
 				startCmd = append(startCmd, c.RootCmds[i].sqlitePathArgs()...)
+
+				if c.ExtraStartArgs != nil {
+					startCmd = append(startCmd, c.ExtraStartArgs(i)...)
+				}
 			}
 
 			_ = c.RootCmds[i].RunC(ctx, startCmd...)
@@ -399,6 +409,7 @@ func AddLateNode(
 	chainID string,
 	canonicalGenesisPath string,
 	seedPath string,
+	extraStartArgs ...string,
 ) (httpAddrFile string) {
 	t.Helper()
 
@@ -451,6 +462,7 @@ func AddLateNode(
 				"--g-seed-addrs", string(bytes.TrimSuffix(seedAddrs, []byte("\n"))),
 			)
 			startCmd = append(startCmd, e.sqlitePathArgs()...)
+			startCmd = append(startCmd, extraStartArgs...)
 		}
 
 		_ = e.RunC(ctx, startCmd...)
@@ -462,6 +474,168 @@ func AddLateNode(
 	return httpAddrFile
 }
 
+// ReadLateNodeHTTPAddr returns the HTTP address that a node started by [AddLateNode]
+// writes to httpAddrFile, failing the test if the address is not written in time.
+func ReadLateNodeHTTPAddr(t *testing.T, httpAddrFile string) string {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		b, err := os.ReadFile(httpAddrFile)
+		if err != nil {
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		s, ok := strings.CutSuffix(string(b), "\n")
+		if !ok {
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		return s
+	}
+
+	t.Fatal("did not read http address from file before deadline")
+	return ""
+}
+
+// GetVotingHeight returns the current voting height
+// reported by the node serving HTTP on httpAddr.
+func GetVotingHeight(t *testing.T, httpAddr string, msgAndArgs ...any) uint {
+	t.Helper()
+
+	resp, err := http.Get("http://" + httpAddr + "/blocks/watermark")
+	require.NoError(t, err, msgAndArgs...)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var m map[string]uint
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&m))
+	return m["VotingHeight"]
+}
+
+// RequireVotingHeight polls the watermark of the node serving HTTP on httpAddr
+// until its voting height reaches minHeight,
+// failing the test with msgAndArgs if it does not get there before timeout.
+func RequireVotingHeight(
+	t *testing.T,
+	httpAddr string,
+	minHeight uint,
+	timeout time.Duration,
+	msgAndArgs ...any,
+) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	var maxHeight uint
+	for time.Now().Before(deadline) {
+		maxHeight = GetVotingHeight(t, httpAddr, msgAndArgs...)
+		if maxHeight >= minHeight {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	require.GreaterOrEqual(t, maxHeight, minHeight, msgAndArgs...)
+}
+
+// BlockDataArchive is a test web server that stores block data uploaded with PUT
+// and serves it back with GET,
+// standing in for the web server or CDN behind --g-block-data-upload-url.
+type BlockDataArchive struct {
+	URL string
+
+	mu   sync.Mutex
+	data map[string][]byte
+	gets int
+}
+
+// NewBlockDataArchive starts a new BlockDataArchive
+// that is closed when the test finishes.
+func NewBlockDataArchive(t *testing.T) *BlockDataArchive {
+	t.Helper()
+
+	a := &BlockDataArchive{
+		data: make(map[string][]byte),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		switch req.Method {
+		case http.MethodPut:
+			b, err := io.ReadAll(req.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			a.data[req.URL.Path] = b
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			b, ok := a.data[req.URL.Path]
+			if !ok {
+				http.NotFound(w, req)
+				return
+			}
+			a.gets++
+			_, _ = w.Write(b)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	a.URL = srv.URL
+	return a
+}
+
+// Uploads reports how many distinct block data entries have been uploaded.
+func (a *BlockDataArchive) Uploads() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.data)
+}
+
+// Gets reports how many successful GET requests the archive has served.
+func (a *BlockDataArchive) Gets() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.gets
+}
+
+// ReadSeedAddrs waits for the seed command to write its output file at path,
+// and returns the multiaddrs listed in the file.
+func ReadSeedAddrs(t *testing.T, path string) []string {
+	t.Helper()
+
+	// The seed should start up relatively quickly.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				time.Sleep(20 * time.Millisecond)
+				continue
+			}
+			t.Fatalf("failed to read p2p seed path file %q: %v", path, err)
+		}
+
+		if !bytes.HasSuffix(b, []byte("\n")) {
+			// Partial write; delay and try again.
+			time.Sleep(20 * time.Millisecond)
+			continue
+		}
+
+		return strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	}
+
+	t.Fatalf("seed did not write addresses to %q in time", path)
+	return nil
+}
+
 func NewRootCmd(
 	t *testing.T,
 	log *slog.Logger,
@@ -17,6 +17,7 @@ import (
 
 	"github.com/gordian-engine/gcosmos/internal/copy/gtest"
 	"github.com/gordian-engine/gcosmos/internal/gci"
+	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 )
 
@@ -61,6 +62,140 @@ func TestRootCmd(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNodeKey_initAndShow(t *testing.T) {
+	t.Parallel()
+
+	e := NewRootCmd(t, gtest.NewLogger(t))
+	e.Run("init", "defaultmoniker").NoError(t)
+
+	// Init with the default path prints a valid peer ID.
+	res := e.Run("gordian", "node-key", "init")
+	res.NoError(t)
+	id := strings.TrimSpace(res.Stdout.String())
+	_, err := libp2ppeer.Decode(id)
+	require.NoError(t, err)
+
+	// Show reports the same identity.
+	res = e.Run("gordian", "node-key", "show")
+	res.NoError(t)
+	require.Equal(t, id, strings.TrimSpace(res.Stdout.String()))
+
+	// A second init must not replace the existing identity.
+	res = e.Run("gordian", "node-key", "init")
+	require.Error(t, res.Err)
+
+	res = e.Run("gordian", "node-key", "show")
+	res.NoError(t)
+	require.Equal(t, id, strings.TrimSpace(res.Stdout.String()))
+
+	// An explicit path gets its own independent key.
+	otherPath := filepath.Join(t.TempDir(), "other_key.json")
+	res = e.Run("gordian", "node-key", "init", "--path", otherPath)
+	res.NoError(t)
+	otherID := strings.TrimSpace(res.Stdout.String())
+	require.NotEqual(t, id, otherID)
+
+	res = e.Run("gordian", "node-key", "show", "--path", otherPath)
+	res.NoError(t)
+	require.Equal(t, otherID, strings.TrimSpace(res.Stdout.String()))
+
+	// Showing a missing key is an error rather than generating one.
+	missingPath := filepath.Join(t.TempDir(), "missing_key.json")
+	res = e.Run("gordian", "node-key", "show", "--path", missingPath)
+	require.Error(t, res.Err)
+	_, err = os.Stat(missingPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRootCmd_startWithGordian_nodeBinding(t *testing.T) {
+	if gci.RunCometInsteadOfGordian {
+		t.Skip("skipping due to not testing Gordian")
+	}
+
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := ConfigureChain(t, ctx, ChainConfig{
+		ID:            t.Name(),
+		NVals:         1,
+		StakeStrategy: ConstantStakeStrategy(1_000_000_000),
+	})
+	e := c.RootCmds[0]
+
+	res := e.Run("gordian", "node-key", "init")
+	res.NoError(t)
+	id := strings.TrimSpace(res.Stdout.String())
+
+	e.Run("gordian", "node-key", "bind").NoError(t)
+
+	b, err := os.ReadFile(filepath.Join(e.homeDir, "config", "gordian_node_binding.json"))
+	require.NoError(t, err)
+	var binding struct {
+		ChainID string `json:"chain_id"`
+		PeerID  string `json:"peer_id"`
+	}
+	require.NoError(t, json.Unmarshal(b, &binding))
+	require.Equal(t, t.Name(), binding.ChainID)
+	require.Equal(t, id, binding.PeerID)
+
+	// The start command rejects a binding that does not match the node key,
+	// so reaching consensus confirms the binding was accepted.
+	httpAddr := c.Start(t, ctx, 1).HTTP[0]
+	RequireVotingHeight(t, httpAddr, 3, 10*time.Second)
+}
+
+func TestSeed_persistentIdentity(t *testing.T) {
+	t.Parallel()
+
+	e := NewRootCmd(t, gtest.NewLogger(t))
+	e.Run("init", "defaultmoniker").NoError(t)
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "seed_key.json")
+
+	const announceAddr = "/ip4/203.0.113.7/tcp/26656"
+
+	runSeed := func(outPath string) []string {
+		t.Helper()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		seedDone := make(chan struct{})
+		go func() {
+			defer close(seedDone)
+			_ = e.RunC(
+				ctx, "gordian", "seed", outPath,
+				"--node-key-path", keyPath,
+				"--listen-addrs", "/ip4/127.0.0.1/tcp/0",
+				"--announce-addrs", announceAddr,
+			)
+		}()
+		defer func() {
+			cancel()
+			<-seedDone
+		}()
+
+		return ReadSeedAddrs(t, outPath)
+	}
+
+	// The first run creates the key and announces only the configured address.
+	addrs := runSeed(filepath.Join(dir, "seed1.txt"))
+	require.Len(t, addrs, 1)
+	announced, id, ok := strings.Cut(addrs[0], "/p2p/")
+	require.True(t, ok, "seed address %q missing peer ID", addrs[0])
+	require.Equal(t, announceAddr, announced)
+
+	// The node-key command reads the same key file.
+	res := e.Run("gordian", "node-key", "show", "--path", keyPath)
+	res.NoError(t)
+	require.Equal(t, id, strings.TrimSpace(res.Stdout.String()))
+
+	// A restart with the same key keeps the same peer ID.
+	addrs = runSeed(filepath.Join(dir, "seed2.txt"))
+	require.Equal(t, []string{announceAddr + "/p2p/" + id}, addrs)
+}
+
 func TestRootCmd_startWithGordian_singleValidator(t *testing.T) {
 	t.Parallel()
 
@@ -104,6 +239,74 @@ func TestRootCmd_startWithGordian_singleValidator(t *testing.T) {
 	}
 }
 
+func TestRootCmd_startWithGordian_remoteSigner(t *testing.T) {
+	if gci.RunCometInsteadOfGordian {
+		t.Skip("skipping due to not testing Gordian")
+	}
+
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := ConfigureChain(t, ctx, ChainConfig{
+		ID:            t.Name(),
+		NVals:         1,
+		StakeStrategy: ConstantStakeStrategy(1_000_000_000),
+	})
+
+	// Run the stand-in signer from the validator's home directory,
+	// so that it picks up the validator key.
+	signerAddr := "unix://" + filepath.Join(t.TempDir(), "signer.sock")
+	signerDone := make(chan struct{})
+	go func() {
+		defer close(signerDone)
+		_ = c.RootCmds[0].RunC(ctx, "gordian", "signer", "--listen", signerAddr)
+	}()
+	t.Cleanup(func() {
+		<-signerDone
+	})
+
+	// The validator fails to start if the signer isn't listening yet.
+	sockPath := strings.TrimPrefix(signerAddr, "unix://")
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(sockPath); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	c.ExtraStartArgs = func(int) []string {
+		return []string{"--g-remote-signer-addr", signerAddr}
+	}
+	httpAddr := c.Start(t, ctx, 1).HTTP[0]
+
+	u := "http://" + httpAddr + "/blocks/watermark"
+
+	deadline = time.Now().Add(10 * time.Second)
+	var maxHeight uint
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(u)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var m map[string]uint
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&m))
+		resp.Body.Close()
+
+		maxHeight = m["VotingHeight"]
+		if maxHeight < 3 {
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+
+		break
+	}
+
+	require.GreaterOrEqual(t, maxHeight, uint(3))
+}
+
 func TestRootCmd_startWithGordian_multipleValidators(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping slow test in short mode")
@@ -147,29 +350,10 @@ func TestRootCmd_startWithGordian_multipleValidators(t *testing.T) {
 		// Gratuitous deadline to get the voting height,
 		// because the first proposed block is likely to time out
 		// due to libp2p settle time.
-		u := "http://" + httpAddrs[i] + "/blocks/watermark"
-		deadline := time.Now().Add(30 * time.Second)
-		var maxHeight uint
-		for time.Now().Before(deadline) {
-			resp, err := http.Get(u)
-			require.NoErrorf(t, err, "failed to get the watermark for validator %d/%d", i+1, totalVals)
-			require.Equal(t, http.StatusOK, resp.StatusCode)
-
-			var m map[string]uint
-			require.NoError(t, json.NewDecoder(resp.Body).Decode(&m))
-			resp.Body.Close()
-
-			maxHeight = m["VotingHeight"]
-			if maxHeight < 4 {
-				time.Sleep(100 * time.Millisecond)
-				continue
-			}
-
-			// We got at least to height 4, so quit the loop.
-			break
-		}
-
-		require.GreaterOrEqualf(t, maxHeight, uint(4), "checking max block height on validator at index %d", i)
+		RequireVotingHeight(
+			t, httpAddrs[i], 4, 30*time.Second,
+			"checking max block height on validator at index %d", i,
+		)
 	}
 
 	t.Run("adding a new validator catches up", func(t *testing.T) {
@@ -181,55 +365,27 @@ func TestRootCmd_startWithGordian_multipleValidators(t *testing.T) {
 		defer cancel()
 
 		lateHTTPAddrFile := AddLateNode(t, localCtx, chainID, c.CanonicalGenesisPath, ca.P2PSeedPath)
-		var httpAddr string
-
-		deadline := time.Now().Add(10 * time.Second)
-		for time.Now().Before(deadline) {
-			b, err := os.ReadFile(lateHTTPAddrFile)
-			if err != nil {
-				time.Sleep(100 * time.Millisecond)
-				continue
-			}
-
-			s, ok := strings.CutSuffix(string(b), "\n")
-			if !ok {
-				time.Sleep(100 * time.Millisecond)
-				continue
-			}
-
-			httpAddr = s
-			break
-		}
-
-		if httpAddr == "" {
-			t.Fatal("did not read http address from file before deadline")
-		}
+		httpAddr := ReadLateNodeHTTPAddr(t, lateHTTPAddrFile)
 
-		u := "http://" + httpAddr + "/blocks/watermark"
 		// TODO: we might need to delay until we get a non-error HTTP response.
+		RequireVotingHeight(t, httpAddr, 4, 10*time.Second, "late-started server did not reach minimum height")
+	})
 
-		deadline = time.Now().Add(10 * time.Second)
-		var maxHeight uint
-		for time.Now().Before(deadline) {
-			resp, err := http.Get(u)
-			require.NoError(t, err)
-			require.Equal(t, http.StatusOK, resp.StatusCode)
-
-			var m map[string]uint
-			require.NoError(t, json.NewDecoder(resp.Body).Decode(&m))
-			resp.Body.Close()
+	t.Run("full node without a signer follows the chain", func(t *testing.T) {
+		if gci.RunCometInsteadOfGordian {
+			t.Skip("skipping due to not testing Gordian")
+		}
 
-			maxHeight = m["VotingHeight"]
-			if maxHeight < 4 {
-				time.Sleep(100 * time.Millisecond)
-				continue
-			}
+		localCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
 
-			// We got at least to height 4, so quit the loop.
-			break
-		}
+		fullHTTPAddrFile := AddLateNode(
+			t, localCtx, chainID, c.CanonicalGenesisPath, ca.P2PSeedPath,
+			"--g-mode", "full",
+		)
+		httpAddr := ReadLateNodeHTTPAddr(t, fullHTTPAddrFile)
 
-		require.GreaterOrEqual(t, maxHeight, uint(4), "late-started server did not reach minimum height")
+		RequireVotingHeight(t, httpAddr, 4, 10*time.Second, "full node did not reach minimum height")
 	})
 }
 
@@ -946,6 +1102,13 @@ func TestTx_multiple_simpleSend(t *testing.T) {
 		FixedAccountInitialBalance: 10_000,
 	})
 
+	// Every validator also uploads its proposed block data to an archive,
+	// so that a header-only node can catch up from the archive later.
+	archive := NewBlockDataArchive(t)
+	c.ExtraStartArgs = func(int) []string {
+		return []string{"--g-block-data-upload-url", archive.URL}
+	}
+
 	ca := c.Start(t, ctx, totalVals)
 	httpAddrs := ca.HTTP
 
@@ -1177,6 +1340,46 @@ func TestTx_multiple_simpleSend(t *testing.T) {
 		require.Equal(t, "10100", newBalance.Balance.Amount, "validator reported wrong receiver balance") // Was at 10k, added 100.
 	})
 
+	t.Run("header-only node catches up with block data from archive", func(t *testing.T) {
+		if gci.RunCometInsteadOfGordian {
+			t.Skip("skipping due to not testing Gordian")
+		}
+
+		// The block with the send transaction was uploaded by its proposer.
+		require.NotZero(t, archive.Uploads())
+
+		// Validators may have retrieved the proposed block data from the archive too.
+		getsBefore := archive.Gets()
+
+		localCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		lateHTTPAddrFile := AddLateNode(
+			t, localCtx, chainID, c.CanonicalGenesisPath, ca.P2PSeedPath,
+			"--g-catchup-block-data-url", archive.URL,
+		)
+
+		targetHeight := GetVotingHeight(t, httpAddrs[0])
+
+		httpAddr := ReadLateNodeHTTPAddr(t, lateHTTPAddrFile)
+		RequireVotingHeight(
+			t, httpAddr, targetHeight, 10*time.Second,
+			"header-only server did not reach target height of earlier validator",
+		)
+
+		// Peers only served the headers,
+		// so the transaction's block data must have come from the archive.
+		require.Greater(t, archive.Gets(), getsBefore)
+
+		resp, err := http.Get("http://" + httpAddr + "/debug/accounts/" + c.FixedAddresses[0] + "/balance")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var newBalance balance
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&newBalance))
+		resp.Body.Close()
+		require.Equal(t, "9900", newBalance.Balance.Amount, "header-only node reported wrong sender balance") // Was at 10k, subtracted 100.
+	})
+
 	// See the "hacking on a demo" section of the README for details on what we are doing here.
 	defer func() {
 		if os.Getenv("HACK_TEST_DEMO") == "" {